@@ -1,23 +1,127 @@
 package api
 
 import (
-	"log"
+	"context"
+	"net"
 	"os"
+	"sync"
 
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/proto_api"
 	"github.com/aawadall/simple-kv/types"
+	"google.golang.org/grpc"
 )
 
 // gRPC API for the application
 type GrpcApi struct {
-	logger *log.Logger
-	server types.Server
+	proto_api.UnimplementedKeyValueServiceServer
+	proto_api.UnimplementedServerServiceServer
+
+	logger     *health.Logger
+	server     types.Server
+	grpcServer *grpc.Server
+	address    string
+	listener   net.Listener
+	// closed on shutdown, ending watch streams
+	stopping     chan struct{}
+	stoppingOnce *sync.Once
+	// limits the calls of each tenant, none when nil
+	limiter *RateLimiter
+	// counts calls, none when nil
+	metrics *requestMetrics
 }
 
 // NewGrpcApi creates a new gRPC API
 func NewGrpcApi(server types.Server) *GrpcApi {
 	// TODO: Add configuration
 	return &GrpcApi{
-		logger: log.New(os.Stdout, "GrpcApi ", log.LstdFlags),
-		server: server,
+		logger:       health.Default().Component("grpc"),
+		server:       server,
+		stopping:     make(chan struct{}),
+		stoppingOnce: &sync.Once{},
+	}
+}
+
+// SetRateLimiter sets the limiter calls are taken from
+func (api *GrpcApi) SetRateLimiter(limiter *RateLimiter) {
+	api.limiter = limiter
+}
+
+// SetMetrics sets the registry counting calls
+func (api *GrpcApi) SetMetrics(registry *health.Registry) {
+	api.metrics = newGRPCMetrics(registry)
+}
+
+// SetAddress sets the address the gRPC API listens on, overriding KV_GRPC_PORT
+func (api *GrpcApi) SetAddress(address string) {
+	api.address = address
+}
+
+// Addr returns the address the gRPC API listens on, the address set until it listens
+func (api *GrpcApi) Addr() string {
+	if api.listener != nil {
+		return api.listener.Addr().String()
+	}
+	return api.address
+}
+
+// Serve starts listening for gRPC requests, returning once it listens
+func (api *GrpcApi) Serve() error {
+	api.logger.Debug("Starting gRPC API")
+
+	// check if GRPC_PORT is set, unless the server set an address
+	address := api.address
+	if address == "" {
+		if port, ok := os.LookupEnv("KV_GRPC_PORT"); ok {
+			address = ":" + port
+		} else {
+			address = ":9090"
+		}
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	api.listener = listener
+
+	api.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(api.unaryMeasure, api.unaryLog, api.unaryTenantScope),
+		grpc.ChainStreamInterceptor(api.streamMeasure, api.streamLog, api.streamTenantScope),
+	)
+	proto_api.RegisterKeyValueServiceServer(api.grpcServer, api)
+	proto_api.RegisterServerServiceServer(api.grpcServer, api)
+
+	go func() {
+		err := api.grpcServer.Serve(listener)
+		if err != nil {
+			api.logger.Error("gRPC API stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the gRPC API, waiting for in-flight requests until the context
+// ends and then closing the connections left
+func (api *GrpcApi) Shutdown(ctx context.Context) error {
+	api.logger.Debug("Stopping gRPC API")
+	if api.grpcServer == nil {
+		return nil
+	}
+
+	// watch streams never finish on their own
+	api.stoppingOnce.Do(func() { close(api.stopping) })
+
+	stopped := make(chan struct{})
+	go func() {
+		api.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		api.grpcServer.Stop()
+		return ctx.Err()
 	}
 }
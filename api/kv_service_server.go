@@ -2,42 +2,581 @@ package api
 
 import (
 	"context"
+	"errors"
+	"io"
+	"time"
 
 	"github.com/aawadall/simple-kv/proto_api"
+	"github.com/aawadall/simple-kv/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 // GrpcApi must be embedded to have forward compatible implementations.
 
-func (GrpcApi) Get(context.Context, *proto_api.GetRequest) (*proto_api.KeyValueRecord, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+// downloadMessageSize - data carried by each Download message
+const downloadMessageSize = 64 << 10
+
+func (api GrpcApi) Get(ctx context.Context, req *proto_api.GetRequest) (*proto_api.KeyValueRecord, error) {
+	asOf, hasAsOf, err := parseAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var value interface{}
+	var metadata map[string]string
+	if hasAsOf {
+		value, err = api.serverFor(ctx).GetAsOf(req.GetKey(), asOf)
+		if err == nil {
+			metadata, err = api.serverFor(ctx).GetAllMetadataAsOf(req.GetKey(), asOf)
+		}
+	} else {
+		value, err = api.serverFor(ctx).Get(req.GetKey())
+		if err == nil {
+			metadata, err = api.serverFor(ctx).GetAllMetadata(req.GetKey())
+		}
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	record := &proto_api.KeyValueRecord{
+		Key:      req.GetKey(),
+		Metadata: metadata,
+	}
+	if value != nil {
+		record.Value = value.([]byte)
+	}
+	return record, nil
+}
+func (api GrpcApi) Set(ctx context.Context, req *proto_api.SetRequest) (*proto_api.SetResponse, error) {
+	err := api.serverFor(ctx).Set(req.GetKey(), req.GetValue())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
 }
-func (GrpcApi) Set(context.Context, *proto_api.SetRequest) (*proto_api.SetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+func (api GrpcApi) Delete(ctx context.Context, req *proto_api.DeleteRequest) (*proto_api.DeleteResponse, error) {
+	err := api.serverFor(ctx).Delete(req.GetKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.DeleteResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
 }
-func (GrpcApi) Delete(context.Context, *proto_api.DeleteRequest) (*proto_api.DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (api GrpcApi) SetMetadata(ctx context.Context, req *proto_api.SetMetadataRequest) (*proto_api.SetMetadataResponse, error) {
+	err := api.serverFor(ctx).SetMetadata(req.GetKey(), req.GetMetadataKey(), req.GetMetadataValue())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetMetadataResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) DeleteMetadata(ctx context.Context, req *proto_api.DeleteMetadataRequest) (*proto_api.DeleteMetadataResponse, error) {
+	err := api.serverFor(ctx).DeleteMetadata(req.GetKey(), req.GetMetadataKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.DeleteMetadataResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) GetAllMetadata(ctx context.Context, req *proto_api.GetAllMetadataRequest) (*proto_api.GetAllMetadataResponse, error) {
+	asOf, hasAsOf, err := parseAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var metadata map[string]string
+	if hasAsOf {
+		metadata, err = api.serverFor(ctx).GetAllMetadataAsOf(req.GetKey(), asOf)
+	} else {
+		metadata, err = api.serverFor(ctx).GetAllMetadata(req.GetKey())
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto_api.GetAllMetadataResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Metadata: metadata,
+	}, nil
 }
-func (GrpcApi) SetMetadata(context.Context, *proto_api.SetMetadataRequest) (*proto_api.SetMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetMetadata not implemented")
+func (api GrpcApi) Find(ctx context.Context, req *proto_api.FindRequest) (*proto_api.FindResponse, error) {
+	asOf, hasAsOf, err := parseAsOf(req.GetAsOf())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var keys []string
+	if hasAsOf {
+		keys, err = api.serverFor(ctx).FindAsOf(req.GetPartialKey(), asOf)
+	} else {
+		keys, err = api.serverFor(ctx).Find(req.GetPartialKey())
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &proto_api.FindResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Records:  keys,
+	}, nil
+}
+func (api GrpcApi) FindByMetadata(ctx context.Context, req *proto_api.FindByMetadataRequest) (*proto_api.FindByMetadataResponse, error) {
+	keys, err := api.serverFor(ctx).FindByMetadata(req.GetQuery())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.FindByMetadataResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Records:  keys,
+	}, nil
+}
+func (api GrpcApi) Watch(req *proto_api.WatchRequest, stream proto_api.KeyValueService_WatchServer) error {
+	events, cancel, err := api.serverFor(stream.Context()).Watch(types.WatchFilter{
+		Key:           req.GetKey(),
+		Prefix:        req.GetPrefix(),
+		MetadataQuery: req.GetMetadataQuery(),
+		FromRevision:  req.GetFromRevision(),
+	})
+	if errors.Is(err, types.ErrCompacted) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-api.stopping:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "watcher fell behind, resume from the last received revision")
+			}
+			err := stream.Send(&proto_api.WatchEvent{
+				Revision:  event.Revision,
+				Key:       event.Key,
+				Operation: event.Operation,
+				Version:   int64(event.Version),
+				Value:     event.Value,
+				Metadata:  event.Metadata,
+				Timestamp: event.Timestamp.Format(time.RFC3339Nano),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+func (api GrpcApi) Increment(ctx context.Context, req *proto_api.IncrementRequest) (*proto_api.IncrementResponse, error) {
+	options := types.CounterOptions{Initial: req.GetInitial()}
+	if req.GetHasMin() {
+		min := req.GetMin()
+		options.Min = &min
+	}
+	if req.GetHasMax() {
+		max := req.GetMax()
+		options.Max = &max
+	}
+
+	value, err := api.serverFor(ctx).Increment(req.GetKey(), req.GetDelta(), options)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.IncrementResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Value:    value,
+	}, nil
 }
-func (GrpcApi) DeleteMetadata(context.Context, *proto_api.DeleteMetadataRequest) (*proto_api.DeleteMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteMetadata not implemented")
+func (api GrpcApi) NextSequence(ctx context.Context, req *proto_api.NextSequenceRequest) (*proto_api.NextSequenceResponse, error) {
+	value, err := api.serverFor(ctx).NextSequence(req.GetKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.NextSequenceResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Value:    value,
+	}, nil
 }
-func (GrpcApi) GetAllMetadata(context.Context, *proto_api.GetAllMetadataRequest) (*proto_api.GetAllMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllMetadata not implemented")
+func (api GrpcApi) ListPush(ctx context.Context, req *proto_api.ListPushRequest) (*proto_api.ListPushResponse, error) {
+	length, err := api.serverFor(ctx).ListPush(req.GetKey(), req.GetValues(), req.GetFront())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.ListPushResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Length:   int64(length),
+	}, nil
 }
-func (GrpcApi) Find(context.Context, *proto_api.FindRequest) (*proto_api.FindResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Find not implemented")
+func (api GrpcApi) ListPop(ctx context.Context, req *proto_api.ListPopRequest) (*proto_api.ListPopResponse, error) {
+	value, err := api.serverFor(ctx).ListPop(req.GetKey(), req.GetFront())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.ListPopResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Value:    value,
+	}, nil
 }
-func (GrpcApi) FindByMetadata(context.Context, *proto_api.FindByMetadataRequest) (*proto_api.FindByMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FindByMetadata not implemented")
+func (api GrpcApi) ListRange(ctx context.Context, req *proto_api.ListRangeRequest) (*proto_api.ListRangeResponse, error) {
+	values, err := api.serverFor(ctx).ListRange(req.GetKey(), int(req.GetStart()), int(req.GetStop()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.ListRangeResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Values:   values,
+	}, nil
+}
+func (api GrpcApi) SetAdd(ctx context.Context, req *proto_api.SetAddRequest) (*proto_api.SetAddResponse, error) {
+	added, err := api.serverFor(ctx).SetAdd(req.GetKey(), req.GetMembers())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetAddResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Added:    int64(added),
+	}, nil
+}
+func (api GrpcApi) SetRemove(ctx context.Context, req *proto_api.SetRemoveRequest) (*proto_api.SetRemoveResponse, error) {
+	removed, err := api.serverFor(ctx).SetRemove(req.GetKey(), req.GetMembers())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetRemoveResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Removed:  int64(removed),
+	}, nil
+}
+func (api GrpcApi) SetMembers(ctx context.Context, req *proto_api.SetMembersRequest) (*proto_api.SetMembersResponse, error) {
+	members, err := api.serverFor(ctx).SetMembers(req.GetKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetMembersResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Members:  members,
+	}, nil
+}
+func (api GrpcApi) HashSet(ctx context.Context, req *proto_api.HashSetRequest) (*proto_api.HashSetResponse, error) {
+	err := api.serverFor(ctx).HashSet(req.GetKey(), req.GetField(), req.GetValue())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.HashSetResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+	}, nil
+}
+func (api GrpcApi) HashGet(ctx context.Context, req *proto_api.HashGetRequest) (*proto_api.HashGetResponse, error) {
+	value, err := api.serverFor(ctx).HashGet(req.GetKey(), req.GetField())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.HashGetResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Value:    value,
+	}, nil
+}
+func (api GrpcApi) HashDelete(ctx context.Context, req *proto_api.HashDeleteRequest) (*proto_api.HashDeleteResponse, error) {
+	err := api.serverFor(ctx).HashDelete(req.GetKey(), req.GetField())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.HashDeleteResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+	}, nil
+}
+func (api GrpcApi) HashGetAll(ctx context.Context, req *proto_api.HashGetAllRequest) (*proto_api.HashGetAllResponse, error) {
+	fields, err := api.serverFor(ctx).HashGetAll(req.GetKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.HashGetAllResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Fields:   fields,
+	}, nil
+}
+func (api GrpcApi) SetDocument(ctx context.Context, req *proto_api.SetDocumentRequest) (*proto_api.SetDocumentResponse, error) {
+	err := api.serverFor(ctx).SetDocument(req.GetKey(), req.GetDocument())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.SetDocumentResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+	}, nil
+}
+func (api GrpcApi) GetDocument(ctx context.Context, req *proto_api.GetDocumentRequest) (*proto_api.GetDocumentResponse, error) {
+	document, err := api.serverFor(ctx).GetDocument(req.GetKey(), req.GetPath())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.GetDocumentResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Document: document,
+	}, nil
+}
+func (api GrpcApi) PatchDocument(ctx context.Context, req *proto_api.PatchDocumentRequest) (*proto_api.PatchDocumentResponse, error) {
+	document, err := api.serverFor(ctx).PatchDocument(req.GetKey(), req.GetPatch(), req.GetMerge())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.PatchDocumentResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Document: document,
+	}, nil
+}
+func (api GrpcApi) FindByDocument(ctx context.Context, req *proto_api.FindByDocumentRequest) (*proto_api.FindByDocumentResponse, error) {
+	keys, err := api.serverFor(ctx).FindByDocument(req.GetQuery())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.FindByDocumentResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Records:  keys,
+	}, nil
+}
+func (api GrpcApi) Upload(stream proto_api.KeyValueService_UploadServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no key provided")
+	}
+	if err != nil {
+		return err
+	}
+
+	// feed the received data to the server as one stream
+	reader, writer := io.Pipe()
+	go func() {
+		data := first.GetData()
+		for {
+			if _, err := writer.Write(data); err != nil {
+				return
+			}
+			req, err := stream.Recv()
+			if err == io.EOF {
+				writer.Close()
+				return
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+			data = req.GetData()
+		}
+	}()
+
+	size, err := api.serverFor(stream.Context()).SetStream(first.GetKey(), reader)
+	reader.Close()
+	if err != nil {
+		return grpcError(err)
+	}
+	return stream.SendAndClose(&proto_api.UploadResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Size:     size,
+	})
+}
+func (api GrpcApi) Download(req *proto_api.DownloadRequest, stream proto_api.KeyValueService_DownloadServer) error {
+	reader, err := api.serverFor(stream.Context()).OpenValue(req.GetKey())
+	if err != nil {
+		return grpcError(err)
+	}
+
+	buf := make([]byte, downloadMessageSize)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&proto_api.DownloadResponse{Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return grpcError(err)
+		}
+	}
+}
+func (api GrpcApi) LeaseGrant(ctx context.Context, req *proto_api.LeaseGrantRequest) (*proto_api.LeaseResponse, error) {
+	lease, err := api.serverFor(ctx).GrantLease(time.Duration(req.GetTtl()) * time.Second)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return leaseResponse(lease), nil
+}
+func (api GrpcApi) LeaseKeepAlive(ctx context.Context, req *proto_api.LeaseIdRequest) (*proto_api.LeaseResponse, error) {
+	lease, err := api.serverFor(ctx).KeepAliveLease(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return leaseResponse(lease), nil
+}
+func (api GrpcApi) LeaseGet(ctx context.Context, req *proto_api.LeaseIdRequest) (*proto_api.LeaseResponse, error) {
+	lease, err := api.serverFor(ctx).GetLease(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return leaseResponse(lease), nil
+}
+func (api GrpcApi) LeaseRevoke(ctx context.Context, req *proto_api.LeaseIdRequest) (*proto_api.LeaseRevokeResponse, error) {
+	err := api.serverFor(ctx).RevokeLease(req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.LeaseRevokeResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) LeaseAttach(ctx context.Context, req *proto_api.LeaseAttachRequest) (*proto_api.LeaseAttachResponse, error) {
+	err := api.serverFor(ctx).AttachLease(req.GetKey(), req.GetId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.LeaseAttachResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) Lock(ctx context.Context, req *proto_api.LockRequest) (*proto_api.LockResponse, error) {
+	token, err := api.serverFor(ctx).Lock(req.GetName(), req.GetLeaseId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.LockResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Token:    token,
+	}, nil
+}
+func (api GrpcApi) Unlock(ctx context.Context, req *proto_api.UnlockRequest) (*proto_api.UnlockResponse, error) {
+	err := api.serverFor(ctx).Unlock(req.GetName(), req.GetToken())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.UnlockResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) BulkDelete(ctx context.Context, req *proto_api.BulkDeleteRequest) (*proto_api.BulkResponse, error) {
+	var result types.BulkResult
+	var err error
+	switch {
+	case req.GetPrefix() != "" && req.GetMetadataQuery() == "":
+		result, err = api.serverFor(ctx).DeletePrefix(req.GetPrefix(), req.GetDryRun())
+	case req.GetMetadataQuery() != "" && req.GetPrefix() == "":
+		result, err = api.serverFor(ctx).DeleteByMetadata(req.GetMetadataQuery(), req.GetDryRun())
+	default:
+		return nil, status.Error(codes.InvalidArgument, "one of prefix and metadata query is required")
+	}
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return bulkResponse(result), nil
+}
+func (api GrpcApi) Copy(ctx context.Context, req *proto_api.CopyRequest) (*proto_api.BulkResponse, error) {
+	result, err := api.serverFor(ctx).Copy(req.GetSource(), req.GetDestination(), copyOptions(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return bulkResponse(result), nil
+}
+func (api GrpcApi) Rename(ctx context.Context, req *proto_api.CopyRequest) (*proto_api.BulkResponse, error) {
+	result, err := api.serverFor(ctx).Rename(req.GetSource(), req.GetDestination(), copyOptions(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return bulkResponse(result), nil
+}
+func (api GrpcApi) ListFlags(ctx context.Context, req *proto_api.ListFlagsRequest) (*proto_api.ListFlagsResponse, error) {
+	flags, err := api.server.ListFlags()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	messages := make([]*proto_api.FeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		messages = append(messages, flagMessage(flag))
+	}
+	return &proto_api.ListFlagsResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Flags:    messages,
+	}, nil
+}
+func (api GrpcApi) GetFlag(ctx context.Context, req *proto_api.FlagNameRequest) (*proto_api.FlagResponse, error) {
+	flag, err := api.server.GetFlag(req.GetName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.FlagResponse{Response: &proto_api.UniversalResponse{Success: true}, Flag: flagMessage(flag)}, nil
+}
+func (api GrpcApi) SetFlag(ctx context.Context, req *proto_api.FeatureFlag) (*proto_api.FlagResponse, error) {
+	flag := types.FeatureFlag{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Enabled:     req.GetEnabled(),
+		Rollout:     int(req.GetRollout()),
+		Tenants:     req.GetTenants(),
+	}
+	if err := api.server.SetFlag(flag); err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.FlagResponse{Response: &proto_api.UniversalResponse{Success: true}, Flag: flagMessage(flag)}, nil
+}
+func (api GrpcApi) DeleteFlag(ctx context.Context, req *proto_api.FlagNameRequest) (*proto_api.DeleteFlagResponse, error) {
+	if err := api.server.DeleteFlag(req.GetName()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.DeleteFlagResponse{Response: &proto_api.UniversalResponse{Success: true}}, nil
+}
+func (api GrpcApi) EvaluateFlag(ctx context.Context, req *proto_api.EvaluateFlagRequest) (*proto_api.EvaluateFlagResponse, error) {
+	enabled, err := api.serverFor(ctx).EvaluateFlag(req.GetName(), req.GetSubject())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.EvaluateFlagResponse{Response: &proto_api.UniversalResponse{Success: true}, Enabled: enabled}, nil
+}
+func (api GrpcApi) GetConfig(ctx context.Context, req *proto_api.GetConfigRequest) (*proto_api.GetConfigResponse, error) {
+	report, err := api.server.GetConfig()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	settings := make([]*proto_api.ConfigSetting, 0, len(report.Settings))
+	for _, setting := range report.Settings {
+		settings = append(settings, &proto_api.ConfigSetting{
+			Name:   setting.Name,
+			Value:  setting.Value,
+			Source: setting.Source,
+			Secret: setting.Secret,
+		})
+	}
+	return &proto_api.GetConfigResponse{
+		Response: &proto_api.UniversalResponse{Success: true},
+		Settings: settings,
+		Pending:  report.Pending,
+	}, nil
 }
 func (GrpcApi) mustEmbedGrpcApi() {}
 
-func (GrpcApi) GetStatus(context.Context, *proto_api.GetStatusRequest) (*proto_api.GetStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+func (api GrpcApi) GetStatus(ctx context.Context, req *proto_api.GetStatusRequest) (*proto_api.GetStatusResponse, error) {
+	serverStatus, err := api.server.GetStatus()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &proto_api.GetStatusResponse{
+		CommonResponse: &proto_api.UniversalResponse{Success: true},
+		Status: &proto_api.ServerStatus{
+			Version:       serverStatus.Version,
+			Status:        serverStatus.State,
+			UptimeSeconds: serverStatus.UptimeSeconds,
+			Records:       int64(serverStatus.Records),
+			Memory: &proto_api.MemoryStatus{
+				AllocBytes: serverStatus.Memory.AllocBytes,
+				SysBytes:   serverStatus.Memory.SysBytes,
+				GcCycles:   serverStatus.Memory.GCCycles,
+				Goroutines: int64(serverStatus.Memory.Goroutines),
+			},
+			Persistence: &proto_api.PersistenceStatus{
+				Driver:         serverStatus.Persistence.Driver,
+				LastSync:       serverStatus.Persistence.LastSync,
+				LastSyncMillis: serverStatus.Persistence.LastSyncMillis,
+				LastSyncError:  serverStatus.Persistence.LastSyncError,
+			},
+			Listeners:   serverStatus.Listeners,
+			ClusterRole: serverStatus.ClusterRole,
+		},
+	}, nil
 }
 func (GrpcApi) Start(context.Context, *proto_api.StartRequest) (*proto_api.StartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
@@ -45,3 +584,71 @@ func (GrpcApi) Start(context.Context, *proto_api.StartRequest) (*proto_api.Start
 func (GrpcApi) Stop(context.Context, *proto_api.StopRequest) (*proto_api.StopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+
+// Helper Functions
+// leaseResponse - converts a lease to its message
+func leaseResponse(lease types.Lease) *proto_api.LeaseResponse {
+	return &proto_api.LeaseResponse{
+		Response:  &proto_api.UniversalResponse{Success: true},
+		Id:        lease.ID,
+		Ttl:       lease.TTL,
+		ExpiresAt: lease.ExpiresAt.Format(time.RFC3339Nano),
+		Keys:      lease.Keys,
+		Locks:     lease.Locks,
+	}
+}
+
+// flagMessage - converts a feature flag to its message
+func flagMessage(flag types.FeatureFlag) *proto_api.FeatureFlag {
+	return &proto_api.FeatureFlag{
+		Name:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Rollout:     int32(flag.Rollout),
+		Tenants:     flag.Tenants,
+	}
+}
+
+// copyOptions - reads the options of a copy or rename
+func copyOptions(req *proto_api.CopyRequest) types.CopyOptions {
+	return types.CopyOptions{
+		Prefix:    req.GetPrefix(),
+		History:   req.GetHistory(),
+		Overwrite: req.GetOverwrite(),
+		DryRun:    req.GetDryRun(),
+	}
+}
+
+// bulkResponse - converts the result of a bulk operation to its message
+func bulkResponse(result types.BulkResult) *proto_api.BulkResponse {
+	return &proto_api.BulkResponse{
+		Response:     &proto_api.UniversalResponse{Success: true},
+		Count:        int64(result.Count),
+		Keys:         result.Keys,
+		Destinations: result.Destinations,
+		DryRun:       result.DryRun,
+	}
+}
+
+// grpcError - maps server errors to gRPC status errors
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrBeforeHistory), errors.Is(err, types.ErrEmptyCollection), errors.Is(err, types.ErrPathNotFound),
+		errors.Is(err, types.ErrLeaseNotFound), errors.Is(err, types.ErrFlagNotFound), errors.Is(err, types.ErrKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, types.ErrTypeMismatch), errors.Is(err, types.ErrOutOfBounds), errors.Is(err, types.ErrPatchTestFailed),
+		errors.Is(err, types.ErrLockHeld), errors.Is(err, types.ErrLockNotHeld):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, types.ErrInvalidDocument), errors.Is(err, types.ErrInvalidSchema), errors.Is(err, types.ErrSchemaViolation),
+		errors.Is(err, types.ErrValueTooLarge), errors.Is(err, types.ErrInvalidFlag), errors.Is(err, types.ErrInvalidKey):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrKeyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, types.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, types.ErrHookRejected):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
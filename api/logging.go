@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Request logging
+// every request gets a logger carrying its request ID, tenant and key, the ID is
+// taken from the X-Request-ID header or metadata or generated, and sent back to
+// REST clients, a line is logged when the request ends, probes and scrapes at
+// debug so they do not drown the others
+
+// requestIDHeader - header and metadata carrying the ID of a request
+const requestIDHeader = "X-Request-ID"
+
+// quietRoutes - routes logged at debug
+var quietRoutes = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// SetLogger sets the logger of the REST API, before it is started
+func (api *RestApi) SetLogger(logger *health.Logger) {
+	api.logger = logger
+}
+
+// SetLogger sets the logger of the gRPC API, before it is served
+func (api *GrpcApi) SetLogger(logger *health.Logger) {
+	api.logger = logger
+}
+
+// logRequests - REST middleware scoping a logger to the request and logging
+// the request once served
+func (api *RestApi) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		vars := mux.Vars(r)
+		tenant := vars["tenant"]
+		if tenant == "" {
+			tenant = r.Header.Get(tenantHeader)
+		}
+		logger := api.logger.With("request_id", id, "tenant", limitedTenant(tenant))
+		if key, ok := vars["key"]; ok {
+			logger = logger.With("key", key)
+		}
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		started := time.Now()
+		next.ServeHTTP(recorder, r.WithContext(health.WithLogger(r.Context(), logger)))
+
+		level := health.LevelInfo
+		if quietRoutes[route] {
+			level = health.LevelDebug
+		}
+		logger.Log(level, "Request served", "method", r.Method, "route", route,
+			"status", recorder.status, "duration", time.Since(started))
+	})
+}
+
+// log - the logger of a REST request
+func (api *RestApi) log(r *http.Request) *health.Logger {
+	return health.LoggerFrom(r.Context(), api.logger)
+}
+
+// unaryLog - gRPC interceptor scoping a logger to unary calls and logging them
+func (api *GrpcApi) unaryLog(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	logger := api.callLogger(ctx, info.FullMethod)
+	started := time.Now()
+	resp, err := handler(health.WithLogger(ctx, logger), req)
+	logCall(logger, started, err)
+	return resp, err
+}
+
+// streamLog - gRPC interceptor scoping a logger to streams and logging them
+func (api *GrpcApi) streamLog(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	logger := api.callLogger(stream.Context(), info.FullMethod)
+	started := time.Now()
+	err := handler(srv, &scopedStream{ServerStream: stream, ctx: health.WithLogger(stream.Context(), logger)})
+	logCall(logger, started, err)
+	return err
+}
+
+// callLogger - a logger carrying the request ID, method and tenant of a call
+func (api *GrpcApi) callLogger(ctx context.Context, method string) *health.Logger {
+	id, tenant := "", ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			id = values[0]
+		}
+		if values := md.Get(tenantHeader); len(values) > 0 {
+			tenant = values[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	return api.logger.With("request_id", id, "method", method, "tenant", limitedTenant(tenant))
+}
+
+// Helper Functions
+// logCall - logs a finished gRPC call
+func logCall(logger *health.Logger, started time.Time, err error) {
+	logger.Info("Call served", "code", status.Code(err).String(), "duration", time.Since(started))
+}
+
+// newRequestID - a random request ID
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Request metrics
+// REST requests are counted by route template, so a key in the path does not
+// make a series of its own, gRPC calls by full method, rejected requests
+// included
+
+// requestMetrics - request counts and latencies of an API
+type requestMetrics struct {
+	requests health.Counter
+	duration health.Histogram
+}
+
+// newRESTMetrics - registers the metrics of the REST API
+func newRESTMetrics(registry *health.Registry) *requestMetrics {
+	return &requestMetrics{
+		requests: registry.Counter("kv_http_requests_total", "REST requests by route, method and status code.", "route", "method", "code"),
+		duration: registry.Histogram("kv_http_request_duration_seconds", "Latency of REST requests by route and method.", health.DefaultBuckets, "route", "method"),
+	}
+}
+
+// newGRPCMetrics - registers the metrics of the gRPC API
+func newGRPCMetrics(registry *health.Registry) *requestMetrics {
+	return &requestMetrics{
+		requests: registry.Counter("kv_grpc_requests_total", "gRPC calls by method and status code.", "method", "code"),
+		duration: registry.Histogram("kv_grpc_request_duration_seconds", "Latency of gRPC calls by method.", health.DefaultBuckets, "method"),
+	}
+}
+
+// measure - REST middleware counting requests and their latency
+func (api *RestApi) measure(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if api.metrics == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		started := time.Now()
+		next.ServeHTTP(recorder, r)
+
+		api.metrics.duration.Observe(time.Since(started).Seconds(), route, r.Method)
+		api.metrics.requests.Inc(route, r.Method, strconv.Itoa(recorder.status))
+	})
+}
+
+// unaryMeasure - gRPC interceptor counting unary calls and their latency
+func (api *GrpcApi) unaryMeasure(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	started := time.Now()
+	resp, err := handler(ctx, req)
+	api.observe(info.FullMethod, started, err)
+	return resp, err
+}
+
+// streamMeasure - gRPC interceptor counting streams and their duration
+func (api *GrpcApi) streamMeasure(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	started := time.Now()
+	err := handler(srv, stream)
+	api.observe(info.FullMethod, started, err)
+	return err
+}
+
+// observe - records a finished gRPC call
+func (api *GrpcApi) observe(method string, started time.Time, err error) {
+	if api.metrics == nil {
+		return
+	}
+	api.metrics.duration.Observe(time.Since(started).Seconds(), method)
+	api.metrics.requests.Inc(method, status.Code(err).String())
+}
+
+// statusRecorder - keeps the status code written, passing flushes through for
+// watch streams
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
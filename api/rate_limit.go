@@ -0,0 +1,79 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// Rate limits
+// every tenant has a bucket of tokens, refilled at the rate allowed up to the
+// burst, a request takes a token and is refused when none is left, the REST and
+// gRPC APIs share the buckets of the limiter they are given
+
+// RateLimiter - per tenant token buckets
+type RateLimiter struct {
+	mu sync.Mutex
+	// rate - tokens added per second, 0 allows every request
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket - the tokens of a tenant when last taken from
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requests per second to each
+// tenant, 0 allows every request
+func NewRateLimiter(requestsPerSecond int, burst int) *RateLimiter {
+	limiter := &RateLimiter{now: time.Now}
+	limiter.SetLimit(requestsPerSecond, burst)
+	return limiter
+}
+
+// SetLimit changes the requests allowed per second and at once, the burst is
+// the requests per second when 0, buckets start full again
+func (l *RateLimiter) SetLimit(requestsPerSecond int, burst int) {
+	if burst <= 0 {
+		burst = requestsPerSecond
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate, l.burst = float64(requestsPerSecond), float64(burst)
+	l.buckets = make(map[string]*bucket)
+}
+
+// Allow takes a token of a tenant, false when the tenant is over its limit
+func (l *RateLimiter) Allow(tenant string) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true
+	}
+
+	now := l.now()
+	b, ok := l.buckets[tenant]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[tenant] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
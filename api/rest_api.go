@@ -1,69 +1,178 @@
 package api
 
 import (
-	"log"
+	"context"
+	"net"
 	"net/http"
 	"os"
 
+	"github.com/aawadall/simple-kv/health"
 	"github.com/aawadall/simple-kv/types"
 	"github.com/gorilla/mux"
 )
 
 // REST API for the application
 type RestApi struct {
-	logger *log.Logger
-	server types.Server
-	router *mux.Router
+	logger     *health.Logger
+	server     types.Server
+	router     *mux.Router
+	address    string
+	listener   net.Listener
+	httpServer *http.Server
+	// cancels the context of every request, ending watch streams on shutdown
+	cancelRequests context.CancelFunc
+	// limits the data requests of each tenant, none when nil
+	limiter *RateLimiter
+	// readiness checks served on /readyz
+	health *health.Checker
+	// metrics served on /metrics, none when nil
+	registry *health.Registry
+	metrics  *requestMetrics
 }
 
 // NewRestApi creates a new REST API
 func NewRestApi(server types.Server) *RestApi {
 	// TODO: Add configuration
 	return &RestApi{
-		logger: log.New(os.Stdout, "RestApi ", log.LstdFlags),
+		logger: health.Default().Component("rest"),
 		server: server,
 		router: mux.NewRouter(),
+		health: health.NewChecker(),
 	}
 }
 
-// Start starts the REST API
-func (api *RestApi) Start() {
-	api.logger.Println("Starting REST API")
-	go api.handleRequest()
+// SetRateLimiter sets the limiter data requests are taken from
+func (api *RestApi) SetRateLimiter(limiter *RateLimiter) {
+	api.limiter = limiter
 }
 
-// Stop stops the REST API
-func (api *RestApi) Stop() {
-	api.logger.Println("Stopping REST API")
+// SetHealth sets the readiness checks served on /readyz
+func (api *RestApi) SetHealth(checker *health.Checker) {
+	api.health = checker
 }
 
-// handle requests
-func (api *RestApi) handleRequest() {
+// SetMetrics sets the registry served on /metrics and counting requests
+func (api *RestApi) SetMetrics(registry *health.Registry) {
+	api.registry = registry
+	api.metrics = newRESTMetrics(registry)
+}
+
+// SetAddress sets the address the REST API listens on, overriding KV_SERVER_PORT
+func (api *RestApi) SetAddress(address string) {
+	api.address = address
+}
+
+// Addr returns the address the REST API listens on, the address set until it listens
+func (api *RestApi) Addr() string {
+	if api.listener != nil {
+		return api.listener.Addr().String()
+	}
+	return api.address
+}
+
+// Start starts the REST API, returning once it listens
+func (api *RestApi) Start() error {
+	api.logger.Debug("Starting REST API")
+
 	// router
+	api.router = mux.NewRouter()
 	api.routeHander()
 
-	// check if REST_PORT is set
-	var address string
-	if port, ok := os.LookupEnv("KV_SERVER_PORT"); ok {
-		address = ":" + port
-	} else {
-		address = ":8080"
+	// check if REST_PORT is set, unless the server set an address
+	address := api.address
+	if address == "" {
+		if port, ok := os.LookupEnv("KV_SERVER_PORT"); ok {
+			address = ":" + port
+		} else {
+			address = ":8080"
+		}
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	api.listener = listener
+
+	requests, cancel := context.WithCancel(context.Background())
+	api.cancelRequests = cancel
+	api.httpServer = &http.Server{
+		Handler:     api.router,
+		BaseContext: func(net.Listener) context.Context { return requests },
 	}
 
 	// Start the server
-	log.Fatal(http.ListenAndServe(address, api.router))
+	go func() {
+		err := api.httpServer.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			api.logger.Error("REST API stopped", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the REST API, waiting for in-flight requests until the context
+// ends and then closing the connections left
+func (api *RestApi) Stop(ctx context.Context) error {
+	api.logger.Debug("Stopping REST API")
+	if api.httpServer == nil {
+		return nil
+	}
+
+	// watch streams never finish on their own
+	api.cancelRequests()
+	err := api.httpServer.Shutdown(ctx)
+	if err != nil {
+		api.httpServer.Close()
+	}
+	return err
 }
 
 // refactor routes
 func (api *RestApi) routeHander() error {
 	// Server Router
 	api.router.HandleFunc("/api/server/status", api.handleStatus)
+	api.router.HandleFunc("/api/server/config", api.handleConfig).Methods("GET")
+
+	// Probes and metrics, outside of rate limits
+	api.router.HandleFunc("/healthz", health.Live).Methods("GET")
+	api.router.Handle("/readyz", api.health).Methods("GET")
+	if api.registry != nil {
+		api.router.Handle("/metrics", api.registry).Methods("GET")
+	}
+	api.router.Use(api.measure, api.logRequests)
 	api.router.HandleFunc("/api/server/start", api.handleStart)
 	api.router.HandleFunc("/api/server/stop", api.handleStop)
 
+	// Feature Flag Router, flags are shared by every tenant
+	api.router.HandleFunc("/api/flags", api.handleListFlags).Methods("GET")
+	api.router.HandleFunc("/api/flags/{name}", api.handleGetFlag).Methods("GET")
+	api.router.HandleFunc("/api/flags/{name}", api.handleSetFlag).Methods("PUT", "POST")
+	api.router.HandleFunc("/api/flags/{name}", api.handleDeleteFlag).Methods("DELETE")
+	api.router.HandleFunc("/api/flags/{name}/evaluate", api.handleEvaluateFlag).Methods("GET")
+
+	// Data routes serve the default tenant under /api and a named tenant
+	// under /api/tenants/{tenant}, the X-Tenant header also names a tenant
+	tenantRouter := api.router.PathPrefix("/api/tenants/{tenant}").Subrouter()
+	defaultRouter := api.router.PathPrefix("/api").Subrouter()
+	for _, router := range []*mux.Router{tenantRouter, defaultRouter} {
+		router.Use(api.tenantScope)
+		api.dataRoutes(router)
+	}
+
+	return nil
+}
+
+// dataRoutes - registers the routes operating on records
+func (api *RestApi) dataRoutes(router *mux.Router) {
+	// Search Router, ahead of the key routes so a metadata search is not
+	// taken for the metadata of a key named search
+	router.HandleFunc("/kv/search/{partialKey}", api.handleFind)
+	router.HandleFunc("/kv/search/metadata/{query}", api.handleFindByMetadata)
+	router.HandleFunc("/kv/search/document/{query}", api.handleFindByDocument)
+
 	// KV Router
-	api.router.HandleFunc("/api/kv/{key}", func(w http.ResponseWriter, r *http.Request) {
-		api.logger.Printf("Request: %v %s", r.Method, r.URL.Path)
+	router.HandleFunc("/kv/{key}", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case "GET":
 			api.handleGet(w, r)
@@ -72,13 +181,65 @@ func (api *RestApi) routeHander() error {
 		case "DELETE":
 			api.handleDelete(w, r)
 		default:
-			api.logger.Println("Invalid method")
+			api.log(r).Debug("Invalid method")
+			http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Raw Value Router, streams large values
+	router.HandleFunc("/kv/{key}/raw", api.handleDownload).Methods("GET", "HEAD")
+	router.HandleFunc("/kv/{key}/raw", api.handleUpload).Methods("PUT", "POST")
+
+	// Undelete Router
+	router.HandleFunc("/kv/{key}/undelete", api.handleUndelete).Methods("POST")
+
+	// History Router
+	router.HandleFunc("/kv/{key}/history", api.handleHistory).Methods("GET")
+
+	// Counter Routers
+	router.HandleFunc("/kv/{key}/increment", api.handleIncrement).Methods("POST")
+	router.HandleFunc("/kv/{key}/decrement", api.handleIncrement).Methods("POST")
+	router.HandleFunc("/kv/{key}/sequence", api.handleNextSequence).Methods("POST")
+
+	// Structured Value Routers
+	router.HandleFunc("/kv/{key}/list", api.handleListRange).Methods("GET")
+	router.HandleFunc("/kv/{key}/list/push", api.handleListPush).Methods("POST")
+	router.HandleFunc("/kv/{key}/list/pop", api.handleListPop).Methods("POST")
+	router.HandleFunc("/kv/{key}/set", api.handleSetMembers).Methods("GET")
+	router.HandleFunc("/kv/{key}/set", api.handleSetAdd).Methods("POST")
+	router.HandleFunc("/kv/{key}/set", api.handleSetRemove).Methods("DELETE")
+	router.HandleFunc("/kv/{key}/hash", api.handleHashGetAll).Methods("GET")
+	router.HandleFunc("/kv/{key}/hash/{field}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			api.handleHashGet(w, r)
+		case "POST":
+			api.handleHashSet(w, r)
+		case "DELETE":
+			api.handleHashDelete(w, r)
+		default:
+			api.log(r).Debug("Invalid method")
+			http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Document Router
+	router.HandleFunc("/kv/{key}/document", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			api.handleGetDocument(w, r)
+		case "POST":
+			api.handleSetDocument(w, r)
+		case "PATCH":
+			api.handlePatchDocument(w, r)
+		default:
+			api.log(r).Debug("Invalid method")
 			http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
 		}
 	})
 
 	// Metadata Router
-	api.router.HandleFunc("/api/kv/{key}/metadata/{metadataKey}", func(w http.ResponseWriter, r *http.Request) {
+	router.HandleFunc("/kv/{key}/metadata/{metadataKey}", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
 		case "GET":
 			api.handleGetMetadata(w, r)
@@ -87,17 +248,47 @@ func (api *RestApi) routeHander() error {
 		case "DELETE":
 			api.handleDeleteMetadata(w, r)
 		default:
-			api.logger.Println("Invalid method")
+			api.log(r).Debug("Invalid method")
 			http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
 		}
 	})
 
 	// Get All Metadata
-	api.router.HandleFunc("/api/kv/{key}/metadata", api.handleGetAllMetadata)
+	router.HandleFunc("/kv/{key}/metadata", api.handleGetAllMetadata)
 
-	// Search Router
-	api.router.HandleFunc("/api/kv/search/{partialKey}", api.handleFind)
-	api.router.HandleFunc("/api/kv/search/metadata/{query}", api.handleFindByMetadata)
+	// Schema Router, the prefix may contain slashes
+	router.HandleFunc("/schema/{prefix:.+}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			api.handleGetSchema(w, r)
+		case "POST":
+			api.handleSetSchema(w, r)
+		case "DELETE":
+			api.handleDeleteSchema(w, r)
+		default:
+			api.log(r).Debug("Invalid method")
+			http.Error(w, "Invalid method", http.StatusMethodNotAllowed)
+		}
+	})
 
-	return nil
+	// Lease and Lock Routers, lock names may contain slashes
+	router.HandleFunc("/kv/{key}/lease/{id}", api.handleAttachLease).Methods("POST")
+	router.HandleFunc("/lease", api.handleGrantLease).Methods("POST")
+	router.HandleFunc("/lease/{id}", api.handleGetLease).Methods("GET")
+	router.HandleFunc("/lease/{id}", api.handleRevokeLease).Methods("DELETE")
+	router.HandleFunc("/lease/{id}/keepalive", api.handleKeepAliveLease).Methods("POST")
+	router.HandleFunc("/lock/{name:.+}", api.handleLock).Methods("POST")
+	router.HandleFunc("/lock/{name:.+}", api.handleUnlock).Methods("DELETE")
+
+	// Bulk Routers, prefixes may contain slashes
+	router.HandleFunc("/bulk/prefix/{prefix:.+}", api.handleDeletePrefix).Methods("DELETE")
+	router.HandleFunc("/bulk/metadata/{query}", api.handleDeleteByMetadata).Methods("DELETE")
+	router.HandleFunc("/bulk/copy", api.handleCopy).Methods("POST")
+	router.HandleFunc("/bulk/rename", api.handleCopy).Methods("POST")
+
+	// Usage Router
+	router.HandleFunc("/usage", api.handleUsage).Methods("GET")
+
+	// Watch Router, Server-Sent Events
+	router.HandleFunc("/watch", api.handleWatch).Methods("GET")
 }
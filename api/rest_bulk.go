@@ -0,0 +1,105 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aawadall/simple-kv/types"
+	"github.com/gorilla/mux"
+)
+
+// copyRequest - body of copy and rename requests
+type copyRequest struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Prefix      bool   `json:"prefix"`
+	History     bool   `json:"history"`
+	Overwrite   bool   `json:"overwrite"`
+	DryRun      bool   `json:"dryRun"`
+}
+
+// handle DeletePrefix(prefix string, dryRun bool) (BulkResult, error)
+// query parameter dry_run reports the keys without deleting them
+func (api *RestApi) handleDeletePrefix(w http.ResponseWriter, r *http.Request) {
+	// Get prefix and dry run from request
+	prefix := mux.Vars(r)["prefix"]
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		api.log(r).Debug("Invalid dry_run provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Delete keys in server
+	result, err := api.serverFor(r).DeletePrefix(prefix, dryRun)
+	api.writeBulkResult(w, r, result, err)
+}
+
+// handle DeleteByMetadata(query string, dryRun bool) (BulkResult, error)
+// query parameter dry_run reports the keys without deleting them
+func (api *RestApi) handleDeleteByMetadata(w http.ResponseWriter, r *http.Request) {
+	// Get query and dry run from request
+	query := mux.Vars(r)["query"]
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		api.log(r).Debug("Invalid dry_run provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Delete keys in server
+	result, err := api.serverFor(r).DeleteByMetadata(query, dryRun)
+	api.writeBulkResult(w, r, result, err)
+}
+
+// handle Copy and Rename(source string, destination string, options CopyOptions) (BulkResult, error)
+// the route picks the operation, the body is a copyRequest
+func (api *RestApi) handleCopy(w http.ResponseWriter, r *http.Request) {
+	// Get request body
+	var req copyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.log(r).Debug("Invalid copy request provided")
+		http.Error(w, "Invalid copy request", http.StatusBadRequest)
+		return
+	}
+	options := types.CopyOptions{
+		Prefix:    req.Prefix,
+		History:   req.History,
+		Overwrite: req.Overwrite,
+		DryRun:    req.DryRun,
+	}
+
+	// Copy or rename keys in server
+	var result types.BulkResult
+	var err error
+	if strings.HasSuffix(r.URL.Path, "/rename") {
+		result, err = api.serverFor(r).Rename(req.Source, req.Destination, options)
+	} else {
+		result, err = api.serverFor(r).Copy(req.Source, req.Destination, options)
+	}
+	api.writeBulkResult(w, r, result, err)
+}
+
+// writeBulkResult - writes the result of a bulk operation, or its error
+func (api *RestApi) writeBulkResult(w http.ResponseWriter, r *http.Request, result types.BulkResult, err error) {
+	if err != nil {
+		api.log(r).Warn("Error running bulk operation in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// parseDryRun - reads the dry_run query parameter, false when missing
+func parseDryRun(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("dry_run")
+	if raw == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(raw)
+}
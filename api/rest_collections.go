@@ -0,0 +1,316 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// handle ListPush(key string, values []string, front bool)
+// query parameter side=front pushes onto the head of the list
+func (api *RestApi) handleListPush(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get values from request, a JSON array of strings
+	var values []string
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		api.log(r).Debug("Invalid values provided")
+		http.Error(w, "Body must be a JSON array of strings", http.StatusBadRequest)
+		return
+	}
+
+	length, err := api.serverFor(r).ListPush(key, values, r.URL.Query().Get("side") == "front")
+	if err != nil {
+		api.log(r).Warn("Error pushing to list in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(length)
+}
+
+// handle ListPop(key string, front bool)
+// query parameter side=front pops the head of the list
+func (api *RestApi) handleListPop(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	value, err := api.serverFor(r).ListPop(key, r.URL.Query().Get("side") == "front")
+	if err != nil {
+		api.log(r).Warn("Error popping from list in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(value)
+}
+
+// handle ListRange(key string, start int, stop int)
+// query parameters start (default 0) and stop (default -1)
+func (api *RestApi) handleListRange(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get range from request
+	start, stop := 0, -1
+	var err error
+	if raw := r.URL.Query().Get("start"); raw != "" {
+		if start, err = strconv.Atoi(raw); err != nil {
+			http.Error(w, "Invalid start", http.StatusBadRequest)
+			return
+		}
+	}
+	if raw := r.URL.Query().Get("stop"); raw != "" {
+		if stop, err = strconv.Atoi(raw); err != nil {
+			http.Error(w, "Invalid stop", http.StatusBadRequest)
+			return
+		}
+	}
+
+	values, err := api.serverFor(r).ListRange(key, start, stop)
+	if err != nil {
+		api.log(r).Warn("Error reading list in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(values)
+}
+
+// handle SetAdd(key string, members []string)
+func (api *RestApi) handleSetAdd(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get values from request, a JSON array of strings
+	var values []string
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		api.log(r).Debug("Invalid values provided")
+		http.Error(w, "Body must be a JSON array of strings", http.StatusBadRequest)
+		return
+	}
+
+	added, err := api.serverFor(r).SetAdd(key, values)
+	if err != nil {
+		api.log(r).Warn("Error adding to set in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(added)
+}
+
+// handle SetRemove(key string, members []string)
+func (api *RestApi) handleSetRemove(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get values from request, a JSON array of strings
+	var values []string
+	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+		api.log(r).Debug("Invalid values provided")
+		http.Error(w, "Body must be a JSON array of strings", http.StatusBadRequest)
+		return
+	}
+
+	removed, err := api.serverFor(r).SetRemove(key, values)
+	if err != nil {
+		api.log(r).Warn("Error removing from set in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(removed)
+}
+
+// handle SetMembers(key string)
+func (api *RestApi) handleSetMembers(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	members, err := api.serverFor(r).SetMembers(key)
+	if err != nil {
+		api.log(r).Warn("Error reading set in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+// handle HashSet(key string, field string, value string)
+// the request body is the field value
+func (api *RestApi) handleHashSet(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+	field, ok := vars["field"]
+	if !ok || field == "" {
+		api.log(r).Debug("No field provided")
+		http.Error(w, "No field provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get value from request
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(r.Body)
+
+	err := api.serverFor(r).HashSet(key, field, buf.String())
+	if err != nil {
+		api.log(r).Warn("Error setting hash field in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Field set")
+}
+
+// handle HashGet(key string, field string)
+func (api *RestApi) handleHashGet(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+	field, ok := vars["field"]
+	if !ok || field == "" {
+		api.log(r).Debug("No field provided")
+		http.Error(w, "No field provided", http.StatusBadRequest)
+		return
+	}
+
+	value, err := api.serverFor(r).HashGet(key, field)
+	if err != nil {
+		api.log(r).Warn("Error getting hash field in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(value)
+}
+
+// handle HashDelete(key string, field string)
+func (api *RestApi) handleHashDelete(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+	field, ok := vars["field"]
+	if !ok || field == "" {
+		api.log(r).Debug("No field provided")
+		http.Error(w, "No field provided", http.StatusBadRequest)
+		return
+	}
+
+	err := api.serverFor(r).HashDelete(key, field)
+	if err != nil {
+		api.log(r).Warn("Error deleting hash field in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Field deleted")
+}
+
+// handle HashGetAll(key string)
+func (api *RestApi) handleHashGetAll(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	hash, err := api.serverFor(r).HashGetAll(key)
+	if err != nil {
+		api.log(r).Warn("Error reading hash in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hash)
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aawadall/simple-kv/types"
+	"github.com/gorilla/mux"
+)
+
+// handle Increment(key string, delta int64, options CounterOptions)
+// query parameters: delta (default 1), initial, min and max,
+// the decrement route negates delta
+func (api *RestApi) handleIncrement(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get delta and options from request
+	delta, options, err := parseCounterRequest(r)
+	if err != nil {
+		api.log(r).Debug("Invalid counter parameters provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/decrement") {
+		delta = -delta
+	}
+
+	// Increment value in server
+	value, err := api.serverFor(r).Increment(key, delta, options)
+	if err != nil {
+		api.log(r).Warn("Error incrementing value in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write value to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(value)
+}
+
+// handle NextSequence(key string)
+func (api *RestApi) handleNextSequence(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Advance sequence in server
+	value, err := api.serverFor(r).NextSequence(key)
+	if err != nil {
+		api.log(r).Warn("Error advancing sequence in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write value to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(value)
+}
+
+// parseCounterRequest - reads delta, initial, min and max query parameters
+func parseCounterRequest(r *http.Request) (delta int64, options types.CounterOptions, err error) {
+	query := r.URL.Query()
+	parse := func(name string) (int64, bool, error) {
+		raw := query.Get(name)
+		if raw == "" {
+			return 0, false, nil
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %v: %v", name, raw)
+		}
+		return value, true, nil
+	}
+
+	delta, ok, err := parse("delta")
+	if err != nil {
+		return 0, options, err
+	}
+	if !ok {
+		delta = 1
+	}
+
+	if options.Initial, _, err = parse("initial"); err != nil {
+		return 0, options, err
+	}
+	if value, ok, err := parse("min"); err != nil {
+		return 0, options, err
+	} else if ok {
+		options.Min = &value
+	}
+	if value, ok, err := parse("max"); err != nil {
+		return 0, options, err
+	} else if ok {
+		options.Max = &value
+	}
+	return delta, options, nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// contentTypeMergePatch - selects RFC 7386 merge patches, any other content
+// type is read as an RFC 6902 JSON Patch
+const contentTypeMergePatch = "application/merge-patch+json"
+
+// handle SetDocument(key string, document []byte) error
+func (api *RestApi) handleSetDocument(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get document from request
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(r.Body)
+
+	// Set document in server
+	err := api.serverFor(r).SetDocument(key, buf.Bytes())
+	if err != nil {
+		api.log(r).Warn("Error setting document in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Document set")
+}
+
+// handle GetDocument(key string, path string) ([]byte, error)
+// query parameter path selects part of the document, as a JSON Pointer or dotted path
+func (api *RestApi) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get document from server
+	document, err := api.serverFor(r).GetDocument(key, r.URL.Query().Get("path"))
+	if err != nil {
+		api.log(r).Warn("Error getting document from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write document to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
+
+// handle PatchDocument(key string, patch []byte, merge bool) ([]byte, error)
+// the Content-Type selects JSON Patch or merge patch, JSON Patch by default
+func (api *RestApi) handlePatchDocument(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get patch from request
+	merge := strings.HasPrefix(r.Header.Get("Content-Type"), contentTypeMergePatch)
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(r.Body)
+
+	// Patch document in server
+	document, err := api.serverFor(r).PatchDocument(key, buf.Bytes(), merge)
+	if err != nil {
+		api.log(r).Warn("Error patching document in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write patched document to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
+
+// handle FindByDocument(query string) ([]string, error)
+func (api *RestApi) handleFindByDocument(w http.ResponseWriter, r *http.Request) {
+	// Get query from request
+	vars := mux.Vars(r)
+	query, ok := vars["query"]
+	if !ok || query == "" {
+		api.log(r).Debug("No query provided")
+		http.Error(w, "No query provided", http.StatusBadRequest)
+		return
+	}
+
+	// Find keys from server
+	keys, err := api.serverFor(r).FindByDocument(query)
+	if err != nil {
+		api.log(r).Warn("Error finding keys from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write keys to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(keys)
+}
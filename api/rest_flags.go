@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aawadall/simple-kv/types"
+	"github.com/gorilla/mux"
+)
+
+// handle ListFlags() ([]FeatureFlag, error)
+func (api *RestApi) handleListFlags(w http.ResponseWriter, r *http.Request) {
+	// Get flags from server
+	flags, err := api.server.ListFlags()
+	if err != nil {
+		api.log(r).Warn("Error listing flags in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write flags to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(flags)
+}
+
+// handle GetFlag(name string) (FeatureFlag, error)
+func (api *RestApi) handleGetFlag(w http.ResponseWriter, r *http.Request) {
+	// Get flag from server
+	flag, err := api.server.GetFlag(mux.Vars(r)["name"])
+	if err != nil {
+		api.log(r).Warn("Error getting flag from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write flag to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(flag)
+}
+
+// handle SetFlag(flag FeatureFlag) error
+// the body is the flag as JSON, its name is taken from the path
+func (api *RestApi) handleSetFlag(w http.ResponseWriter, r *http.Request) {
+	// Get flag from request
+	var flag types.FeatureFlag
+	if err := json.NewDecoder(r.Body).Decode(&flag); err != nil {
+		api.log(r).Debug("Invalid flag provided")
+		http.Error(w, fmt.Sprintf("Invalid flag: %v", err), http.StatusBadRequest)
+		return
+	}
+	flag.Name = mux.Vars(r)["name"]
+
+	// Set flag in server
+	if err := api.server.SetFlag(flag); err != nil {
+		api.log(r).Warn("Error setting flag in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write flag to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(flag)
+}
+
+// handle DeleteFlag(name string) error
+func (api *RestApi) handleDeleteFlag(w http.ResponseWriter, r *http.Request) {
+	// Delete flag in server
+	if err := api.server.DeleteFlag(mux.Vars(r)["name"]); err != nil {
+		api.log(r).Warn("Error deleting flag in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Flag deleted")
+}
+
+// handle EvaluateFlag(name string, subject string) (bool, error)
+// query parameters tenant and subject, the default tenant when none is given
+func (api *RestApi) handleEvaluateFlag(w http.ResponseWriter, r *http.Request) {
+	// Get tenant and subject from request
+	name := mux.Vars(r)["name"]
+	subject := r.URL.Query().Get("subject")
+	server, err := tenantServer(api.server, r.URL.Query().Get("tenant"))
+	if err != nil {
+		api.log(r).Debug("Invalid tenant provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Evaluate flag in server
+	enabled, err := server.EvaluateFlag(name, subject)
+	if err != nil {
+		api.log(r).Warn("Error evaluating flag in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write result to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{"name": name, "enabled": enabled})
+}
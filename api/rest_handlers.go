@@ -2,19 +2,26 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/aawadall/simple-kv/types"
 	"github.com/gorilla/mux"
 )
 
+// time given to in-flight requests when stopped over the API
+const stopTimeout = 30 * time.Second
+
 // handle status
 func (api *RestApi) handleStatus(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling status request")
 	// Get status from server
 	status, err := api.server.GetStatus()
 	if err != nil {
-		api.logger.Println("Error getting status from server")
+		api.log(r).Warn("Error getting status from server", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -25,11 +32,30 @@ func (api *RestApi) handleStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(status)
 }
 
+// handle GetConfig() (ConfigReport, error)
+func (api *RestApi) handleConfig(w http.ResponseWriter, r *http.Request) {
+	// Get configuration from server
+	report, err := api.server.GetConfig()
+	if err != nil {
+		api.log(r).Warn("Error getting config from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write configuration to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(report)
+}
+
 // handler server start
 func (api *RestApi) handleStart(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling start request")
 	// Start server
-	api.server.Start()
+	if err := api.server.Start(r.Context()); err != nil {
+		api.log(r).Warn("Error starting server", "error", err)
+		httpError(w, err)
+		return
+	}
 
 	// Write status to response
 	w.Header().Set("Content-Type", "application/json")
@@ -39,10 +65,13 @@ func (api *RestApi) handleStart(w http.ResponseWriter, r *http.Request) {
 
 // handler server stop
 func (api *RestApi) handleStop(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling stop request")
-	// Stop server
+	// Stop server, after this response as stopping drains the requests in flight
 	go func() {
-		api.server.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+		defer cancel()
+		if err := api.server.Stop(ctx); err != nil {
+			api.logger.Error("Error stopping server", "error", err)
+		}
 	}()
 	// Write status to response
 	w.Header().Set("Content-Type", "application/json")
@@ -52,31 +81,42 @@ func (api *RestApi) handleStop(w http.ResponseWriter, r *http.Request) {
 
 // handle Get(key string) (interface{}, error)
 func (api *RestApi) handleGet(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling get request")
 	// Get key from request
 	vars := mux.Vars(r)
 
-	key, ok := vars["key"]
-	if !ok {
-		api.logger.Printf("Something went wrong %v, do we have key? %v", ok, key)
-
-		// api.logger.Println("No key provided")
-		// http.Error(w, "No key provided", http.StatusBadRequest)
-		// return
-	}
+	key := vars["key"]
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
+	// Get optional point in time
+	asOf, hasAsOf, err := parseAsOf(r.URL.Query().Get("as_of"))
+	if err != nil {
+		api.log(r).Debug("Invalid as_of provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// chunked values are written as they are, with range support
+	if valueType, _ := api.serverFor(r).GetMetadata(key, types.MetadataValueType); valueType == types.ValueTypeChunked && !hasAsOf {
+		api.serveValue(w, r, key)
+		return
+	}
+
 	// Get valueBytes from server
-	valueBytes, err := api.server.Get(key)
+	var valueBytes interface{}
+	if hasAsOf {
+		valueBytes, err = api.serverFor(r).GetAsOf(key, asOf)
+	} else {
+		valueBytes, err = api.serverFor(r).Get(key)
+	}
 
 	if err != nil {
-		api.logger.Println("Error getting value from server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error getting value from server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -86,6 +126,14 @@ func (api *RestApi) handleGet(w http.ResponseWriter, r *http.Request) {
 		value = valueBytes.([]byte)
 	}
 
+	// documents are already JSON, write them as they are
+	if valueType, _ := api.serverFor(r).GetMetadata(key, types.MetadataValueType); valueType == types.ValueTypeDocument && json.Valid(value) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(value)
+		return
+	}
+
 	// convert value to string
 	valueString := string(value)
 
@@ -97,21 +145,13 @@ func (api *RestApi) handleGet(w http.ResponseWriter, r *http.Request) {
 
 // handle Set(key string, value interface{}) error
 func (api *RestApi) handleSet(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling set request")
 	// Get key from request
 
 	vars := mux.Vars(r)
-	key, ok := vars["key"]
-	if !ok {
-		api.logger.Printf("Something went wrong %v, do we have key? %v", ok, key)
-
-		// api.logger.Println("No key provided")
-		// http.Error(w, "No key provided", http.StatusBadRequest)
-		// return
-	}
+	key := vars["key"]
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
@@ -119,24 +159,18 @@ func (api *RestApi) handleSet(w http.ResponseWriter, r *http.Request) {
 	// Get value from request
 	value := r.Body
 	if value == nil {
-		api.logger.Println("No value provided")
+		api.log(r).Debug("No value provided")
 		http.Error(w, "No value provided", http.StatusBadRequest)
 		return
 	}
 
-	// convert value to byte array
-	buf := new(bytes.Buffer)
-	buf.ReadFrom(value)
-	valueBytes := buf.Bytes()
-
-	// Set value in server
-	// for concurrency, we need to lock the record
-
-	err := api.server.Set(key, valueBytes)
+	// Set value in server, streaming the body so large values are stored
+	// chunk by chunk
+	_, err := api.serverFor(r).SetStream(key, value)
 
 	if err != nil {
-		api.logger.Println("Error setting value in server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error setting value in server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -148,27 +182,26 @@ func (api *RestApi) handleSet(w http.ResponseWriter, r *http.Request) {
 
 // handle Delete(key string) error
 func (api *RestApi) handleDelete(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling delete request")
 	// Get key from request
 	vars := mux.Vars(r)
 	key, ok := vars["key"]
 	if !ok {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	// Delete value from server
-	err := api.server.Delete(key)
+	err := api.serverFor(r).Delete(key)
 	if err != nil {
-		api.logger.Println("Error deleting value from server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error deleting value from server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -178,20 +211,44 @@ func (api *RestApi) handleDelete(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode("Value deleted")
 }
 
+// handle Undelete(key string) error
+func (api *RestApi) handleUndelete(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Undelete value in server
+	err := api.serverFor(r).Undelete(key)
+	if err != nil {
+		api.log(r).Warn("Error undeleting value in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Value undeleted")
+}
+
 // handle SetMetadata(key string, metadataKey string, metadataValue string) error
 func (api *RestApi) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling set metadata request")
 	// Get key from request
 	vars := mux.Vars(r)
 	key, ok := vars["key"]
 	if !ok {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
@@ -200,13 +257,13 @@ func (api *RestApi) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
 	metadataKey, ok := vars["metadataKey"]
 
 	if !ok {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
 
 	if metadataKey == "" {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
@@ -214,7 +271,7 @@ func (api *RestApi) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
 	// Get metadata value from request
 	metadataValue := r.Body
 	if metadataValue == nil {
-		api.logger.Println("No metadata value provided")
+		api.log(r).Debug("No metadata value provided")
 		http.Error(w, "No metadata value provided", http.StatusBadRequest)
 		return
 	}
@@ -225,10 +282,10 @@ func (api *RestApi) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
 	metadataValueString := buf.String()
 
 	// Set metadata in server
-	err := api.server.SetMetadata(key, metadataKey, metadataValueString)
+	err := api.serverFor(r).SetMetadata(key, metadataKey, metadataValueString)
 	if err != nil {
-		api.logger.Println("Error setting metadata in server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error setting metadata in server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -240,18 +297,17 @@ func (api *RestApi) handleSetMetadata(w http.ResponseWriter, r *http.Request) {
 
 // handle GetMetadata(key string, metadataKey string) (string, error)
 func (api *RestApi) handleGetMetadata(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling get metadata request")
 	// Get key from request
 	vars := mux.Vars(r)
 	key, ok := vars["key"]
 	if !ok {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
@@ -260,22 +316,22 @@ func (api *RestApi) handleGetMetadata(w http.ResponseWriter, r *http.Request) {
 	metadataKey, ok := vars["metadataKey"]
 
 	if !ok {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
 
 	if metadataKey == "" {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
 
 	// Get metadata from server
-	metadata, err := api.server.GetMetadata(key, metadataKey)
+	metadata, err := api.serverFor(r).GetMetadata(key, metadataKey)
 	if err != nil {
-		api.logger.Println("Error getting metadata from server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error getting metadata from server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -291,18 +347,17 @@ func (api *RestApi) handleGetMetadata(w http.ResponseWriter, r *http.Request) {
 
 // handle DeleteMetadata(key string, metadataKey string) error
 func (api *RestApi) handleDeleteMetadata(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling delete metadata request")
 	// Get key from request
 	vars := mux.Vars(r)
 	key, ok := vars["key"]
 	if !ok {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
@@ -311,22 +366,22 @@ func (api *RestApi) handleDeleteMetadata(w http.ResponseWriter, r *http.Request)
 	metadataKey, ok := vars["metadataKey"]
 
 	if !ok {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
 
 	if metadataKey == "" {
-		api.logger.Println("No metadata key provided")
+		api.log(r).Debug("No metadata key provided")
 		http.Error(w, "No metadata key provided", http.StatusBadRequest)
 		return
 	}
 
 	// Delete metadata from server
-	err := api.server.DeleteMetadata(key, metadataKey)
+	err := api.serverFor(r).DeleteMetadata(key, metadataKey)
 	if err != nil {
-		api.logger.Println("Error deleting metadata from server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error deleting metadata from server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -338,27 +393,39 @@ func (api *RestApi) handleDeleteMetadata(w http.ResponseWriter, r *http.Request)
 
 // handle GetAllMetadata(key string) (map[string]string, error)
 func (api *RestApi) handleGetAllMetadata(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling get all metadata request")
 	// Get key from request
 	vars := mux.Vars(r)
 	key, ok := vars["key"]
 	if !ok {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
 	if key == "" {
-		api.logger.Println("No key provided")
+		api.log(r).Debug("No key provided")
 		http.Error(w, "No key provided", http.StatusBadRequest)
 		return
 	}
 
+	// Get optional point in time
+	asOf, hasAsOf, err := parseAsOf(r.URL.Query().Get("as_of"))
+	if err != nil {
+		api.log(r).Debug("Invalid as_of provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get all metadata from server
-	metadata, err := api.server.GetAllMetadata(key)
+	var metadata map[string]string
+	if hasAsOf {
+		metadata, err = api.serverFor(r).GetAllMetadataAsOf(key, asOf)
+	} else {
+		metadata, err = api.serverFor(r).GetAllMetadata(key)
+	}
 	if err != nil {
-		api.logger.Println("Error getting all metadata from server")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		api.log(r).Warn("Error getting all metadata from server", "error", err)
+		httpError(w, err)
 		return
 	}
 
@@ -368,28 +435,87 @@ func (api *RestApi) handleGetAllMetadata(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(metadata)
 }
 
+// versionEntry - JSON shape of a retained version
+type versionEntry struct {
+	Version   int    `json:"version"`
+	Value     string `json:"value,omitempty"`
+	Size      int64  `json:"size"`
+	Timestamp string `json:"timestamp"`
+	Deleted   bool   `json:"deleted,omitempty"`
+	Chunked   bool   `json:"chunked,omitempty"`
+}
+
+// handle History(key string) ([]ValueVersion, error)
+func (api *RestApi) handleHistory(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get versions from server
+	versions, err := api.serverFor(r).History(key)
+	if err != nil {
+		api.log(r).Warn("Error getting history from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	entries := make([]versionEntry, 0, len(versions))
+	for _, version := range versions {
+		entries = append(entries, versionEntry{
+			Version:   version.Version,
+			Value:     string(version.Value),
+			Size:      version.Size,
+			Timestamp: version.Timestamp.Format(time.RFC3339Nano),
+			Deleted:   version.Deleted,
+			Chunked:   version.Chunked,
+		})
+	}
+
+	// Write versions to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entries)
+}
+
 // handle Find(partialKey string) ([]string, error)
 func (api *RestApi) handleFind(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling find request")
 	// Get partial key from request
 	vars := mux.Vars(r)
 	partialKey, ok := vars["partialKey"]
 	if !ok {
-		api.logger.Println("No partial key provided")
+		api.log(r).Debug("No partial key provided")
 		http.Error(w, "No partial key provided", http.StatusBadRequest)
 		return
 	}
 
 	if partialKey == "" {
-		api.logger.Println("No partial key provided")
+		api.log(r).Debug("No partial key provided")
 		http.Error(w, "No partial key provided", http.StatusBadRequest)
 		return
 	}
 
+	// Get optional point in time
+	asOf, hasAsOf, err := parseAsOf(r.URL.Query().Get("as_of"))
+	if err != nil {
+		api.log(r).Debug("Invalid as_of provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Find keys from server
-	keys, err := api.server.Find(partialKey)
+	var keys []string
+	if hasAsOf {
+		keys, err = api.serverFor(r).FindAsOf(partialKey, asOf)
+	} else {
+		keys, err = api.serverFor(r).Find(partialKey)
+	}
 	if err != nil {
-		api.logger.Println("Error finding keys from server")
+		api.log(r).Warn("Error finding keys from server", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -402,25 +528,24 @@ func (api *RestApi) handleFind(w http.ResponseWriter, r *http.Request) {
 
 // handle FindByMetadata(query string) ([]string, error)
 func (api *RestApi) handleFindByMetadata(w http.ResponseWriter, r *http.Request) {
-	api.logger.Println("Handling find by metadata request")
 	// Get query from request
 	vars := mux.Vars(r)
 	query, ok := vars["query"]
 	if !ok {
-		api.logger.Println("No query provided")
+		api.log(r).Debug("No query provided")
 		http.Error(w, "No query provided", http.StatusBadRequest)
 		return
 	}
 	if query == "" {
-		api.logger.Println("No query provided")
+		api.log(r).Debug("No query provided")
 		http.Error(w, "No query provided", http.StatusBadRequest)
 		return
 	}
 
 	// Find keys from server
-	keys, err := api.server.FindByMetadata(query)
+	keys, err := api.serverFor(r).FindByMetadata(query)
 	if err != nil {
-		api.logger.Println("Error finding keys from server")
+		api.log(r).Warn("Error finding keys from server", "error", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -430,3 +555,74 @@ func (api *RestApi) handleFindByMetadata(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(keys)
 }
+
+// handle Usage() (map[string]UsageReport, error)
+func (api *RestApi) handleUsage(w http.ResponseWriter, r *http.Request) {
+	// Get usage from server
+	usage, err := api.serverFor(r).Usage()
+	if err != nil {
+		api.log(r).Warn("Error getting usage from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write usage to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(usage)
+}
+
+// Helper Functions
+// parseAsOf - parses an optional `as_of` RFC3339 instant
+func parseAsOf(raw string) (asOf time.Time, ok bool, err error) {
+	if raw == "" {
+		return time.Time{}, false, nil
+	}
+
+	asOf, err = time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid as_of '%s', expected RFC3339", raw)
+	}
+
+	return asOf, true, nil
+}
+
+// httpError - writes a server error, schema violations are written as JSON
+// listing every failing path
+func httpError(w http.ResponseWriter, err error) {
+	var validationErr *types.ValidationError
+	if errors.As(err, &validationErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(validationErr)
+		return
+	}
+	http.Error(w, err.Error(), errorStatus(err))
+}
+
+// errorStatus - maps server errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, types.ErrBeforeHistory), errors.Is(err, types.ErrEmptyCollection), errors.Is(err, types.ErrPathNotFound),
+		errors.Is(err, types.ErrLeaseNotFound), errors.Is(err, types.ErrFlagNotFound), errors.Is(err, types.ErrKeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrTypeMismatch), errors.Is(err, types.ErrOutOfBounds), errors.Is(err, types.ErrPatchTestFailed),
+		errors.Is(err, types.ErrLockHeld), errors.Is(err, types.ErrLockNotHeld), errors.Is(err, types.ErrKeyExists):
+		return http.StatusConflict
+	case errors.Is(err, types.ErrInvalidDocument), errors.Is(err, types.ErrInvalidSchema), errors.Is(err, types.ErrInvalidFlag),
+		errors.Is(err, types.ErrInvalidKey):
+		return http.StatusBadRequest
+	case errors.Is(err, types.ErrSchemaViolation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, types.ErrQuotaExceeded):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrValueTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, types.ErrHookRejected):
+		return http.StatusForbidden
+	case errors.Is(err, types.ErrInvalidTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
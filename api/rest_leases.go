@@ -0,0 +1,200 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// handle GrantLease(ttl time.Duration) (Lease, error)
+// query parameter ttl is in seconds
+func (api *RestApi) handleGrantLease(w http.ResponseWriter, r *http.Request) {
+	// Get ttl from request
+	seconds, err := strconv.ParseInt(r.URL.Query().Get("ttl"), 10, 64)
+	if err != nil || seconds <= 0 {
+		api.log(r).Debug("Invalid ttl provided")
+		http.Error(w, "Invalid ttl", http.StatusBadRequest)
+		return
+	}
+
+	// Grant lease in server
+	lease, err := api.serverFor(r).GrantLease(time.Duration(seconds) * time.Second)
+	if err != nil {
+		api.log(r).Warn("Error granting lease in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write lease to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lease)
+}
+
+// handle GetLease(id int64) (Lease, error)
+func (api *RestApi) handleGetLease(w http.ResponseWriter, r *http.Request) {
+	// Get id from request
+	id, err := parseLeaseID(mux.Vars(r)["id"])
+	if err != nil {
+		api.log(r).Debug("Invalid lease id provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get lease from server
+	lease, err := api.serverFor(r).GetLease(id)
+	if err != nil {
+		api.log(r).Warn("Error getting lease from server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write lease to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lease)
+}
+
+// handle KeepAliveLease(id int64) (Lease, error)
+func (api *RestApi) handleKeepAliveLease(w http.ResponseWriter, r *http.Request) {
+	// Get id from request
+	id, err := parseLeaseID(mux.Vars(r)["id"])
+	if err != nil {
+		api.log(r).Debug("Invalid lease id provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Keep lease alive in server
+	lease, err := api.serverFor(r).KeepAliveLease(id)
+	if err != nil {
+		api.log(r).Warn("Error keeping lease alive in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write lease to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(lease)
+}
+
+// handle RevokeLease(id int64) error
+func (api *RestApi) handleRevokeLease(w http.ResponseWriter, r *http.Request) {
+	// Get id from request
+	id, err := parseLeaseID(mux.Vars(r)["id"])
+	if err != nil {
+		api.log(r).Debug("Invalid lease id provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Revoke lease in server
+	err = api.serverFor(r).RevokeLease(id)
+	if err != nil {
+		api.log(r).Warn("Error revoking lease in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Lease revoked")
+}
+
+// handle AttachLease(key string, id int64) error
+func (api *RestApi) handleAttachLease(w http.ResponseWriter, r *http.Request) {
+	// Get key and id from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+	id, err := parseLeaseID(vars["id"])
+	if err != nil {
+		api.log(r).Debug("Invalid lease id provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Attach key in server
+	err = api.serverFor(r).AttachLease(key, id)
+	if err != nil {
+		api.log(r).Warn("Error attaching lease in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Lease attached")
+}
+
+// handle Lock(name string, leaseID int64) (int64, error)
+// query parameter lease names the lease holding the lock
+func (api *RestApi) handleLock(w http.ResponseWriter, r *http.Request) {
+	// Get name and lease from request
+	name := mux.Vars(r)["name"]
+	id, err := parseLeaseID(r.URL.Query().Get("lease"))
+	if err != nil {
+		api.log(r).Debug("Invalid lease id provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Acquire lock in server
+	token, err := api.serverFor(r).Lock(name, id)
+	if err != nil {
+		api.log(r).Warn("Error acquiring lock in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write fencing token to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int64{"token": token})
+}
+
+// handle Unlock(name string, token int64) error
+// query parameter token is the fencing token of the acquisition
+func (api *RestApi) handleUnlock(w http.ResponseWriter, r *http.Request) {
+	// Get name and token from request
+	name := mux.Vars(r)["name"]
+	token, err := strconv.ParseInt(r.URL.Query().Get("token"), 10, 64)
+	if err != nil {
+		api.log(r).Debug("Invalid token provided")
+		http.Error(w, "Invalid token", http.StatusBadRequest)
+		return
+	}
+
+	// Release lock in server
+	err = api.serverFor(r).Unlock(name, token)
+	if err != nil {
+		api.log(r).Warn("Error releasing lock in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Lock released")
+}
+
+// parseLeaseID - reads a lease id
+func parseLeaseID(raw string) (int64, error) {
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid lease id '%s'", raw)
+	}
+	return id, nil
+}
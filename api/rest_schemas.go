@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// handle SetSchema(prefix string, schema []byte) error
+func (api *RestApi) handleSetSchema(w http.ResponseWriter, r *http.Request) {
+	// Get prefix from request
+	vars := mux.Vars(r)
+	prefix, ok := vars["prefix"]
+	if !ok || prefix == "" {
+		api.log(r).Debug("No prefix provided")
+		http.Error(w, "No prefix provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get schema from request
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(r.Body)
+
+	// Set schema in server
+	err := api.serverFor(r).SetSchema(prefix, buf.Bytes())
+	if err != nil {
+		api.log(r).Warn("Error setting schema in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Schema set")
+}
+
+// handle GetSchema(prefix string, version int) ([]byte, error)
+// query parameter version selects an older schema version
+func (api *RestApi) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	// Get prefix from request
+	vars := mux.Vars(r)
+	prefix, ok := vars["prefix"]
+	if !ok || prefix == "" {
+		api.log(r).Debug("No prefix provided")
+		http.Error(w, "No prefix provided", http.StatusBadRequest)
+		return
+	}
+
+	// Get optional version from request
+	version := -1
+	if raw := r.URL.Query().Get("version"); raw != "" {
+		var err error
+		if version, err = strconv.Atoi(raw); err != nil || version < 0 {
+			api.log(r).Debug("Invalid version provided")
+			http.Error(w, "Invalid version", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Get schema from server
+	schema, err := api.serverFor(r).GetSchema(prefix, version)
+	if err != nil {
+		api.log(r).Warn("Error getting schema from server", "error", err)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	// Write schema to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(schema)
+}
+
+// handle DeleteSchema(prefix string) error
+func (api *RestApi) handleDeleteSchema(w http.ResponseWriter, r *http.Request) {
+	// Get prefix from request
+	vars := mux.Vars(r)
+	prefix, ok := vars["prefix"]
+	if !ok || prefix == "" {
+		api.log(r).Debug("No prefix provided")
+		http.Error(w, "No prefix provided", http.StatusBadRequest)
+		return
+	}
+
+	// Delete schema in server
+	err := api.serverFor(r).DeleteSchema(prefix)
+	if err != nil {
+		api.log(r).Warn("Error deleting schema in server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write status to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Schema deleted")
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// handle SetStream(key string, value io.Reader) (int64, error)
+// the body is stored as it is, values longer than a chunk are stored as chunks
+func (api *RestApi) handleUpload(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	// Stream value to server
+	size, err := api.serverFor(r).SetStream(key, r.Body)
+	if err != nil {
+		api.log(r).Warn("Error uploading value to server", "error", err)
+		httpError(w, err)
+		return
+	}
+
+	// Write size to response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int64{"size": size})
+}
+
+// handle OpenValue(key string) (io.ReadSeeker, error)
+// the value is written as it is, with Content-Length and range requests
+func (api *RestApi) handleDownload(w http.ResponseWriter, r *http.Request) {
+	// Get key from request
+	vars := mux.Vars(r)
+	key, ok := vars["key"]
+	if !ok || key == "" {
+		api.log(r).Debug("No key provided")
+		http.Error(w, "No key provided", http.StatusBadRequest)
+		return
+	}
+
+	api.serveValue(w, r, key)
+}
+
+// serveValue - streams the latest value of a key
+func (api *RestApi) serveValue(w http.ResponseWriter, r *http.Request, key string) {
+	value, err := api.serverFor(r).OpenValue(key)
+	if err != nil {
+		api.log(r).Warn("Error opening value in server", "error", err)
+		http.Error(w, err.Error(), errorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, "", time.Time{}, value)
+}
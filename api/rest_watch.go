@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// watchEvent - JSON shape of a change event
+type watchEvent struct {
+	Revision  uint64            `json:"revision"`
+	Key       string            `json:"key"`
+	Operation string            `json:"operation"`
+	Version   int               `json:"version"`
+	Value     string            `json:"value,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Timestamp string            `json:"timestamp"`
+}
+
+// handle Watch(filter WatchFilter), streams change events as Server-Sent Events
+// query parameters: key, prefix, query (metadata) and from_revision,
+// a reconnecting EventSource resumes through the Last-Event-ID header
+func (api *RestApi) handleWatch(w http.ResponseWriter, r *http.Request) {
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Get filter from request
+	filter, err := parseWatchFilter(r)
+	if err != nil {
+		api.log(r).Debug("Invalid watch filter provided")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Subscribe to server
+	events, cancel, err := api.serverFor(r).Watch(filter)
+	if err != nil {
+		api.log(r).Warn("Error watching server", "error", err)
+		// only a compacted revision is gone, other problems are the request's
+		code := http.StatusBadRequest
+		if errors.Is(err, types.ErrCompacted) {
+			code = http.StatusGone
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	defer cancel()
+
+	// Write events to response as they arrive
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				// dropped by the server, the client resumes from its last event id
+				return
+			}
+			data, err := json.Marshal(toWatchEvent(event))
+			if err != nil {
+				api.log(r).Error("Error encoding event", "error", err)
+				return
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Revision, event.Operation, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// Helper Functions
+// parseWatchFilter - reads the watch filter from the request
+func parseWatchFilter(r *http.Request) (types.WatchFilter, error) {
+	query := r.URL.Query()
+	filter := types.WatchFilter{
+		Key:           query.Get("key"),
+		Prefix:        query.Get("prefix"),
+		MetadataQuery: query.Get("query"),
+	}
+
+	if from := query.Get("from_revision"); from != "" {
+		revision, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from_revision '%s'", from)
+		}
+		filter.FromRevision = revision
+	} else if lastId := r.Header.Get("Last-Event-ID"); lastId != "" {
+		revision, err := strconv.ParseUint(lastId, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid Last-Event-ID '%s'", lastId)
+		}
+		filter.FromRevision = revision + 1
+	}
+
+	return filter, nil
+}
+
+// toWatchEvent - converts a change event to its JSON shape
+func toWatchEvent(event types.ChangeEvent) watchEvent {
+	return watchEvent{
+		Revision:  event.Revision,
+		Key:       event.Key,
+		Operation: event.Operation,
+		Version:   event.Version,
+		Value:     string(event.Value),
+		Metadata:  event.Metadata,
+		Timestamp: event.Timestamp.Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aawadall/simple-kv/types"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantHeader - names the tenant of a request, as an HTTP header or gRPC
+// metadata, requests without one use the default tenant
+const tenantHeader = "X-Tenant"
+
+// serverContextKey - request context key of the tenant scoped server
+type serverContextKey struct{}
+
+// tenantServer - the server scoped to a tenant, the default tenant when empty
+func tenantServer(server types.Server, tenant string) (types.Server, error) {
+	if tenant == "" {
+		return server, nil
+	}
+	return server.ForTenant(tenant)
+}
+
+// tenantScope - REST middleware resolving the tenant from the path or header
+func (api *RestApi) tenantScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := mux.Vars(r)["tenant"]
+		if tenant == "" {
+			tenant = r.Header.Get(tenantHeader)
+		}
+
+		server, err := tenantServer(api.server, tenant)
+		if err != nil {
+			api.log(r).Debug("Invalid tenant provided")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !api.limiter.Allow(limitedTenant(tenant)) {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), serverContextKey{}, server)))
+	})
+}
+
+// serverFor - the server scoped to the tenant of a REST request
+func (api *RestApi) serverFor(r *http.Request) types.Server {
+	if server, ok := r.Context().Value(serverContextKey{}).(types.Server); ok {
+		return server
+	}
+	return api.server
+}
+
+// scopeContext - resolves the tenant from gRPC metadata into the context
+func (api *GrpcApi) scopeContext(ctx context.Context) (context.Context, error) {
+	tenant := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantHeader); len(values) > 0 {
+			tenant = values[0]
+		}
+	}
+
+	server, err := tenantServer(api.server, tenant)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if !api.limiter.Allow(limitedTenant(tenant)) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return context.WithValue(ctx, serverContextKey{}, server), nil
+}
+
+// unaryTenantScope - gRPC interceptor scoping unary calls to their tenant
+func (api *GrpcApi) unaryTenantScope(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := api.scopeContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamTenantScope - gRPC interceptor scoping streams to their tenant
+func (api *GrpcApi) streamTenantScope(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := api.scopeContext(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &scopedStream{ServerStream: stream, ctx: ctx})
+}
+
+// scopedStream - a server stream carrying the tenant scoped context
+type scopedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *scopedStream) Context() context.Context {
+	return s.ctx
+}
+
+// serverFor - the server scoped to the tenant of a gRPC call
+func (api GrpcApi) serverFor(ctx context.Context) types.Server {
+	if server, ok := ctx.Value(serverContextKey{}).(types.Server); ok {
+		return server
+	}
+	return api.server
+}
+
+// limitedTenant - the tenant a request is counted against
+func limitedTenant(tenant string) string {
+	if tenant == "" {
+		return types.DefaultTenant
+	}
+	return tenant
+}
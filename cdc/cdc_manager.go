@@ -0,0 +1,353 @@
+package cdc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// defaults
+const (
+	defaultDirectory    = "cdc_data"
+	defaultFileMaxBytes = 64 * 1024 * 1024
+	defaultMaxLag       = 100000
+	deliveryInterval    = time.Second
+)
+
+// Manager - CDC manager, spools committed changes and delivers them to sinks
+type Manager struct {
+	logger     *health.Logger
+	sinks      []Sink
+	spool      *spool
+	checkpoint *checkpoint
+
+	// maxLag - events kept for a sink that does not accept them, older ones are dropped
+	maxLag int
+
+	mu           sync.Mutex
+	queue        []Event
+	lastRevision uint64
+	// droppedThrough - last revision dropped from the queue, sinks behind it skip ahead
+	droppedThrough uint64
+	dropped        uint64
+
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewManager - create a new CDC manager, configured by
+// `cdc_sinks` (comma separated: file, socket), `cdc_dir`, `cdc_file_max_bytes`, `cdc_socket_path`
+// and `cdc_max_lag`, CDC is disabled when no sinks are configured
+func NewManager(config map[string]interface{}) *Manager {
+	m := &Manager{
+		logger: health.Default().Component("cdc"),
+		maxLag: defaultMaxLag,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if maxLag, err := strconv.Atoi(configString(config, "cdc_max_lag", "")); err == nil && maxLag > 0 {
+		m.maxLag = maxLag
+	}
+
+	dir := configString(config, "cdc_dir", defaultDirectory)
+	for _, name := range strings.Split(configString(config, "cdc_sinks", ""), ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "file":
+			maxBytes, err := strconv.ParseInt(configString(config, "cdc_file_max_bytes", ""), 10, 64)
+			if err != nil || maxBytes <= 0 {
+				maxBytes = defaultFileMaxBytes
+			}
+			m.sinks = append(m.sinks, NewFileSink(dir, "changes", maxBytes))
+		case "socket":
+			m.sinks = append(m.sinks, NewSocketSink(configString(config, "cdc_socket_path", filepath.Join(dir, "cdc.sock"))))
+		default:
+			m.logger.Warn("Unknown CDC sink", "sink", name)
+		}
+	}
+
+	if !m.Enabled() {
+		return m
+	}
+
+	m.logger.Info("Creating CDC Manager", "sinks", len(m.sinks), "dir", dir)
+	err := m.recover(dir)
+	if err != nil {
+		m.logger.Error("Error recovering CDC state, CDC disabled", "error", err)
+		m.sinks = nil
+	}
+	return m
+}
+
+// SetLogger - set the logger of the manager, before it is started
+func (m *Manager) SetLogger(logger *health.Logger) {
+	m.logger = logger
+}
+
+// Enabled - checks if any sink is configured
+func (m *Manager) Enabled() bool {
+	return len(m.sinks) > 0
+}
+
+// LastRevision - highest revision spooled or delivered, revisions continue from here
+func (m *Manager) LastRevision() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastRevision
+}
+
+// QueueDepth - events spooled and not yet delivered to every sink
+func (m *Manager) QueueDepth() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.queue)
+}
+
+// Dropped - events dropped since the start because a sink lagged more than the cap
+func (m *Manager) Dropped() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dropped
+}
+
+// Publish - spool a committed change for delivery, called in revision order
+func (m *Manager) Publish(change types.ChangeEvent) {
+	if !m.Enabled() {
+		return
+	}
+
+	event := NewEvent(change)
+
+	m.mu.Lock()
+	err := m.spool.append(event)
+	m.queue = append(m.queue, event)
+	m.lastRevision = event.Revision
+	m.limitLag()
+	m.mu.Unlock()
+
+	if err != nil {
+		m.logger.Error("Error spooling event", "revision", event.Revision, "error", err)
+	}
+
+	// wake the delivery loop without blocking the writer
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start - start delivering events
+func (m *Manager) Start() {
+	if !m.Enabled() {
+		return
+	}
+
+	m.logger.Debug("Starting CDC Manager")
+	go m.run()
+}
+
+// Stop - deliver what can be delivered and close the sinks
+func (m *Manager) Stop() {
+	if !m.Enabled() {
+		return
+	}
+
+	m.logger.Debug("Stopping CDC Manager")
+	close(m.stop)
+	<-m.done
+
+	for _, sink := range m.sinks {
+		err := sink.Close()
+		if err != nil {
+			m.logger.Error("Error closing sink", "sink", sink.Name(), "error", err)
+		}
+	}
+	m.spool.close()
+}
+
+// helper functions
+// recover - load the checkpoint and requeue spooled events past it
+func (m *Manager) recover(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	m.checkpoint, err = loadCheckpoint(filepath.Join(dir, "checkpoint.json"))
+	if err != nil {
+		return err
+	}
+
+	m.spool, err = openSpool(filepath.Join(dir, "spool.jsonl"))
+	if err != nil {
+		return err
+	}
+
+	spooled, err := m.spool.load()
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range m.checkpoint.Revisions {
+		if revision > m.lastRevision {
+			m.lastRevision = revision
+		}
+	}
+
+	delivered := m.deliveredRevision()
+	for _, event := range spooled {
+		if event.Revision > delivered {
+			m.queue = append(m.queue, event)
+		}
+		if event.Revision > m.lastRevision {
+			m.lastRevision = event.Revision
+		}
+	}
+	m.limitLag()
+
+	m.logger.Info("Recovered CDC", "revision", m.lastRevision, "undelivered", len(m.queue))
+	return nil
+}
+
+// run - delivery loop
+func (m *Manager) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(deliveryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			m.deliver()
+			return
+		case <-m.notify:
+		case <-ticker.C:
+		}
+		m.deliver()
+	}
+}
+
+// deliver - send each sink the queued events past its checkpoint, sinks behind
+// the events dropped by the lag cap skip past them first
+func (m *Manager) deliver() {
+	m.mu.Lock()
+	queue := make([]Event, len(m.queue))
+	copy(queue, m.queue)
+	droppedThrough := m.droppedThrough
+	m.mu.Unlock()
+
+	advanced := false
+	for _, sink := range m.sinks {
+		accepted := m.checkpoint.Revisions[sink.Name()]
+		if accepted < droppedThrough {
+			m.logger.Warn("CDC sink lagging, events dropped", "sink", sink.Name(),
+				"from_revision", accepted+1, "to_revision", droppedThrough, "max_lag", m.maxLag)
+			accepted = droppedThrough
+			m.checkpoint.Revisions[sink.Name()] = accepted
+			advanced = true
+		}
+		pending := []Event{}
+		for _, event := range queue {
+			if event.Revision > accepted {
+				pending = append(pending, event)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		err := sink.Write(pending)
+		if err != nil {
+			m.logger.Warn("Error delivering events, will retry", "events", len(pending), "sink", sink.Name(), "error", err)
+			continue
+		}
+		m.checkpoint.Revisions[sink.Name()] = pending[len(pending)-1].Revision
+		advanced = true
+	}
+
+	if !advanced {
+		return
+	}
+
+	err := m.checkpoint.save()
+	if err != nil {
+		m.logger.Error("Error saving CDC checkpoint", "error", err)
+		return
+	}
+
+	m.trim()
+}
+
+// trim - forget events every sink has accepted, emptying the spool when nothing
+// is left and rewriting it when mostly delivered events are left in it
+func (m *Manager) trim() {
+	delivered := m.deliveredRevision()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	remaining := m.queue[:0]
+	for _, event := range m.queue {
+		if event.Revision > delivered {
+			remaining = append(remaining, event)
+		}
+	}
+	m.queue = remaining
+
+	if len(m.queue) == 0 {
+		err := m.spool.reset()
+		if err != nil {
+			m.logger.Error("Error resetting CDC spool", "error", err)
+		}
+		return
+	}
+
+	if m.spool.lines > compactMinimum && m.spool.lines > 2*len(m.queue) {
+		err := m.spool.rewrite(m.queue)
+		if err != nil {
+			m.logger.Error("Error compacting CDC spool", "error", err)
+		}
+	}
+}
+
+// limitLag - drop the oldest events past the lag cap, callers hold the lock
+func (m *Manager) limitLag() {
+	excess := len(m.queue) - m.maxLag
+	if excess <= 0 {
+		return
+	}
+
+	m.droppedThrough = m.queue[excess-1].Revision
+	m.dropped += uint64(excess)
+	m.queue = append(m.queue[:0:0], m.queue[excess:]...)
+}
+
+// deliveredRevision - highest revision accepted by every sink
+func (m *Manager) deliveredRevision() uint64 {
+	delivered := uint64(0)
+	for i, sink := range m.sinks {
+		revision := m.checkpoint.Revisions[sink.Name()]
+		if i == 0 || revision < delivered {
+			delivered = revision
+		}
+	}
+	return delivered
+}
+
+// configString - reads a configuration value as a string
+func configString(config map[string]interface{}, key string, fallback string) string {
+	value, ok := config[key]
+	if !ok || value == nil {
+		return fallback
+	}
+	return fmt.Sprintf("%v", value)
+}
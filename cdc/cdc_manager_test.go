@@ -0,0 +1,129 @@
+package cdc
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that events reach the file sink and are not replayed after a restart
+func TestManagerFileSinkCheckpoint(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	config := map[string]interface{}{"cdc_sinks": "file", "cdc_dir": dir}
+	manager := NewManager(config)
+	manager.Start()
+
+	// Act
+	for revision := uint64(1); revision <= 3; revision++ {
+		manager.Publish(types.ChangeEvent{
+			Revision:        revision,
+			Key:             "key",
+			Operation:       types.OperationSet,
+			Version:         int(revision) - 1,
+			PreviousVersion: int(revision) - 2,
+			Timestamp:       time.Now().UTC(),
+		})
+	}
+	manager.Stop()
+
+	// Assert
+	events := readEvents(t, filepath.Join(dir, "changes.jsonl"))
+	if len(events) != 3 {
+		t.Fatalf("sink has %d events instead of 3", len(events))
+	}
+	for i, event := range events {
+		if event.Revision != uint64(i+1) {
+			t.Errorf("event %d has revision %d", i, event.Revision)
+		}
+	}
+
+	// Act - restart
+	restarted := NewManager(config)
+
+	// Assert
+	if restarted.LastRevision() != 3 {
+		t.Errorf("restarted at revision %d instead of 3", restarted.LastRevision())
+	}
+	if len(restarted.queue) != 0 {
+		t.Errorf("%d delivered events were requeued", len(restarted.queue))
+	}
+}
+
+// Test that undelivered events survive a restart
+func TestManagerRequeuesUndelivered(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	config := map[string]interface{}{"cdc_sinks": "socket", "cdc_dir": dir, "cdc_socket_path": filepath.Join(dir, "missing.sock")}
+	manager := NewManager(config)
+
+	// Act - the socket is not listening, so nothing is delivered
+	manager.Publish(types.ChangeEvent{Revision: 1, Key: "key", Operation: types.OperationSet})
+	manager.Publish(types.ChangeEvent{Revision: 2, Key: "key", Operation: types.OperationDelete})
+	manager.deliver()
+	manager.spool.close()
+	restarted := NewManager(config)
+
+	// Assert
+	if len(restarted.queue) != 2 {
+		t.Errorf("%d events requeued instead of 2", len(restarted.queue))
+	}
+}
+
+// Test that a sink lagging past the cap skips the oldest events and the spool is compacted
+func TestManagerDropsLagging(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	config := map[string]interface{}{"cdc_sinks": "socket", "cdc_dir": dir, "cdc_socket_path": filepath.Join(dir, "missing.sock"), "cdc_max_lag": "10"}
+	manager := NewManager(config)
+
+	// Act - the socket is not listening, so nothing is delivered
+	for revision := uint64(1); revision <= 2*compactMinimum; revision++ {
+		manager.Publish(types.ChangeEvent{Revision: revision, Key: "key", Operation: types.OperationSet})
+	}
+	manager.deliver()
+
+	// Assert
+	if manager.QueueDepth() != 10 || manager.Dropped() != 2*compactMinimum-10 {
+		t.Errorf("%d events queued and %d dropped", manager.QueueDepth(), manager.Dropped())
+	}
+	if accepted := manager.checkpoint.Revisions["socket"]; accepted != 2*compactMinimum-10 {
+		t.Errorf("lagging sink at revision %d", accepted)
+	}
+	if manager.spool.lines != 10 {
+		t.Errorf("spool has %d events instead of 10", manager.spool.lines)
+	}
+
+	// Act - restart
+	manager.spool.close()
+	restarted := NewManager(config)
+
+	// Assert
+	if len(restarted.queue) != 10 || restarted.queue[0].Revision != 2*compactMinimum-9 {
+		t.Errorf("%d events requeued from revision %d", len(restarted.queue), restarted.queue[0].Revision)
+	}
+}
+
+func readEvents(t *testing.T, location string) []Event {
+	f, err := os.Open(location)
+	if err != nil {
+		t.Fatalf("could not open %v: %v", location, err)
+	}
+	defer f.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,52 @@
+package cdc
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// checkpoint - last revision accepted by each sink, persisted as JSON
+type checkpoint struct {
+	location  string
+	Revisions map[string]uint64 `json:"revisions"`
+}
+
+// loadCheckpoint - read the checkpoint file, a missing file is an empty checkpoint
+func loadCheckpoint(location string) (*checkpoint, error) {
+	cp := &checkpoint{
+		location:  location,
+		Revisions: make(map[string]uint64),
+	}
+
+	data, err := os.ReadFile(location)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, cp)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Revisions == nil {
+		cp.Revisions = make(map[string]uint64)
+	}
+	return cp, nil
+}
+
+// save - replace the checkpoint file atomically
+func (cp *checkpoint) save() error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	tmp := cp.location + ".tmp"
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, cp.location)
+}
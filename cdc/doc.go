@@ -0,0 +1,16 @@
+package cdc
+
+/*
+Package cdc - change data capture for the application.
+it is responsible for feeding every committed mutation to external consumers
+
+- events are appended to a local spool as they are committed, without syncing,
+  so they survive the process crashing but not the machine losing power
+- a delivery loop writes them, in revision order, to pluggable sinks
+	- rotating JSONL files
+	- Unix domain socket
+- each sink has a persisted checkpoint of the last revision it accepted,
+  so after a restart only the events past the checkpoint are delivered again
+- a sink lagging more than the cap skips the oldest events, the spool is
+  rewritten once it holds mostly delivered events
+*/
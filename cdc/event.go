@@ -0,0 +1,33 @@
+package cdc
+
+import (
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Event - a change event as emitted to sinks
+type Event struct {
+	Revision     uint64             `json:"revision"`
+	Tenant       string             `json:"tenant"`
+	Key          string             `json:"key"`
+	Operation    string             `json:"operation"`
+	OldVersion   int                `json:"old_version"`
+	NewVersion   int                `json:"new_version"`
+	MetadataDiff types.MetadataDiff `json:"metadata_diff"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// NewEvent - converts a committed change to a CDC event
+func NewEvent(change types.ChangeEvent) Event {
+	return Event{
+		Revision:     change.Revision,
+		Tenant:       change.Tenant,
+		Key:          change.Key,
+		Operation:    change.Operation,
+		OldVersion:   change.PreviousVersion,
+		NewVersion:   change.Version,
+		MetadataDiff: change.MetadataDiff,
+		Timestamp:    change.Timestamp,
+	}
+}
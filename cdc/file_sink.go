@@ -0,0 +1,119 @@
+package cdc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSink - writes events to JSONL files, rotating when a file grows past maxBytes
+type FileSink struct {
+	dir      string
+	prefix   string
+	maxBytes int64
+	file     *os.File
+	size     int64
+}
+
+// NewFileSink - create a new rotating JSONL file sink
+func NewFileSink(dir string, prefix string, maxBytes int64) *FileSink {
+	return &FileSink{
+		dir:      dir,
+		prefix:   prefix,
+		maxBytes: maxBytes,
+	}
+}
+
+// Name - sink identifier
+func (fs *FileSink) Name() string {
+	return "file"
+}
+
+// Write - append events to the current file
+func (fs *FileSink) Write(events []Event) error {
+	if fs.file == nil {
+		err := fs.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		line, err := encodeLines([]Event{event})
+		if err != nil {
+			return err
+		}
+
+		// rotate before the file outgrows its budget, never leaving it empty
+		if fs.size > 0 && fs.size+int64(len(line)) > fs.maxBytes {
+			err := fs.rotate()
+			if err != nil {
+				return err
+			}
+		}
+
+		n, err := fs.file.Write(line)
+		fs.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fs.file.Sync()
+}
+
+// Close - close the current file
+func (fs *FileSink) Close() error {
+	if fs.file == nil {
+		return nil
+	}
+	err := fs.file.Close()
+	fs.file = nil
+	return err
+}
+
+// helper functions
+// current - path of the file being written
+func (fs *FileSink) current() string {
+	return filepath.Join(fs.dir, fs.prefix+".jsonl")
+}
+
+// open - open the current file for appending
+func (fs *FileSink) open() error {
+	err := os.MkdirAll(fs.dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(fs.current(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	fs.file = f
+	fs.size = info.Size()
+	return nil
+}
+
+// rotate - move the current file aside and start a new one
+func (fs *FileSink) rotate() error {
+	err := fs.Close()
+	if err != nil {
+		return err
+	}
+
+	rotated := filepath.Join(fs.dir, fmt.Sprintf("%s-%s.jsonl", fs.prefix, time.Now().UTC().Format("20060102T150405.000000000")))
+	err = os.Rename(fs.current(), rotated)
+	if err != nil {
+		return err
+	}
+
+	return fs.open()
+}
@@ -0,0 +1,28 @@
+package cdc
+
+import "encoding/json"
+
+// Sink - a destination for change events
+type Sink interface {
+	// Name - stable identifier, used to key the sink's checkpoint
+	Name() string
+	// Write - delivers a batch of events in revision order, the batch is
+	// retried in full when an error is returned
+	Write([]Event) error
+	Close() error
+}
+
+// Helper Functions
+// encodeLines - encodes events as newline delimited JSON
+func encodeLines(events []Event) ([]byte, error) {
+	lines := []byte{}
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line...)
+		lines = append(lines, '\n')
+	}
+	return lines, nil
+}
@@ -0,0 +1,63 @@
+package cdc
+
+import (
+	"net"
+	"time"
+)
+
+// how long a write may block on a slow consumer
+const socketWriteTimeout = 5 * time.Second
+
+// SocketSink - streams events as JSONL to a Unix domain socket
+type SocketSink struct {
+	path string
+	conn net.Conn
+}
+
+// NewSocketSink - create a new Unix domain socket sink
+func NewSocketSink(path string) *SocketSink {
+	return &SocketSink{
+		path: path,
+	}
+}
+
+// Name - sink identifier
+func (ss *SocketSink) Name() string {
+	return "socket"
+}
+
+// Write - send events to the socket, connecting on demand
+func (ss *SocketSink) Write(events []Event) error {
+	lines, err := encodeLines(events)
+	if err != nil {
+		return err
+	}
+
+	if ss.conn == nil {
+		conn, err := net.Dial("unix", ss.path)
+		if err != nil {
+			return err
+		}
+		ss.conn = conn
+	}
+
+	ss.conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+	_, err = ss.conn.Write(lines)
+	if err != nil {
+		// reconnect on the next attempt
+		ss.Close()
+		return err
+	}
+
+	return nil
+}
+
+// Close - close the connection
+func (ss *SocketSink) Close() error {
+	if ss.conn == nil {
+		return nil
+	}
+	err := ss.conn.Close()
+	ss.conn = nil
+	return err
+}
@@ -0,0 +1,103 @@
+package cdc
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// compactMinimum - spooled lines below which the spool is not compacted
+const compactMinimum = 1024
+
+// spool - append only file of events not yet delivered to every sink, it is
+// written without syncing, events survive the process crashing but not the
+// machine losing power
+type spool struct {
+	location string
+	file     *os.File
+	// lines - events in the file, delivered or not
+	lines int
+}
+
+// openSpool - open, or create, the spool file
+func openSpool(location string) (*spool, error) {
+	f, err := os.OpenFile(location, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &spool{location: location, file: f}, nil
+}
+
+// append - record an event before it is delivered
+func (sp *spool) append(event Event) error {
+	line, err := encodeLines([]Event{event})
+	if err != nil {
+		return err
+	}
+	_, err = sp.file.Write(line)
+	if err == nil {
+		sp.lines++
+	}
+	return err
+}
+
+// load - read back all spooled events, a torn last line is ignored
+func (sp *spool) load() ([]Event, error) {
+	f, err := os.Open(sp.location)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	sp.lines = len(events)
+	return events, scanner.Err()
+}
+
+// reset - drop all spooled events once every sink has them
+func (sp *spool) reset() error {
+	sp.lines = 0
+	return sp.file.Truncate(0)
+}
+
+// rewrite - replace the spool with the events not yet delivered, through a
+// temporary file renamed over it
+func (sp *spool) rewrite(events []Event) error {
+	lines, err := encodeLines(events)
+	if err != nil {
+		return err
+	}
+
+	tmp := sp.location + ".tmp"
+	err = os.WriteFile(tmp, lines, 0644)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, sp.location)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(sp.location, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	sp.file.Close()
+	sp.file = f
+	sp.lines = len(events)
+	return nil
+}
+
+// close - close the spool file
+func (sp *spool) close() error {
+	return sp.file.Close()
+}
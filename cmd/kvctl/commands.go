@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/aawadall/simple-kv/sdk/rest"
+)
+
+// get - the get command
+func get(args []string) error {
+	flags := flag.NewFlagSet("get", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	asOf := flags.String("as-of", "", "read the value as it was at an RFC3339 instant")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl get [flags] KEY")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the key")
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+
+	var value []byte
+	if *asOf != "" {
+		at, parseErr := time.Parse(time.RFC3339, *asOf)
+		if parseErr != nil {
+			return fmt.Errorf("invalid -as-of: %w", parseErr)
+		}
+		value, err = client.GetAsOf(context.Background(), args[0], at)
+	} else {
+		value, err = client.Get(context.Background(), args[0])
+	}
+	if err != nil {
+		return err
+	}
+	return out.value(value)
+}
+
+// set - the set command
+func set(args []string) error {
+	flags := flag.NewFlagSet("set", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	file := flags.String("file", "", "read the value from a file")
+	document := flags.Bool("document", false, "store the value as a JSON document")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl set [flags] KEY [VALUE | -]")
+		fmt.Fprintln(flags.Output(), "The value is read from stdin when it is - or missing and -file is not given.")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		flags.Usage()
+		return fmt.Errorf("expected the key")
+	}
+
+	client, _, err := options.open()
+	if err != nil {
+		return err
+	}
+	value, err := readValue(args[1:], *file)
+	if err != nil {
+		return err
+	}
+	defer value.Close()
+
+	if *document {
+		data, err := ioutil.ReadAll(value)
+		if err != nil {
+			return err
+		}
+		return client.SetDocument(context.Background(), args[0], data)
+	}
+	return client.Set(context.Background(), args[0], value)
+}
+
+// remove - the delete command
+func remove(args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl delete [flags] KEY...")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		flags.Usage()
+		return fmt.Errorf("expected a key")
+	}
+
+	client, _, err := options.open()
+	if err != nil {
+		return err
+	}
+	for _, key := range args {
+		if err := client.Delete(context.Background(), key); err != nil {
+			return fmt.Errorf("%v: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// metadata - the metadata command
+func metadata(args []string) error {
+	flags := flag.NewFlagSet("metadata", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	file := flags.String("file", "", "read the metadata value from a file, for set")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl metadata list [flags] KEY")
+		fmt.Fprintln(flags.Output(), "       kvctl metadata get [flags] KEY NAME")
+		fmt.Fprintln(flags.Output(), "       kvctl metadata set [flags] KEY NAME [VALUE | -]")
+		fmt.Fprintln(flags.Output(), "       kvctl metadata delete [flags] KEY NAME")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 {
+		flags.Usage()
+		return fmt.Errorf("expected an action and the key")
+	}
+	action, key, args := args[0], args[1], args[2:]
+	if action != "list" && len(args) == 0 {
+		flags.Usage()
+		return fmt.Errorf("expected the metadata name")
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch action {
+	case "list":
+		all, err := client.ListMetadata(ctx, key)
+		if err != nil {
+			return err
+		}
+		return out.rows([]string{"name", "value"}, mapRows(all), all)
+	case "get":
+		value, err := client.GetMetadata(ctx, key, args[0])
+		if err != nil {
+			return err
+		}
+		return out.value([]byte(value))
+	case "set":
+		value, err := readValue(args[1:], *file)
+		if err != nil {
+			return err
+		}
+		defer value.Close()
+		data, err := ioutil.ReadAll(value)
+		if err != nil {
+			return err
+		}
+		return client.SetMetadata(ctx, key, args[0], string(data))
+	case "delete":
+		return client.DeleteMetadata(ctx, key, args[0])
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
+
+// search - the search command
+func search(args []string) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	byMetadata := flags.Bool("metadata", false, "match a metadata query, such as owner:==:alice, instead of a key prefix")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl search [flags] PREFIX")
+		fmt.Fprintln(flags.Output(), "       kvctl search -metadata [flags] QUERY")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the prefix or query")
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+	keys, err := findKeys(client, args[0], *byMetadata)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{key})
+	}
+	return out.rows([]string{"key"}, rows, keys)
+}
+
+// history - the history command
+func history(args []string) error {
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl history [flags] KEY")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the key")
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+	versions, err := client.History(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(versions))
+	for _, version := range versions {
+		state, value := "set", version.Value
+		switch {
+		case version.Deleted:
+			state = "deleted"
+		case version.Chunked:
+			state, value = "chunked", ""
+		}
+		rows = append(rows, []string{strconv.Itoa(version.Version), version.Timestamp.Format(time.RFC3339Nano),
+			state, strconv.FormatInt(version.Size, 10), value})
+	}
+	return out.rows([]string{"version", "timestamp", "state", "size", "value"}, rows, versions)
+}
+
+// watch - the watch command
+func watch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	filter := rest.WatchFilter{}
+	flags.StringVar(&filter.Key, "key", "", "watch a single key")
+	flags.StringVar(&filter.Prefix, "prefix", "", "watch the keys starting with a prefix")
+	flags.StringVar(&filter.MetadataQuery, "query", "", "watch the keys whose metadata matches a query")
+	flags.Uint64Var(&filter.FromRevision, "from-revision", 0, "replay the changes since a revision first")
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+
+	// watch until interrupted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err = client.Watch(ctx, filter, func(event rest.Event) error {
+		return out.row([]string{"revision", "operation", "key", "version", "value"}, []string{
+			strconv.FormatUint(event.Revision, 10), event.Operation, event.Key, strconv.Itoa(event.Version), event.Value,
+		}, event)
+	})
+	if err == context.Canceled {
+		return nil
+	}
+	return err
+}
+
+// status - the status command
+func status(args []string) error {
+	flags := flag.NewFlagSet("status", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	client, out, err := options.open()
+	if err != nil {
+		return err
+	}
+	serverStatus, err := client.Status(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]string, len(serverStatus))
+	for name, value := range serverStatus {
+		if text, ok := value.(string); ok {
+			fields[name] = text
+			continue
+		}
+		encoded, _ := json.Marshal(value)
+		fields[name] = string(encoded)
+	}
+	return out.rows([]string{"field", "value"}, mapRows(fields), serverStatus)
+}
+
+// Helper Functions
+// findKeys - the keys matching a prefix, or a metadata query
+func findKeys(client *rest.Client, term string, byMetadata bool) ([]string, error) {
+	if byMetadata {
+		return client.FindByMetadata(context.Background(), term)
+	}
+	return client.Find(context.Background(), term)
+}
+
+// mapRows - rows of name and value, sorted by name
+func mapRows(values map[string]string) [][]string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name, values[name]})
+	}
+	return rows
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// kvctl - a command line client of the simple-kv REST API
+
+// command - a subcommand of kvctl
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+// commands - every subcommand, in the order usage lists them
+var commands = []command{
+	{"get", "print the value of a key", get},
+	{"set", "set the value of a key from an argument, a file or stdin", set},
+	{"delete", "delete a key", remove},
+	{"metadata", "get, set, delete or list the metadata of a key", metadata},
+	{"search", "list the keys matching a prefix or a metadata query", search},
+	{"history", "list the retained versions of a key", history},
+	{"watch", "print changes as they happen", watch},
+	{"export", "write the keys matching a prefix as JSON lines", export},
+	{"import", "write the keys of an export", importKeys},
+	{"status", "print the server status", status},
+	{"profile", "list, add, remove or select server profiles", profile},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run - runs the subcommand named by the first argument, returns the exit code
+func run(args []string) int {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return 2
+	}
+
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+		return 0
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		err := c.run(args[1:])
+		if err == flag.ErrHelp {
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "kvctl %v: %v\n", name, err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "kvctl: unknown command %q\n\n", name)
+	usage(os.Stderr)
+	return 2
+}
+
+// usage - lists the subcommands
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: kvctl <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %v\n", c.name, c.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command talking to a server takes -server, -tenant, -profile and")
+	fmt.Fprintln(w, "-o raw|json|table. Run 'kvctl <command> -h' for the flags of a command.")
+}
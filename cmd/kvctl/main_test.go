@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// fakeServer - a server holding values in a map, serving the routes kvctl uses
+func fakeServer() *httptest.Server {
+	values := map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/api/kv/")
+		switch {
+		case strings.HasPrefix(path, "search/"):
+			keys := []string{}
+			for key := range values {
+				if strings.HasPrefix(key, strings.TrimPrefix(path, "search/")) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			json.NewEncoder(w).Encode(keys)
+		case r.Method == "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			values[path] = string(body)
+		case r.Method == "DELETE":
+			delete(values, path)
+		case r.Method == "GET":
+			value, ok := values[strings.TrimSuffix(path, "/raw")]
+			if !ok {
+				http.Error(w, "key not found", http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, value)
+		}
+	}))
+}
+
+// capture - runs kvctl, returning its exit code and what it wrote to stdout
+func capture(t *testing.T, args ...string) (int, string) {
+	file, err := ioutil.TempFile("", "kvctl")
+	if err != nil {
+		t.Fatalf("temp file failed: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = file, file
+	code := run(args)
+	os.Stdout, os.Stderr = stdout, stderr
+
+	output, _ := ioutil.ReadFile(file.Name())
+	return code, string(output)
+}
+
+// Test the main commands against a server
+func TestCommands(t *testing.T) {
+	// Arrange
+	dir, err := ioutil.TempDir("", "kvctl")
+	if err != nil {
+		t.Fatalf("temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("KVCTL_CONFIG", filepath.Join(dir, "profiles.json"))
+	defer os.Unsetenv("KVCTL_CONFIG")
+	server := fakeServer()
+	defer server.Close()
+
+	cases := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{[]string{"set", "greeting", "hello"}, 0, ""},
+		{[]string{"set", "farewell", "bye"}, 0, ""},
+		{[]string{"get", "greeting"}, 0, "hello"},
+		{[]string{"get", "-o", "json", "greeting"}, 0, "\"hello\"\n"},
+		{[]string{"search", "-o", "json", "g"}, 0, "[\n  \"greeting\"\n]\n"},
+		{[]string{"delete", "greeting"}, 0, ""},
+		{[]string{"search", ""}, 0, "farewell\n"},
+		{[]string{"get", "greeting"}, 1, "kvctl get: 404 Not Found: key not found\n"},
+		{[]string{"get"}, 1, ""},
+		{[]string{"unknown"}, 2, ""},
+	}
+
+	for _, c := range cases {
+		// Act
+		code, output := capture(t, append(c.args, "-server", server.URL)...)
+
+		// Assert
+		if code != c.code || (c.output != "" && output != c.output) {
+			t.Errorf("kvctl %v exited %d with %q instead of %d with %q", c.args, code, output, c.code, c.output)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/aawadall/simple-kv/sdk/rest"
+)
+
+// defaultServer - the server used when neither a flag nor a profile names one
+const defaultServer = "http://localhost:8080"
+
+// clientOptions - flags choosing the server and the output, shared by every
+// command talking to a server
+type clientOptions struct {
+	server  string
+	tenant  string
+	profile string
+	output  string
+}
+
+func (o *clientOptions) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.server, "server", "", "server URL, overriding the profile")
+	flags.StringVar(&o.tenant, "tenant", "", "tenant, overriding the profile")
+	flags.StringVar(&o.profile, "profile", "", "profile naming the server, KVCTL_PROFILE or the current profile by default")
+	flags.StringVar(&o.output, "o", "raw", "output format: raw, json or table")
+}
+
+// client - a client of the chosen server, flags win over the profile
+func (o *clientOptions) client() (*rest.Client, error) {
+	selected := serverProfile{Server: defaultServer}
+
+	set, err := loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+	name := o.profile
+	if name == "" {
+		name = os.Getenv("KVCTL_PROFILE")
+	}
+	if name == "" {
+		name = set.Current
+	}
+	if name != "" {
+		found, ok := set.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("no profile %q", name)
+		}
+		selected = found
+	}
+
+	if o.server != "" {
+		selected.Server = o.server
+	}
+	if o.tenant != "" {
+		selected.Tenant = o.tenant
+	}
+	return rest.NewClient(selected.Server, selected.Tenant), nil
+}
+
+// printer - a printer of the chosen output format on stdout
+func (o *clientOptions) printer() (*printer, error) {
+	return newPrinter(o.output, os.Stdout)
+}
+
+// open - the client and printer chosen by the options
+func (o *clientOptions) open() (*rest.Client, *printer, error) {
+	out, err := o.printer()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := o.client()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, out, nil
+}
+
+// parseArgs - parses flags placed before, between or after the arguments,
+// everything after -- is an argument
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	positional := []string{}
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := flags.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// readValue - the value given as an argument, streamed from a file, or
+// streamed from stdin when the argument is - or missing
+func readValue(args []string, file string) (io.ReadCloser, error) {
+	switch {
+	case file != "" && len(args) > 0:
+		return nil, fmt.Errorf("give the value either as an argument or with -file")
+	case file != "":
+		return os.Open(file)
+	case len(args) == 0 || args[0] == "-":
+		return ioutil.NopCloser(os.Stdin), nil
+	case len(args) > 1:
+		return nil, fmt.Errorf("expected one value, got %d", len(args))
+	default:
+		return ioutil.NopCloser(strings.NewReader(args[0])), nil
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// output formats
+const (
+	// formatRaw - values as they are, rows as tab separated lines
+	formatRaw = "raw"
+	// formatJSON - values as JSON strings, results as JSON documents
+	formatJSON = "json"
+	// formatTable - rows as aligned columns under a header
+	formatTable = "table"
+)
+
+// printer - writes command results in an output format
+type printer struct {
+	format string
+	w      io.Writer
+	// table of streamed rows, its header is written with the first row
+	table *tabwriter.Writer
+}
+
+// newPrinter - a printer of the given format
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case formatRaw, formatJSON, formatTable:
+		return &printer{format: format, w: w}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected raw, json or table", format)
+	}
+}
+
+// value - writes a single value
+func (p *printer) value(data []byte) error {
+	switch p.format {
+	case formatJSON:
+		return json.NewEncoder(p.w).Encode(string(data))
+	case formatTable:
+		if len(data) == 0 || data[len(data)-1] != '\n' {
+			data = append(data, '\n')
+		}
+	}
+	_, err := p.w.Write(data)
+	return err
+}
+
+// rows - writes a result of rows, data is the result written as JSON
+func (p *printer) rows(headers []string, rows [][]string, data interface{}) error {
+	switch p.format {
+	case formatJSON:
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case formatTable:
+		table := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(table, strings.ToUpper(strings.Join(headers, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(table, strings.Join(row, "\t"))
+		}
+		return table.Flush()
+	default:
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(p.w, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// row - writes one row of a stream of rows, data is the row written as a
+// JSON line
+func (p *printer) row(headers []string, row []string, data interface{}) error {
+	switch p.format {
+	case formatJSON:
+		return json.NewEncoder(p.w).Encode(data)
+	case formatTable:
+		// every row is flushed as it arrives, so columns get a minimum width
+		// instead of fitting the widest cell
+		if p.table == nil {
+			p.table = tabwriter.NewWriter(p.w, 12, 4, 2, ' ', 0)
+			fmt.Fprintln(p.table, strings.ToUpper(strings.Join(headers, "\t")))
+		}
+		fmt.Fprintln(p.table, strings.Join(row, "\t"))
+		return p.table.Flush()
+	default:
+		_, err := fmt.Fprintln(p.w, strings.Join(row, "\t"))
+		return err
+	}
+}
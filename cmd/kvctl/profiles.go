@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// profiles - named servers kept in the profiles file, so commands can switch
+// servers with -profile, KVCTL_PROFILE or the current profile
+
+// profilesFile - the profiles file in the home directory, KVCTL_CONFIG names
+// another one
+const profilesFile = ".kvctl.json"
+
+// serverProfile - a server and the tenant to use on it
+type serverProfile struct {
+	Server string `json:"server"`
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// profileSet - contents of the profiles file
+type profileSet struct {
+	Current  string                   `json:"current,omitempty"`
+	Profiles map[string]serverProfile `json:"profiles"`
+	path     string
+}
+
+// loadProfiles - reads the profiles file, a missing file has no profiles
+func loadProfiles() (*profileSet, error) {
+	path := os.Getenv("KVCTL_CONFIG")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, profilesFile)
+	}
+
+	set := &profileSet{Profiles: make(map[string]serverProfile), path: path}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return set, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	if set.Profiles == nil {
+		set.Profiles = make(map[string]serverProfile)
+	}
+	return set, nil
+}
+
+// save - writes the profiles file back
+func (p *profileSet) save() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.path, append(data, '\n'), 0600)
+}
+
+// profile - the profile command
+func profile(args []string) error {
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	server := flags.String("server", "", "server URL of the profile, for add")
+	tenant := flags.String("tenant", "", "tenant of the profile, for add")
+	output := flags.String("o", "table", "output format: raw, json or table")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl profile list")
+		fmt.Fprintln(flags.Output(), "       kvctl profile add NAME -server URL [-tenant TENANT]")
+		fmt.Fprintln(flags.Output(), "       kvctl profile use NAME")
+		fmt.Fprintln(flags.Output(), "       kvctl profile remove NAME")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		flags.Usage()
+		return fmt.Errorf("expected an action")
+	}
+
+	set, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+
+	action, args := args[0], args[1:]
+	if action == "list" {
+		out, err := newPrinter(*output, os.Stdout)
+		if err != nil {
+			return err
+		}
+		names := make([]string, 0, len(set.Profiles))
+		for name := range set.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		rows := make([][]string, 0, len(names))
+		for _, name := range names {
+			current := ""
+			if name == set.Current {
+				current = "*"
+			}
+			rows = append(rows, []string{current, name, set.Profiles[name].Server, set.Profiles[name].Tenant})
+		}
+		return out.rows([]string{"current", "name", "server", "tenant"}, rows, set)
+	}
+
+	if len(args) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the profile name")
+	}
+	name := args[0]
+	switch action {
+	case "add":
+		if *server == "" {
+			return fmt.Errorf("-server is required")
+		}
+		set.Profiles[name] = serverProfile{Server: *server, Tenant: *tenant}
+		if set.Current == "" {
+			set.Current = name
+		}
+	case "use":
+		if _, ok := set.Profiles[name]; !ok {
+			return fmt.Errorf("no profile %q", name)
+		}
+		set.Current = name
+	case "remove":
+		if _, ok := set.Profiles[name]; !ok {
+			return fmt.Errorf("no profile %q", name)
+		}
+		delete(set.Profiles, name)
+		if set.Current == name {
+			set.Current = ""
+		}
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown action %q", action)
+	}
+	return set.save()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aawadall/simple-kv/sdk/rest"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// exports - one JSON line per key, holding the latest value and the metadata,
+// values are base64 encoded so binary values survive the round trip
+
+// exportEntry - a line of an export
+type exportEntry struct {
+	Key      string            `json:"key"`
+	Value    []byte            `json:"value"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// export - the export command
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	file := flags.String("file", "", "write the export to a file instead of stdout")
+	byMetadata := flags.Bool("metadata", false, "export the keys matching a metadata query instead of a key prefix")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: kvctl export [flags] PREFIX")
+		fmt.Fprintln(flags.Output(), "       kvctl export -metadata [flags] QUERY")
+		flags.PrintDefaults()
+	}
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the prefix or query")
+	}
+
+	client, err := options.client()
+	if err != nil {
+		return err
+	}
+	keys, err := findKeys(client, args[0], *byMetadata)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *file != "" {
+		created, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer created.Close()
+		w = created
+	}
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+
+	ctx := context.Background()
+	for _, key := range keys {
+		value, err := client.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("%v: %w", key, err)
+		}
+		metadata, err := client.ListMetadata(ctx, key)
+		if err != nil {
+			return fmt.Errorf("%v: %w", key, err)
+		}
+		if err := encoder.Encode(exportEntry{Key: key, Value: value, Metadata: metadata}); err != nil {
+			return err
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d keys\n", len(keys))
+	return nil
+}
+
+// importKeys - the import command
+func importKeys(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	options := clientOptions{}
+	options.register(flags)
+	file := flags.String("file", "", "read the export from a file instead of stdin")
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	client, err := options.client()
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *file != "" {
+		opened, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer opened.Close()
+		r = opened
+	}
+
+	decoder := json.NewDecoder(r)
+	count := 0
+	for {
+		var entry exportEntry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("imported %d keys before failing: %w", count, err)
+		}
+		if err := importEntry(client, entry); err != nil {
+			return fmt.Errorf("imported %d keys before failing: %v: %w", count, entry.Key, err)
+		}
+		count++
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d keys\n", count)
+	return nil
+}
+
+// importEntry - writes the value and metadata of an exported key, the version
+// entry belongs to the server, which also tags documents and chunked values
+// itself, other value types are tagged back
+func importEntry(client *rest.Client, entry exportEntry) error {
+	ctx := context.Background()
+	valueType := entry.Metadata[types.MetadataValueType]
+
+	var err error
+	if valueType == types.ValueTypeDocument {
+		err = client.SetDocument(ctx, entry.Key, entry.Value)
+	} else {
+		err = client.Set(ctx, entry.Key, bytes.NewReader(entry.Value))
+	}
+	if err != nil {
+		return err
+	}
+
+	for name, value := range entry.Metadata {
+		if name == "Version" {
+			continue
+		}
+		if name == types.MetadataValueType && (value == types.ValueTypeDocument || value == types.ValueTypeChunked) {
+			continue
+		}
+		if err := client.SetMetadata(ctx, entry.Key, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
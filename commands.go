@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aawadall/simple-kv/config"
+	"github.com/aawadall/simple-kv/health"
+	kvserver "github.com/aawadall/simple-kv/kv_server"
+	"github.com/aawadall/simple-kv/persistence"
+)
+
+// files and directories kept in the data directory
+const (
+	sqliteFile = "kv.sqlite"
+	logFile    = "kv.log"
+	cdcDir     = "cdc"
+)
+
+// storeOptions - flags choosing where the data lives and how the server is
+// configured, shared by every command touching the data
+type storeOptions struct {
+	configFile string
+	dataDir    string
+	driver     string
+	settings   settingFlags
+}
+
+func (o *storeOptions) register(flags *flag.FlagSet) {
+	o.settings = make(settingFlags)
+	flags.StringVar(&o.configFile, "config", "", "configuration `file`: .json, .yaml, .toml, or key = value lines")
+	flags.StringVar(&o.dataDir, "data-dir", "data", "directory holding the data files")
+	flags.StringVar(&o.driver, "driver", "sqlite", "persistence driver: sqlite, log, mock or none")
+	flags.Var(o.settings, "set", "a setting as `name=value`, such as server.sync_interval=5s, repeatable")
+}
+
+// load - the configuration, each layer over the one before: the defaults, the
+// configuration file, KV_ environment variables and the flags given on the
+// command line, flag defaults only fill what the file and environment leave out
+func (o *storeOptions) load(flags *flag.FlagSet) (*config.Config, error) {
+	given := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	defaults, overrides := make(map[string]string), make(map[string]string)
+	setting := func(name string, value string, flagName string) {
+		if given[flagName] {
+			overrides[name] = value
+		} else {
+			defaults[name] = value
+		}
+	}
+
+	setting("storage.driver", o.driver, "driver")
+	setting("storage.db_location", filepath.Join(o.dataDir, sqliteFile), "data-dir")
+	setting("storage.file_location", filepath.Join(o.dataDir, logFile), "data-dir")
+	setting("cdc.dir", filepath.Join(o.dataDir, cdcDir), "data-dir")
+	for name, value := range o.settings {
+		overrides[name] = value
+	}
+
+	return config.Load(config.Options{Defaults: defaults, File: o.configFile, Flags: overrides})
+}
+
+// configuration - the configuration, once the data directory exists
+func (o *storeOptions) configuration(flags *flag.FlagSet) (*config.Config, error) {
+	settings, err := o.load(flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(o.dataDir, 0755); err != nil {
+		return nil, err
+	}
+	// every component logs through a child of the root logger
+	health.SetDefault(health.NewLogger(settings.LogOptions()))
+	return settings, nil
+}
+
+// settingFlags - settings given with repeated -set flags, by name
+type settingFlags map[string]string
+
+func (s settingFlags) String() string {
+	pairs := []string{}
+	for name, value := range s {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func (s settingFlags) Set(pair string) error {
+	split := strings.SplitN(pair, "=", 2)
+	if len(split) != 2 || strings.TrimSpace(split[0]) == "" {
+		return fmt.Errorf("expected name=value")
+	}
+	s[strings.TrimSpace(split[0])] = split[1]
+	return nil
+}
+
+// openStore - a persistence manager over the configured driver
+func openStore(settings *config.Config) *persistence.PersistenceManager {
+	return persistence.NewPersistenceManager(settings.Map())
+}
+
+// serve - the serve command
+func serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	restPort := flags.String("rest-port", "", "REST API port, overriding server.rest_port")
+	grpcPort := flags.String("grpc-port", "", "gRPC API port, overriding server.grpc_port")
+	shutdownTimeout := flags.Duration("shutdown-timeout", 30*time.Second, "time given to in-flight requests on shutdown")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *restPort != "" {
+		store.settings["server.rest_port"] = *restPort
+	}
+	if *grpcPort != "" {
+		store.settings["server.grpc_port"] = *grpcPort
+	}
+
+	settings, err := store.configuration(flags)
+	if err != nil {
+		return err
+	}
+
+	kvserver.Version = version
+	server := kvserver.NewKVServerFrom(settings)
+	if err := server.Start(context.Background()); err != nil {
+		return err
+	}
+
+	// run until interrupted, then drain requests and flush records
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	return server.Stop(ctx)
+}
+
+// migrate - the migrate command
+func migrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	toDriver := flags.String("to-driver", "", "driver to copy the records to, the source driver by default")
+	toDataDir := flags.String("to-data-dir", "", "data directory to copy the records to, the source directory by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *toDriver == "" && *toDataDir == "" {
+		return fmt.Errorf("one of -to-driver and -to-data-dir is required")
+	}
+
+	settings, err := store.configuration(flags)
+	if err != nil {
+		return err
+	}
+
+	// the target differs from the source only in its driver and data files
+	target := *settings
+	if *toDriver != "" {
+		target.Storage.Driver = *toDriver
+	}
+	if *toDataDir != "" {
+		target.Storage.DBLocation = filepath.Join(*toDataDir, sqliteFile)
+		target.Storage.FileLocation = filepath.Join(*toDataDir, logFile)
+	}
+	if err := target.Validate(); err != nil {
+		return err
+	}
+	if target.Storage == settings.Storage {
+		return fmt.Errorf("source and target are the same store")
+	}
+	if *toDataDir != "" {
+		if err := os.MkdirAll(*toDataDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	count, err := openStore(settings).CopyTo(openStore(&target))
+	if err != nil {
+		return fmt.Errorf("migrated %d records before failing: %w", count, err)
+	}
+	fmt.Printf("Migrated %d records to %v\n", count, target.Storage.Driver)
+	return nil
+}
+
+// backup - the backup command
+func backup(args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: simple-kv backup [flags] FILE")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the backup file")
+	}
+
+	settings, err := store.configuration(flags)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	count, err := openStore(settings).Backup(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %d records to %v\n", count, flags.Arg(0))
+	return nil
+}
+
+// restore - the restore command
+func restore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: simple-kv restore [flags] FILE")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected the backup file")
+	}
+
+	settings, err := store.configuration(flags)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	count, err := openStore(settings).Restore(file)
+	if err != nil {
+		return fmt.Errorf("restored %d records before failing: %w", count, err)
+	}
+	fmt.Printf("Restored %d records from %v\n", count, flags.Arg(0))
+	return nil
+}
+
+// check - the check command
+func check(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	settings, err := store.configuration(flags)
+	if err != nil {
+		return err
+	}
+
+	problems, err := openStore(settings).Check()
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problems", len(problems))
+	}
+	fmt.Println("No problems found")
+	return nil
+}
+
+// showConfig - the config command, prints the effective configuration and
+// where each value came from
+func showConfig(args []string) error {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "Usage: simple-kv config print [flags]")
+		return fmt.Errorf("expected the print subcommand")
+	}
+
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	store := storeOptions{}
+	store.register(flags)
+	format := flags.String("format", "table", "output format: table, or json with a description of each setting")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// values that cannot be used are printed along with the problems
+	settings, loadErr := store.load(flags)
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(settings.Settings()); err != nil {
+			return err
+		}
+	case "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SETTING\tVALUE\tSOURCE")
+		for _, setting := range settings.Settings() {
+			fmt.Fprintf(w, "%v\t%v\t%v\n", setting.Name, setting.Value, setting.Source)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	return loadErr
+}
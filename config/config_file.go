@@ -0,0 +1,251 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Configuration files
+// the format follows the extension: .json, .yaml or .yml, .toml, and
+// `key = value` lines otherwise, sections of a file give the dotted names,
+// lists are read as comma separated values
+
+// LoadFile - A function that reads a configuration file into values by
+// dotted name, or by flat name where the file uses them
+func LoadFile(path string) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return loadJSON(path)
+	case ".yaml", ".yml":
+		return loadLines(path, yamlReader())
+	case ".toml":
+		return loadLines(path, tomlReader())
+	}
+	return loadLines(path, plainReader)
+}
+
+// lineReader - reads one line of a file into the values, the line is trimmed
+// of surrounding space and comments, indent is the space it started with
+type lineReader func(values map[string]string, indent int, text string) error
+
+// loadLines - reads a file line by line, blank lines and comments are skipped
+func loadLines(path string, read lineReader) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		raw := strings.TrimRight(scanner.Text(), " \t\r")
+		text := strings.TrimSpace(stripComment(raw))
+		if text == "" {
+			continue
+		}
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		if err := read(values, indent, text); err != nil {
+			return nil, fmt.Errorf("%v:%d: %v", path, line, err)
+		}
+	}
+	return values, scanner.Err()
+}
+
+// plainReader - reads `key = value` lines, values are taken as they are
+func plainReader(values map[string]string, indent int, text string) error {
+	split := strings.SplitN(text, "=", 2)
+	key := strings.TrimSpace(split[0])
+	if len(split) != 2 || key == "" {
+		return fmt.Errorf("expected `key = value`")
+	}
+	values[key] = strings.TrimSpace(split[1])
+	return nil
+}
+
+// tomlReader - reads `[section]` headers and `key = value` lines, strings may
+// be quoted and lists are written `[a, b]`
+func tomlReader() lineReader {
+	section := ""
+	return func(values map[string]string, indent int, text string) error {
+		if strings.HasPrefix(text, "[") {
+			if strings.HasPrefix(text, "[[") || !strings.HasSuffix(text, "]") {
+				return fmt.Errorf("expected `[section]`")
+			}
+			section = strings.TrimSpace(text[1:len(text)-1]) + "."
+			return nil
+		}
+
+		split := strings.SplitN(text, "=", 2)
+		key := strings.TrimSpace(split[0])
+		if len(split) != 2 || key == "" {
+			return fmt.Errorf("expected `key = value`")
+		}
+		value, err := scalar(split[1])
+		if err != nil {
+			return err
+		}
+		values[section+unquote(key)] = value
+		return nil
+	}
+}
+
+// yamlReader - reads nested mappings of `key: value` lines, lists are written
+// `[a, b]` or as `- item` lines under their key
+func yamlReader() lineReader {
+	type level struct {
+		indent int
+		prefix string
+	}
+	levels := []level{{indent: -1}}
+	// list - the key opened last, list items are added to it
+	list, listIndent := "", 0
+	return func(values map[string]string, indent int, text string) error {
+		if text == "---" {
+			return nil
+		}
+		if text == "-" || strings.HasPrefix(text, "- ") {
+			if list == "" || indent < listIndent {
+				return fmt.Errorf("list item outside of a list")
+			}
+			item, err := scalar(text[1:])
+			if err != nil {
+				return err
+			}
+			if values[list] != "" {
+				item = values[list] + "," + item
+			}
+			values[list] = item
+			return nil
+		}
+
+		for indent <= levels[len(levels)-1].indent {
+			levels = levels[:len(levels)-1]
+		}
+		split := strings.SplitN(text, ":", 2)
+		key := unquote(strings.TrimSpace(split[0]))
+		if len(split) != 2 || key == "" {
+			return fmt.Errorf("expected `key: value`")
+		}
+		name := levels[len(levels)-1].prefix + key
+
+		// a key without a value opens a mapping or a list
+		if strings.TrimSpace(split[1]) == "" {
+			levels = append(levels, level{indent: indent, prefix: name + "."})
+			list, listIndent = name, indent
+			return nil
+		}
+		list = ""
+		value, err := scalar(split[1])
+		if err != nil {
+			return err
+		}
+		values[name] = value
+		return nil
+	}
+}
+
+// loadJSON - reads a JSON object, nested objects give the dotted names
+func loadJSON(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	values := make(map[string]string)
+	var flatten func(prefix string, value interface{})
+	flatten = func(prefix string, value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, nested := range value {
+				flatten(prefix+key+".", nested)
+			}
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, jsonScalar(item))
+			}
+			values[strings.TrimSuffix(prefix, ".")] = strings.Join(items, ",")
+		default:
+			values[strings.TrimSuffix(prefix, ".")] = jsonScalar(value)
+		}
+	}
+	flatten("", document)
+	return values, nil
+}
+
+// Helper Functions
+// stripComment - removes a comment started by # outside of quotes, at the
+// start of a line or after a space
+func stripComment(line string) string {
+	quote := rune(0)
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// scalar - reads a value, quoted or not, a list `[a, b]` is read as `a,b`
+func scalar(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "[") {
+		if !strings.HasSuffix(text, "]") {
+			return "", fmt.Errorf("lists must be written on one line")
+		}
+		items := []string{}
+		for _, item := range strings.Split(text[1:len(text)-1], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, unquote(item))
+			}
+		}
+		return strings.Join(items, ","), nil
+	}
+	if text == "~" || text == "null" {
+		return "", nil
+	}
+	return unquote(text), nil
+}
+
+// unquote - removes double or single quotes around a value
+func unquote(text string) string {
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		if unquoted, err := strconv.Unquote(text); err == nil {
+			return unquoted
+		}
+	}
+	if len(text) >= 2 && text[0] == '\'' && text[len(text)-1] == '\'' {
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1)
+	}
+	return text
+}
+
+// jsonScalar - formats a JSON value the way it would be written in a file
+func jsonScalar(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
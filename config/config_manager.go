@@ -2,34 +2,61 @@ package config
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"strings"
+	"sync"
+
+	"github.com/aawadall/simple-kv/health"
 )
 
 // Configuration Manager
 
 type ConfigurationManager struct {
 	Configuration map[string]interface{}
-	logger        *log.Logger
+	logger        *health.Logger
+
+	mu       sync.RWMutex
+	settings *Config
+	// invalid - the problems found loading the settings
+	invalid error
+	// pending - settings changed since loading that wait for a restart
+	pending     []string
+	subscribers []func(Change)
+
+	// reloading - serializes reloads
+	reloading sync.Mutex
+	stop      chan struct{}
+	done      chan struct{}
 }
 
 // NewConfigurationManager - A function that creates a new Configuration Manager
+// from values by dotted or flat name, over the environment and the defaults
 func NewConfigurationManager(configMap map[string]string) *ConfigurationManager {
+	settings, err := Load(Options{Flags: configMap})
+	cfg := NewConfigurationManagerFrom(settings)
+	cfg.invalid = err
+	return cfg
+}
+
+// NewConfigurationManagerFrom - A function that creates a new Configuration
+// Manager from loaded settings, which already hold the KV_ environment
+func NewConfigurationManagerFrom(settings *Config) *ConfigurationManager {
 	cfg := &ConfigurationManager{
-		Configuration: make(map[string]interface{}),
-		logger:        log.New(os.Stdout, "CONFIG: ", log.LstdFlags),
-	}
-	// append configuration from configMap
-	for key, value := range configMap {
-		cfg.Configuration[key] = value
+		Configuration: settings.Map(),
+		settings:      settings,
+		invalid:       settings.Validate(),
+		logger:        health.Default().Component("config"),
 	}
 
-	cfg.logger.Println("Configuration Manager created")
-	cfg.LoadFromEnvironment()
+	cfg.logger.Debug("Configuration Manager created")
 	return cfg
 }
 
+// SetLogger - A function that sets the logger of the Configuration Manager
+func (c *ConfigurationManager) SetLogger(logger *health.Logger) {
+	c.logger = logger
+}
+
 // Get - A function that gets a configuration value
 func (c *ConfigurationManager) Get(key string) (value interface{}, err error) {
 	// if the key is empty return error
@@ -72,24 +99,45 @@ func (c *ConfigurationManager) Set(key string, value interface{}) (err error) {
 	return nil
 }
 
-// LoadFromEnvironment - A function that loads the configuration from the environment
+// LoadFromEnvironment - A function that loads the KV_ environment variables
+// into the configuration, by the flat name of their setting or its dotted name
+// when it has none, other variables and secrets are left out
 func (c *ConfigurationManager) LoadFromEnvironment() (err error) {
-	c.logger.Println("Loading configuration from environment")
-	// Get all environment variables
-	envVars := os.Environ()
-
-	// Iterate over the environment variables
-	for _, envVar := range envVars {
-		// Split the environment variable into key and value
-		key, value := splitEnvVar(envVar)
-		// Set the configuration
+	c.logger.Debug("Loading configuration from environment")
+	for _, envVar := range os.Environ() {
+		name, value := splitEnvVar(envVar)
+		key, ok := fromEnvName(name)
+		if !ok {
+			continue
+		}
+		s, tenant, _ := lookup(key)
+		if s.secret {
+			continue
+		}
+		if tenant == "" && s.legacy != "" {
+			key = s.legacy
+		}
 		c.Set(key, value)
 	}
 
 	return nil
 }
 
-// GetConfig - A function that gets all configuration
+// Settings - A function that gets the typed settings in effect, replaced
+// rather than changed on reload
+func (c *ConfigurationManager) Settings() *Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.settings
+}
+
+// Validate - A function that reports every problem found in the settings
+func (c *ConfigurationManager) Validate() error {
+	return c.invalid
+}
+
+// GetConfig - A function that gets all configuration by flat name, without
+// secrets
 func (c *ConfigurationManager) GetConfig() map[string]interface{} {
 	return c.Configuration
 }
@@ -3,5 +3,6 @@ package config
 /*
 Package config - This package is responsible for handling:
 - cluster configuration
-- application configuration
+- application configuration, typed settings loaded from defaults, a file,
+  KV_ environment variables and flags, each over the one before
 */
@@ -0,0 +1,406 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Loading
+// values are taken from, each over the one before:
+//  1. the defaults of the schema, then those of the command
+//  2. the configuration file
+//  3. environment variables, KV_ followed by the dotted name in upper case with
+//     dots as underscores, such as KV_SERVER_SYNC_INTERVAL, others are ignored
+//  4. command line flags
+//
+// the values of secret settings are redacted wherever settings are shown or
+// their problems reported
+
+// EnvPrefix - prefix of the environment variables read
+const EnvPrefix = "KV_"
+
+// Redacted - shown instead of the value of a secret setting
+const Redacted = "********"
+
+// ErrInvalidConfig - the configuration has values that cannot be used
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// Source - where a value came from
+type Source string
+
+// sources
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Options - the layers a configuration is loaded from
+type Options struct {
+	// Defaults - defaults of the command, over those of the schema
+	Defaults map[string]string
+	// File - configuration file, none when empty
+	File string
+	// Environment - `NAME=value` pairs, the process environment when nil
+	Environment []string
+	// Flags - values given on the command line
+	Flags map[string]string
+}
+
+// ValidationError - every problem found loading a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v:\n  %v", ErrInvalidConfig, strings.Join(e.Problems, "\n  "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Setting - the effective value of a setting, as printed
+type Setting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+	// Secret - the value is redacted, when set
+	Secret bool   `json:"secret,omitempty"`
+	Help   string `json:"help,omitempty"`
+}
+
+// Load - loads a configuration from its layers, the configuration returned
+// with an error is still complete, values that cannot be parsed keep their
+// default
+func Load(options Options) (*Config, error) {
+	c := &Config{options: options, sources: make(map[string]Source), raw: make(map[string]string)}
+	problems := []string{}
+	set := func(name string, value string, source Source) {
+		s, tenant, ok := lookup(name)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%v: unknown setting (from %v)", name, source))
+			return
+		}
+		key := canonical(s, tenant)
+		c.raw[key], c.sources[key] = value, source
+	}
+
+	for _, name := range sortedKeys(options.Defaults) {
+		set(name, options.Defaults[name], SourceDefault)
+	}
+
+	if options.File != "" {
+		values, err := LoadFile(options.File)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		for _, name := range sortedKeys(values) {
+			set(name, values[name], Source(fmt.Sprintf("%v %v", SourceFile, options.File)))
+		}
+	}
+
+	environment := options.Environment
+	if environment == nil {
+		environment = os.Environ()
+	}
+	for _, pair := range environment {
+		name, value := splitEnvVar(pair)
+		if key, ok := fromEnvName(name); ok {
+			set(key, value, Source(fmt.Sprintf("%v %v", SourceEnv, name)))
+		}
+	}
+
+	for _, name := range sortedKeys(options.Flags) {
+		set(name, options.Flags[name], SourceFlag)
+	}
+
+	problems = append(problems, c.resolve()...)
+	if len(problems) > 0 {
+		return c, &ValidationError{Problems: problems}
+	}
+	return c, nil
+}
+
+// Source - where the value of a dotted name came from
+func (c *Config) Source(name string) Source {
+	if s, tenant, ok := lookup(name); ok {
+		if source, ok := c.sources[canonical(s, tenant)]; ok {
+			return source
+		}
+	}
+	return SourceDefault
+}
+
+// Settings - the effective value of every setting and where it came from,
+// in the order of the schema followed by per tenant quotas, secrets redacted
+func (c *Config) Settings() []Setting {
+	return c.list(true)
+}
+
+// Effective - the effective value of every setting by dotted name, loading them
+// back gives the same configuration
+func (c *Config) Effective() map[string]string {
+	values := make(map[string]string)
+	for _, setting := range c.list(false) {
+		values[setting.Name] = setting.Value
+	}
+	return values
+}
+
+// Map - the effective values by the flat names used before sections, empty
+// values are left out so readers fall back to their own defaults, as are
+// secrets
+func (c *Config) Map() map[string]interface{} {
+	values := make(map[string]interface{})
+	for i := range schema {
+		s := &schema[i]
+		if value := format(s.field(c)); value != "" && s.legacy != "" && !s.secret {
+			values[s.legacy] = value
+		}
+	}
+	return values
+}
+
+// Validate - checks every value is in range
+func (c *Config) Validate() error {
+	if problems := c.check(); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Helper Functions
+// list - every setting in the order of the schema followed by per tenant
+// quotas, with the values of secrets redacted or not
+func (c *Config) list(redact bool) []Setting {
+	settings := []Setting{}
+	for i := range schema {
+		s := &schema[i]
+		value := format(s.field(c))
+		if redact && s.secret && value != "" {
+			value = Redacted
+		}
+		settings = append(settings, Setting{
+			Name:   s.name,
+			Value:  value,
+			Source: c.Source(s.name),
+			Secret: s.secret,
+			Help:   s.help,
+		})
+	}
+
+	tenants := make([]string, 0, len(c.Quota.Tenants))
+	for tenant := range c.Quota.Tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	for _, tenant := range tenants {
+		scoped := &Config{Quota: QuotaConfig{Defaults: c.Quota.Tenants[tenant]}}
+		for i := range schema {
+			s := &schema[i]
+			if !strings.HasPrefix(s.name, "quota.") {
+				continue
+			}
+			// limits not given for the tenant come from those of every tenant
+			name, source := canonical(s, tenant), c.Source(s.name)
+			if given, ok := c.sources[name]; ok {
+				source = given
+			}
+			settings = append(settings, Setting{Name: name, Value: format(s.field(scoped)), Source: source})
+		}
+	}
+	return settings
+}
+
+// check - the problems of values out of range, per tenant quotas are checked
+// as they are parsed
+func (c *Config) check() []string {
+	problems := []string{}
+	for i := range schema {
+		s := &schema[i]
+		if s.check == nil {
+			continue
+		}
+		if err := s.check(c); err != nil {
+			problems = append(problems, fmt.Sprintf("%v: %v (from %v)", s.name, err, c.Source(s.name)))
+		}
+	}
+	return problems
+}
+
+// resolve - parses the defaults, then the values given, returning every
+// problem found
+func (c *Config) resolve() []string {
+	problems := []string{}
+	report := func(key string, err error) {
+		problems = append(problems, fmt.Sprintf("%v: %v (from %v)", key, err, c.Source(key)))
+	}
+
+	for i := range schema {
+		s := &schema[i]
+		if err := parse(s, s.value, s.field(c)); err != nil {
+			panic(fmt.Sprintf("default of %v: %v", s.name, err))
+		}
+	}
+
+	// per tenant quotas are parsed once the limits they start from are
+	tenants, tenantKeys := []string{}, make(map[string][]string)
+	for _, key := range sortedKeys(c.raw) {
+		s, tenant, _ := lookup(key)
+		if tenant != "" {
+			if _, ok := tenantKeys[tenant]; !ok {
+				tenants = append(tenants, tenant)
+			}
+			tenantKeys[tenant] = append(tenantKeys[tenant], key)
+			continue
+		}
+		if err := parse(s, c.raw[key], s.field(c)); err != nil {
+			if s.secret {
+				err = fmt.Errorf("invalid value")
+			}
+			report(key, err)
+			parse(s, s.value, s.field(c))
+		}
+	}
+
+	problems = append(problems, c.check()...)
+
+	c.Quota.Tenants = make(map[string]types.TenantQuota)
+	for _, tenant := range tenants {
+		scoped := &Config{Quota: QuotaConfig{Defaults: c.Quota.Defaults}}
+		for _, key := range tenantKeys[tenant] {
+			s, _, _ := lookup(key)
+			err := parse(s, c.raw[key], s.field(scoped))
+			if err == nil {
+				err = s.check(scoped)
+			}
+			if err != nil {
+				report(key, err)
+			}
+		}
+		c.Quota.Tenants[tenant] = scoped.Quota.Defaults
+	}
+	return problems
+}
+
+// parse - parses a value into the field of a setting
+func parse(s *setting, value string, field interface{}) error {
+	value = strings.TrimSpace(value)
+	switch field := field.(type) {
+	case *string:
+		*field = value
+	case *bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		*field = parsed
+	case *int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*field = parsed
+	case *int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		*field = parsed
+	case *time.Duration:
+		// a bare number is in the unit of the setting, as before durations
+		if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+			*field = time.Duration(count) * s.unit
+			return nil
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+		*field = parsed
+	case *[]string:
+		*field = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*field = append(*field, item)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported field %T", field)
+	}
+	return nil
+}
+
+// format - formats a field the way parse reads it
+func format(field interface{}) string {
+	switch field := field.(type) {
+	case *string:
+		return *field
+	case *bool:
+		return strconv.FormatBool(*field)
+	case *int:
+		return strconv.Itoa(*field)
+	case *int64:
+		return strconv.FormatInt(*field, 10)
+	case *time.Duration:
+		return field.String()
+	case *[]string:
+		return strings.Join(*field, ",")
+	}
+	return fmt.Sprintf("%v", field)
+}
+
+// fromEnvName - the dotted name of an environment variable, per tenant quotas
+// are read as KV_QUOTA_TENANTS_<TENANT>_<LIMIT>
+func fromEnvName(name string) (string, bool) {
+	if !strings.HasPrefix(name, EnvPrefix) {
+		return "", false
+	}
+	for i := range schema {
+		s := &schema[i]
+		if envName(s.name) == name {
+			return s.name, true
+		}
+		for _, alias := range s.aliases {
+			if alias == name {
+				return s.name, true
+			}
+		}
+	}
+
+	scoped := envName(tenantQuotaPrefix)
+	if !strings.HasPrefix(name, scoped) {
+		return "", false
+	}
+	for i := range schema {
+		s := &schema[i]
+		suffix := "_" + strings.ToUpper(strings.TrimPrefix(s.name, "quota."))
+		if !strings.HasPrefix(s.name, "quota.") || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		tenant := strings.TrimSuffix(strings.TrimPrefix(name, scoped), suffix)
+		if tenant != "" {
+			return canonical(s, strings.ToLower(tenant)), true
+		}
+	}
+	return "", false
+}
+
+// sortedKeys - the keys of a map in order, so loading is repeatable
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile - writes a configuration file in a temporary directory, removed
+// with the directory of the path returned
+func writeFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatalf("could not create a directory: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("could not write %v: %v", path, err)
+	}
+	return path
+}
+
+// Test that each layer wins over the one before and is reported as the source
+func TestLoadPrecedence(t *testing.T) {
+	// Arrange
+	path := writeFile(t, "kv.yaml", `
+server:
+  sync_interval: 5   # seconds
+  rest_port: 8181
+storage:
+  driver: log
+webhooks:
+  urls:
+    - http://localhost:9000/a
+    - "http://127.0.0.1:9000/b"
+quota:
+  max_keys: 100
+  tenants:
+    acme:
+      max_value_size: 8
+`)
+	defer os.RemoveAll(filepath.Dir(path))
+	options := Options{
+		Defaults:    map[string]string{"storage.driver": "sqlite", "cdc.dir": "data/cdc"},
+		File:        path,
+		Environment: []string{"KV_SERVER_REST_PORT=8282", "KV_GRPC_PORT=9191", "PATH=/bin"},
+		Flags:       map[string]string{"rest_port": "8383"},
+	}
+
+	// Act
+	c, err := Load(options)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("load returned error %v", err)
+	}
+	if c.Server.SyncInterval != 5*time.Second || c.Server.RestPort != 8383 || c.Server.GrpcPort != 9191 {
+		t.Errorf("unexpected server settings %+v", c.Server)
+	}
+	if c.Storage.Driver != "log" || c.CDC.Dir != "data/cdc" || c.Values.ChunkSize != 1<<20 {
+		t.Errorf("unexpected settings %+v %+v %+v", c.Storage, c.CDC, c.Values)
+	}
+	if len(c.Webhooks.URLs) != 2 || c.Webhooks.URLs[1] != "http://127.0.0.1:9000/b" {
+		t.Errorf("unexpected webhooks %v", c.Webhooks.URLs)
+	}
+	if acme := c.Quota.Tenants["acme"]; acme.MaxKeys != 100 || acme.MaxValueSize != 8 {
+		t.Errorf("a tenant quota should start from the default limits, got %+v", acme)
+	}
+	sources := map[string]Source{
+		"server.sync_interval": Source("file " + path),
+		"server.rest_port":     SourceFlag,
+		"grpc_port":            Source("env KV_GRPC_PORT"),
+		"cdc.dir":              SourceDefault,
+		"values.chunk_size":    SourceDefault,
+	}
+	for name, source := range sources {
+		if got := c.Source(name); got != source {
+			t.Errorf("source of %v is %q instead of %q", name, got, source)
+		}
+	}
+	if c.Map()["driver"] != "log" {
+		t.Errorf("flat names should carry the effective values, got %v", c.Map())
+	}
+}
+
+// Test that TOML and JSON files read like YAML ones
+func TestLoadFormats(t *testing.T) {
+	files := map[string]string{
+		"kv.toml": "[server]\nsync_interval = \"1m\" # a minute\n\n[cdc]\nsinks = [\"file\", 'socket']\n",
+		"kv.json": `{"server": {"sync_interval": "1m"}, "cdc": {"sinks": ["file", "socket"]}}`,
+		"kv.conf": "sync_interval = 60\ncdc.sinks = file,socket\n",
+	}
+	for name, content := range files {
+		// Arrange
+		path := writeFile(t, name, content)
+		defer os.RemoveAll(filepath.Dir(path))
+
+		// Act
+		c, err := Load(Options{File: path, Environment: []string{}})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("%v: load returned error %v", name, err)
+		}
+		if c.Server.SyncInterval != time.Minute || strings.Join(c.CDC.Sinks, ",") != "file,socket" {
+			t.Errorf("%v: unexpected settings %+v %+v", name, c.Server, c.CDC)
+		}
+	}
+}
+
+// Test that every problem is reported at once
+func TestLoadProblems(t *testing.T) {
+	// Arrange
+	path := writeFile(t, "kv.toml", "[server]\nsync_interval = \"soon\"\n[storage]\ndriver = \"mongo\"\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	options := Options{
+		File:        path,
+		Environment: []string{"KV_CACHE_EVICTION_POLICY=fifo"},
+		Flags:       map[string]string{"unknown": "1", "quota.acme.max_keys": "-1"},
+	}
+
+	// Act
+	c, err := Load(options)
+
+	// Assert
+	var invalid *ValidationError
+	if !errors.As(err, &invalid) || !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("load returned error %v", err)
+	}
+	if len(invalid.Problems) != 5 {
+		t.Errorf("expected 5 problems, got %q", invalid.Problems)
+	}
+	if c.Server.SyncInterval != 10*time.Second {
+		t.Errorf("a value that cannot be parsed should keep its default, got %v", c.Server.SyncInterval)
+	}
+}
+
+// Test that secrets are redacted when shown but still in effect
+func TestSecrets(t *testing.T) {
+	// Arrange
+	os.Setenv("KV_WEBHOOKS_URLS", "http://localhost:9000/hook?token=hunter2")
+	defer os.Unsetenv("KV_WEBHOOKS_URLS")
+
+	// Act
+	manager := NewConfigurationManager(map[string]string{"driver": "none"})
+	c := manager.Settings()
+
+	// Assert
+	if len(c.Webhooks.URLs) != 1 || c.Effective()["webhooks.urls"] != "http://localhost:9000/hook?token=hunter2" {
+		t.Errorf("the secret should be in effect, got %v", c.Webhooks.URLs)
+	}
+	for _, setting := range c.Settings() {
+		if setting.Name == "webhooks.urls" && (setting.Value != Redacted || !setting.Secret) {
+			t.Errorf("the secret should be redacted, got %+v", setting)
+		}
+	}
+	if _, ok := manager.GetConfig()["webhook_urls"]; ok {
+		t.Errorf("secrets should be left out of the flat configuration")
+	}
+}
+
+// Test that only KV_ variables are read
+func TestEnvironment(t *testing.T) {
+	// Arrange
+	os.Setenv("API_TOKEN", "hunter2")
+	os.Setenv("KV_SERVER_REST_PORT", "8484")
+	defer os.Unsetenv("API_TOKEN")
+	defer os.Unsetenv("KV_SERVER_REST_PORT")
+
+	// Act
+	manager := NewConfigurationManager(map[string]string{"driver": "none"})
+	manager.LoadFromEnvironment()
+
+	// Assert
+	values := manager.GetConfig()
+	if values["rest_port"] != "8484" || values["driver"] != "none" {
+		t.Errorf("unexpected configuration %v", values)
+	}
+	for key, value := range values {
+		if key == "PATH" || value == "hunter2" {
+			t.Errorf("%v should not be in the configuration", key)
+		}
+	}
+}
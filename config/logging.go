@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aawadall/simple-kv/health"
+)
+
+// Logging
+// `log.level` applies to every component unless `log.levels` gives it its own,
+// both change on reload, the format and sampling wait for a restart
+
+// LogOptions - the options of the root logger, writing to standard output
+func (c *Config) LogOptions() health.LogOptions {
+	level, _ := health.ParseLevel(c.Log.Level)
+	levels, _ := componentLevels(c.Log.Levels)
+	return health.LogOptions{
+		Format: c.Log.Format,
+		Level:  level,
+		Levels: levels,
+		Sampling: health.Sampling{
+			First:      c.Log.SampleFirst,
+			Thereafter: c.Log.SampleThereafter,
+		},
+	}
+}
+
+// Helper Functions
+// componentLevels - parses levels given as component=level
+func componentLevels(items []string) (map[string]health.Level, error) {
+	levels := make(map[string]health.Level, len(items))
+	for _, item := range items {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("%q is not component=level", item)
+		}
+		level, err := health.ParseLevel(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		levels[strings.TrimSpace(parts[0])] = level
+	}
+	return levels, nil
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Reloading
+// a reload loads the configuration again from the same layers, on SIGHUP or
+// when the configuration file changes, a configuration that is not valid is
+// rejected as a whole, changed settings that are not reloadable keep their
+// value and wait for a restart, subscribers apply the others
+
+// watchInterval - how often the configuration file is checked for changes
+const watchInterval = time.Second
+
+// Change - the settings changed by a reload
+type Change struct {
+	Old *Config
+	New *Config
+	// Names - dotted names of the settings applied
+	Names []string
+	// Pending - dotted names of the settings waiting for a restart
+	Pending []string
+}
+
+// Changed - checks if a setting, or any setting of a section, was applied
+func (c Change) Changed(name string) bool {
+	for _, changed := range c.Names {
+		if changed == name || strings.HasPrefix(changed, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe - A function that registers a function called after every reload
+// applying settings, subscribers run on the goroutine reloading
+func (c *ConfigurationManager) Subscribe(subscriber func(Change)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribers = append(c.subscribers, subscriber)
+}
+
+// Pending - A function that lists the settings changed since loading that wait
+// for a restart
+func (c *ConfigurationManager) Pending() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string{}, c.pending...)
+}
+
+// Reload - A function that loads the configuration again and applies the
+// reloadable settings that changed, the settings in effect are kept when the
+// configuration is not valid
+func (c *ConfigurationManager) Reload() (Change, error) {
+	c.reloading.Lock()
+	defer c.reloading.Unlock()
+
+	old := c.Settings()
+	next, err := Load(old.options)
+	if err != nil {
+		c.logger.Error("Rejected configuration reload", "error", err)
+		return Change{}, err
+	}
+
+	// settings that are not reloadable keep the value they started with
+	change := Change{Old: old, New: next}
+	for i := range schema {
+		s := &schema[i]
+		if s.reload || format(s.field(old)) == format(s.field(next)) {
+			continue
+		}
+		change.Pending = append(change.Pending, s.name)
+		parse(s, format(s.field(old)), s.field(next))
+		if source, ok := old.sources[s.name]; ok {
+			next.sources[s.name] = source
+		} else {
+			delete(next.sources, s.name)
+		}
+	}
+
+	change.Names = diff(old.Effective(), next.Effective())
+
+	c.mu.Lock()
+	c.settings, c.pending = next, change.Pending
+	subscribers := c.subscribers
+	c.mu.Unlock()
+
+	if len(change.Pending) > 0 {
+		c.logger.Warn("Settings changed that wait for a restart", "settings", strings.Join(change.Pending, ","))
+	}
+	if len(change.Names) == 0 {
+		return change, nil
+	}
+	c.logger.Info("Reloaded configuration", "applying", strings.Join(change.Names, ","))
+	for _, subscriber := range subscribers {
+		subscriber(change)
+	}
+	return change, nil
+}
+
+// Start - A function that reloads the configuration on SIGHUP and when the
+// configuration file changes, until stopped
+func (c *ConfigurationManager) Start() {
+	c.mu.Lock()
+	if c.stop != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.stop, c.done = make(chan struct{}), make(chan struct{})
+	stop, done := c.stop, c.done
+	file := c.settings.options.File
+	c.mu.Unlock()
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go c.watch(file, hangups, stop, done)
+}
+
+// Stop - A function that stops reloading the configuration
+func (c *ConfigurationManager) Stop() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Helper Functions
+// watch - reloads on hangups and changes of the file until stopped
+func (c *ConfigurationManager) watch(file string, hangups chan os.Signal, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	defer signal.Stop(hangups)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := fileVersion(file)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hangups:
+			c.logger.Info("Reloading configuration on SIGHUP")
+			last = fileVersion(file)
+			c.Reload()
+		case <-ticker.C:
+			if file == "" {
+				continue
+			}
+			if current := fileVersion(file); current != last {
+				last = current
+				c.logger.Info("Reloading configuration, file changed", "file", file)
+				c.Reload()
+			}
+		}
+	}
+}
+
+// diff - the names whose value differs, or that only one side has
+func diff(before map[string]string, after map[string]string) []string {
+	names := []string{}
+	for name, value := range after {
+		if previous, ok := before[name]; !ok || previous != value {
+			names = append(names, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fileVersion - the modification time and size of a file, empty when it
+// cannot be read
+func fileVersion(path string) string {
+	if path == "" {
+		return ""
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Test that a reload applies reloadable settings and holds back the others
+func TestReload(t *testing.T) {
+	// Arrange
+	path := writeFile(t, "kv.toml", "[server]\nsync_interval = \"10s\"\n[storage]\ndriver = \"log\"\n")
+	defer os.RemoveAll(filepath.Dir(path))
+	settings, err := Load(Options{File: path, Environment: []string{}})
+	if err != nil {
+		t.Fatalf("load returned error %v", err)
+	}
+	manager := NewConfigurationManagerFrom(settings)
+	changes := []Change{}
+	manager.Subscribe(func(change Change) { changes = append(changes, change) })
+	content := "[server]\nsync_interval = \"2s\"\n[storage]\ndriver = \"sqlite\"\n[rate_limit]\nrequests_per_second = 5\n"
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("could not write %v: %v", path, err)
+	}
+
+	// Act
+	change, err := manager.Reload()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("reload returned error %v", err)
+	}
+	if names := []string{"rate_limit.requests_per_second", "server.sync_interval"}; !reflect.DeepEqual(change.Names, names) {
+		t.Errorf("applied %v instead of %v", change.Names, names)
+	}
+	if !change.Changed("rate_limit") || change.Changed("storage") {
+		t.Errorf("unexpected sections changed in %v", change.Names)
+	}
+	if !reflect.DeepEqual(manager.Pending(), []string{"storage.driver"}) {
+		t.Errorf("the driver should wait for a restart, pending %v", manager.Pending())
+	}
+	current := manager.Settings()
+	if current.Server.SyncInterval != 2*time.Second || current.Storage.Driver != "log" || current.Source("storage.driver") != Source("file "+path) {
+		t.Errorf("unexpected settings after reload %+v %+v", current.Server, current.Storage)
+	}
+	if len(changes) != 1 {
+		t.Errorf("subscribers were called %d times", len(changes))
+	}
+
+	// Act
+	ioutil.WriteFile(path, []byte("[server]\nsync_interval = \"never\"\n"), 0644)
+	_, err = manager.Reload()
+
+	// Assert
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("reloading an invalid configuration returned %v", err)
+	}
+	if manager.Settings() != current || len(changes) != 1 {
+		t.Errorf("a rejected reload should keep the settings in effect")
+	}
+}
@@ -0,0 +1,426 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Typed configuration
+// every setting has a dotted name, `section.setting`, used in files, and the
+// flat name it had before sections, which is still accepted everywhere
+
+// Config - the configuration of a server
+type Config struct {
+	Server     ServerConfig     `json:"server"`
+	Storage    StorageConfig    `json:"storage"`
+	SoftDelete SoftDeleteConfig `json:"soft_delete"`
+	Values     ValuesConfig     `json:"values"`
+	Cache      CacheConfig      `json:"cache"`
+	Webhooks   WebhooksConfig   `json:"webhooks"`
+	Quota      QuotaConfig      `json:"quota"`
+	CDC        CDCConfig        `json:"cdc"`
+	Log        LogConfig        `json:"log"`
+	RateLimit  RateLimitConfig  `json:"rate_limit"`
+
+	// options - the layers loaded, loaded again on reload
+	options Options
+	// sources - where each value came from, by dotted name
+	sources map[string]Source
+	// raw - the value as given, by dotted name, defaults are not kept
+	raw map[string]string
+}
+
+// ServerConfig - the server loop and its listeners
+type ServerConfig struct {
+	SyncInterval time.Duration `json:"sync_interval"`
+	RestPort     int           `json:"rest_port"`
+	GrpcPort     int           `json:"grpc_port"`
+}
+
+// StorageConfig - the persistence driver and its files
+type StorageConfig struct {
+	Driver       string `json:"driver"`
+	DBLocation   string `json:"db_location"`
+	FileLocation string `json:"file_location"`
+}
+
+// SoftDeleteConfig - tombstones written on delete
+type SoftDeleteConfig struct {
+	Enabled     bool          `json:"enabled"`
+	GracePeriod time.Duration `json:"grace_period"`
+}
+
+// ValuesConfig - large values
+type ValuesConfig struct {
+	ChunkSize    int   `json:"chunk_size"`
+	MaxValueSize int64 `json:"max_value_size"`
+}
+
+// CacheConfig - records held in memory
+type CacheConfig struct {
+	MemoryLimit    int64  `json:"memory_limit"`
+	EvictionPolicy string `json:"eviction_policy"`
+}
+
+// WebhooksConfig - local webhooks called after writes
+type WebhooksConfig struct {
+	URLs    []string      `json:"urls"`
+	Timeout time.Duration `json:"timeout"`
+	Retries int           `json:"retries"`
+}
+
+// QuotaConfig - limits of every tenant, and of tenants given their own
+type QuotaConfig struct {
+	Defaults types.TenantQuota            `json:"defaults"`
+	Tenants  map[string]types.TenantQuota `json:"tenants"`
+}
+
+// CDCConfig - change data capture sinks
+type CDCConfig struct {
+	Sinks        []string `json:"sinks"`
+	Dir          string   `json:"dir"`
+	FileMaxBytes int64    `json:"file_max_bytes"`
+	SocketPath   string   `json:"socket_path"`
+	MaxLag       int      `json:"max_lag"`
+}
+
+// LogConfig - server log
+type LogConfig struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+	// Levels - levels of components, as component=level
+	Levels           []string `json:"levels"`
+	SampleFirst      int      `json:"sample_first"`
+	SampleThereafter int      `json:"sample_thereafter"`
+}
+
+// RateLimitConfig - requests allowed per tenant, over both APIs
+type RateLimitConfig struct {
+	RequestsPerSecond int `json:"requests_per_second"`
+	Burst             int `json:"burst"`
+}
+
+// LogLevels - log levels, from the most verbose
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// setting - one entry of the schema
+type setting struct {
+	// name - dotted name, the environment variable is derived from it
+	name string
+	// legacy - flat name used before sections
+	legacy string
+	// aliases - environment variables read besides the derived one
+	aliases []string
+	// value - the default
+	value string
+	// unit - unit of a duration given as a bare number
+	unit time.Duration
+	// reload - a change takes effect on reload, others wait for a restart
+	reload bool
+	// secret - the value is redacted wherever settings are shown or logged
+	secret bool
+	help   string
+	// field - the field the setting is parsed into
+	field func(c *Config) interface{}
+	// check - rejects a parsed value that is out of range
+	check func(c *Config) error
+}
+
+// schema - every setting, in the order they are printed
+var schema = []setting{
+	{
+		name: "server.sync_interval", legacy: "sync_interval", value: "10s", unit: time.Second, reload: true,
+		help:  "time between syncs of the records to the persistence driver",
+		field: func(c *Config) interface{} { return &c.Server.SyncInterval },
+		check: func(c *Config) error { return positive(int64(c.Server.SyncInterval)) },
+	},
+	{
+		name: "server.rest_port", legacy: "rest_port", aliases: []string{"KV_SERVER_PORT"}, value: "8080",
+		help:  "port of the REST API, 0 picks a free port",
+		field: func(c *Config) interface{} { return &c.Server.RestPort },
+		check: func(c *Config) error { return port(c.Server.RestPort) },
+	},
+	{
+		name: "server.grpc_port", legacy: "grpc_port", aliases: []string{"KV_GRPC_PORT"}, value: "9090",
+		help:  "port of the gRPC API, 0 picks a free port",
+		field: func(c *Config) interface{} { return &c.Server.GrpcPort },
+		check: func(c *Config) error { return port(c.Server.GrpcPort) },
+	},
+	{
+		name: "storage.driver", legacy: "driver", value: "flat_file",
+		help:  "persistence driver: sqlite, log, mock, none or flat_file",
+		field: func(c *Config) interface{} { return &c.Storage.Driver },
+		check: func(c *Config) error {
+			return oneOf(c.Storage.Driver, "sqlite", "log", "mock", "none", "flat_file")
+		},
+	},
+	{
+		name: "storage.db_location", legacy: "db_location", value: "kv.sqlite",
+		help:  "database file of the sqlite driver",
+		field: func(c *Config) interface{} { return &c.Storage.DBLocation },
+		check: func(c *Config) error {
+			if c.Storage.Driver == "sqlite" {
+				return required(c.Storage.DBLocation)
+			}
+			return nil
+		},
+	},
+	{
+		name: "storage.file_location", legacy: "file_location", value: "kv.log",
+		help:  "log file of the log driver",
+		field: func(c *Config) interface{} { return &c.Storage.FileLocation },
+		check: func(c *Config) error {
+			if c.Storage.Driver == "log" {
+				return required(c.Storage.FileLocation)
+			}
+			return nil
+		},
+	},
+	{
+		name: "soft_delete.enabled", legacy: "soft_delete", value: "false", reload: true,
+		help:  "delete writes a tombstone version instead of dropping the record",
+		field: func(c *Config) interface{} { return &c.SoftDelete.Enabled },
+	},
+	{
+		name: "soft_delete.grace_period", legacy: "soft_delete_grace_period", value: "24h", unit: time.Second, reload: true,
+		help:  "time a tombstone is kept before it is purged",
+		field: func(c *Config) interface{} { return &c.SoftDelete.GracePeriod },
+		check: func(c *Config) error { return notNegative(int64(c.SoftDelete.GracePeriod)) },
+	},
+	{
+		name: "values.chunk_size", legacy: "chunk_size", value: "1048576",
+		help:  "bytes per chunk of a value streamed in",
+		field: func(c *Config) interface{} { return &c.Values.ChunkSize },
+		check: func(c *Config) error { return positive(int64(c.Values.ChunkSize)) },
+	},
+	{
+		name: "values.max_value_size", legacy: "max_value_size", value: "0",
+		help:  "largest value accepted in bytes, 0 accepts any size",
+		field: func(c *Config) interface{} { return &c.Values.MaxValueSize },
+		check: func(c *Config) error { return notNegative(c.Values.MaxValueSize) },
+	},
+	{
+		name: "cache.memory_limit", legacy: "memory_limit", value: "0",
+		help:  "bytes of records held in memory before cold records are evicted, 0 keeps every record",
+		field: func(c *Config) interface{} { return &c.Cache.MemoryLimit },
+		check: func(c *Config) error { return notNegative(c.Cache.MemoryLimit) },
+	},
+	{
+		name: "cache.eviction_policy", legacy: "eviction_policy", value: types.EvictionPolicyLRU,
+		help:  "which records are cold: lru or lfu",
+		field: func(c *Config) interface{} { return &c.Cache.EvictionPolicy },
+		check: func(c *Config) error {
+			return oneOf(c.Cache.EvictionPolicy, types.EvictionPolicyLRU, types.EvictionPolicyLFU)
+		},
+	},
+	{
+		name: "webhooks.urls", legacy: "webhook_urls", secret: true,
+		help:  "comma separated local endpoints called after every write, secret as they may carry credentials",
+		field: func(c *Config) interface{} { return &c.Webhooks.URLs },
+	},
+	{
+		name: "webhooks.timeout", legacy: "webhook_timeout", value: "2s", unit: time.Millisecond,
+		help:  "time given to a webhook call",
+		field: func(c *Config) interface{} { return &c.Webhooks.Timeout },
+		check: func(c *Config) error { return positive(int64(c.Webhooks.Timeout)) },
+	},
+	{
+		name: "webhooks.retries", legacy: "webhook_retries", value: "2",
+		help:  "attempts after a failed webhook call",
+		field: func(c *Config) interface{} { return &c.Webhooks.Retries },
+		check: func(c *Config) error { return notNegative(int64(c.Webhooks.Retries)) },
+	},
+	{
+		name: "quota.max_keys", legacy: "quota_max_keys", value: "0", reload: true,
+		help:  "keys a tenant may hold, 0 is unlimited",
+		field: func(c *Config) interface{} { return &c.Quota.Defaults.MaxKeys },
+		check: func(c *Config) error { return notNegative(c.Quota.Defaults.MaxKeys) },
+	},
+	{
+		name: "quota.max_value_bytes", legacy: "quota_max_value_bytes", value: "0", reload: true,
+		help:  "bytes of values and metadata a tenant may hold, 0 is unlimited",
+		field: func(c *Config) interface{} { return &c.Quota.Defaults.MaxValueBytes },
+		check: func(c *Config) error { return notNegative(c.Quota.Defaults.MaxValueBytes) },
+	},
+	{
+		name: "quota.max_history_bytes", legacy: "quota_max_history_bytes", value: "0", reload: true,
+		help:  "bytes of older versions a tenant may hold, 0 is unlimited",
+		field: func(c *Config) interface{} { return &c.Quota.Defaults.MaxHistoryBytes },
+		check: func(c *Config) error { return notNegative(c.Quota.Defaults.MaxHistoryBytes) },
+	},
+	{
+		name: "quota.max_value_size", legacy: "quota_max_value_size", value: "0", reload: true,
+		help:  "largest value a tenant may write, 0 is unlimited",
+		field: func(c *Config) interface{} { return &c.Quota.Defaults.MaxValueSize },
+		check: func(c *Config) error { return notNegative(c.Quota.Defaults.MaxValueSize) },
+	},
+	{
+		name: "cdc.sinks", legacy: "cdc_sinks",
+		help:  "comma separated sinks changes are captured to: file, socket",
+		field: func(c *Config) interface{} { return &c.CDC.Sinks },
+		check: func(c *Config) error {
+			for _, sink := range c.CDC.Sinks {
+				if err := oneOf(sink, "file", "socket"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		name: "cdc.dir", legacy: "cdc_dir", value: "cdc_data",
+		help:  "directory of the change spool and the file sink",
+		field: func(c *Config) interface{} { return &c.CDC.Dir },
+	},
+	{
+		name: "cdc.file_max_bytes", legacy: "cdc_file_max_bytes", value: "67108864",
+		help:  "bytes written to a change file before it rolls over",
+		field: func(c *Config) interface{} { return &c.CDC.FileMaxBytes },
+		check: func(c *Config) error { return positive(c.CDC.FileMaxBytes) },
+	},
+	{
+		name: "cdc.socket_path", legacy: "cdc_socket_path",
+		help:  "unix socket of the socket sink, cdc.sock in the CDC directory when empty",
+		field: func(c *Config) interface{} { return &c.CDC.SocketPath },
+	},
+	{
+		name: "cdc.max_lag", legacy: "cdc_max_lag", value: "100000",
+		help:  "changes kept for a sink that is not accepting them, older ones are dropped for it",
+		field: func(c *Config) interface{} { return &c.CDC.MaxLag },
+		check: func(c *Config) error { return positive(int64(c.CDC.MaxLag)) },
+	},
+	{
+		name: "log.level", value: "info", reload: true,
+		help:  "least severe server messages logged: debug, info, warn or error",
+		field: func(c *Config) interface{} { return &c.Log.Level },
+		check: func(c *Config) error { return oneOf(c.Log.Level, LogLevels...) },
+	},
+	{
+		name: "log.format", value: "text",
+		help:  "format of log lines: text or json",
+		field: func(c *Config) interface{} { return &c.Log.Format },
+		check: func(c *Config) error { return oneOf(c.Log.Format, "text", "json") },
+	},
+	{
+		name: "log.levels", reload: true,
+		help:  "levels of components overriding log.level, as component=level, e.g. persistence=warn,rest=debug",
+		field: func(c *Config) interface{} { return &c.Log.Levels },
+		check: func(c *Config) error {
+			_, err := componentLevels(c.Log.Levels)
+			return err
+		},
+	},
+	{
+		name: "log.sample_first", value: "100",
+		help:  "debug messages of a kind logged every second before sampling, 0 logs all of them",
+		field: func(c *Config) interface{} { return &c.Log.SampleFirst },
+		check: func(c *Config) error { return notNegative(int64(c.Log.SampleFirst)) },
+	},
+	{
+		name: "log.sample_thereafter", value: "100",
+		help:  "once sampling, one debug message of a kind in this many is logged, 0 drops the rest",
+		field: func(c *Config) interface{} { return &c.Log.SampleThereafter },
+		check: func(c *Config) error { return notNegative(int64(c.Log.SampleThereafter)) },
+	},
+	{
+		name: "rate_limit.requests_per_second", value: "0", reload: true,
+		help:  "requests a tenant may make per second, 0 is unlimited",
+		field: func(c *Config) interface{} { return &c.RateLimit.RequestsPerSecond },
+		check: func(c *Config) error { return notNegative(int64(c.RateLimit.RequestsPerSecond)) },
+	},
+	{
+		name: "rate_limit.burst", value: "0", reload: true,
+		help:  "requests a tenant may make at once, the requests per second when 0",
+		field: func(c *Config) interface{} { return &c.RateLimit.Burst },
+		check: func(c *Config) error { return notNegative(int64(c.RateLimit.Burst)) },
+	},
+}
+
+// tenantQuotaPrefix - per tenant quotas are named quota.tenants.<tenant>.<limit>,
+// or quota.<tenant>.<limit> as before sections
+const tenantQuotaPrefix = "quota.tenants."
+
+// lookup - the setting of a dotted or flat name, and the tenant of a per
+// tenant quota, names are not case sensitive
+func lookup(name string) (*setting, string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i := range schema {
+		if schema[i].name == name || (schema[i].legacy != "" && schema[i].legacy == name) {
+			return &schema[i], "", true
+		}
+	}
+
+	// per tenant quotas
+	parts := strings.Split(name, ".")
+	if strings.HasPrefix(name, tenantQuotaPrefix) && len(parts) == 4 {
+		parts = []string{"quota", parts[2], parts[3]}
+	}
+	if len(parts) != 3 || parts[0] != "quota" || parts[1] == "" {
+		return nil, "", false
+	}
+	for i := range schema {
+		if schema[i].name == "quota."+parts[2] {
+			return &schema[i], parts[1], true
+		}
+	}
+	return nil, "", false
+}
+
+// canonical - the dotted name a value is kept under
+func canonical(s *setting, tenant string) string {
+	if tenant == "" {
+		return s.name
+	}
+	return tenantQuotaPrefix + tenant + "." + strings.TrimPrefix(s.name, "quota.")
+}
+
+// envName - the environment variable of a dotted name
+func envName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.Replace(name, ".", "_", -1))
+}
+
+// Helper Functions
+// positive - checks a value is above zero
+func positive(value int64) error {
+	if value <= 0 {
+		return fmt.Errorf("must be positive")
+	}
+	return nil
+}
+
+// notNegative - checks a value is zero or more
+func notNegative(value int64) error {
+	if value < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
+
+// port - checks a value is a TCP port
+func port(value int) error {
+	if value < 0 || value > 65535 {
+		return fmt.Errorf("must be a port between 0 and 65535")
+	}
+	return nil
+}
+
+// required - checks a value is given
+func required(value string) error {
+	if value == "" {
+		return fmt.Errorf("is required")
+	}
+	return nil
+}
+
+// oneOf - checks a value is one of the allowed values
+func oneOf(value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not one of %v", value, strings.Join(allowed, ", "))
+}
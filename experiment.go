@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/aawadall/simple-kv/features"
+	"github.com/aawadall/simple-kv/persistence"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// experiment - the experiment command, writes, reads and deletes a record
+// through the sqlite driver directly
+func experiment(args []string) error {
+	flags := flag.NewFlagSet("experiment", flag.ContinueOnError)
+	location := flags.String("location", "local.sqlite", "sqlite file the experiment writes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Define Features Manager, in memory as the experiment runs without a server
+	featureFlagManager := features.NewManager(nil)
+
+	featureFlagManager.Define(features.Flag{Name: "direct_sqlite", Description: "Use the sqlite driver directly", Enabled: true})
+
+	// Experiment: directly use sqlite driver
+	if featureFlagManager.IsEnabled("direct_sqlite") {
+		driver := persistence.NewSQLiteDriver(*location)
+
+		// prepare record
+		record := types.NewKVRecord("test", []byte("test"))
+
+		// inspect record
+		fmt.Println("Inspecting Record: ")
+		fmt.Printf("Record ID: %s\n", record.Id)
+		fmt.Printf("Record Key: %s\n", record.Key)
+
+		value, err := record.Value.Get(-1)
+		fmt.Printf("Record Value: %v\n", value)
+
+		// write a record
+		err = driver.Write(*record)
+
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		// read a record
+		readRecord, err := driver.Read("test")
+
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		fmt.Println(readRecord)
+
+		// delete a record
+		err = driver.Delete("test")
+
+		if err != nil {
+			fmt.Println(err)
+		}
+
+	}
+	return nil
+}
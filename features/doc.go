@@ -0,0 +1,13 @@
+package features
+
+/*
+Package features - runtime feature flags for the application.
+it is responsible for deciding which features are on, without a restart
+
+- flags are stored as documents in the KV under a reserved namespace, so they
+  persist, replicate and can be changed like any other record
+- flags defined in code give the default until a flag of the same name is stored
+- a flag is on for everyone, for a percentage rollout of tenants and subjects,
+  or for listed tenants
+- observers are told about every change, whichever API made it
+*/
@@ -0,0 +1,334 @@
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Aliases
+type Flag = types.FeatureFlag
+
+// Store - the records flags are kept in, the KV server satisfies it
+type Store interface {
+	Get(key string) (interface{}, error)
+	SetDocument(key string, document []byte) error
+	Delete(key string) error
+	Find(partialKey string) ([]string, error)
+	Watch(filter types.WatchFilter) (<-chan types.ChangeEvent, func(), error)
+}
+
+// Change - a flag that was set, or deleted, along with the flag now in effect
+type Change struct {
+	Name string
+	// Flag - the flag in effect after the change, the defined default when a
+	// stored flag was deleted
+	Flag Flag
+	// Deleted - no flag of that name is stored or defined anymore
+	Deleted bool
+}
+
+// Manager - evaluates flags, keeping the stored flags in step with the store
+type Manager struct {
+	store  Store
+	logger *health.Logger
+
+	mu sync.RWMutex
+	// defined - flags defined in code, the default when none is stored
+	defined map[string]Flag
+	// stored - flags read from the store
+	stored    map[string]Flag
+	observers []func(Change)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager - creates a manager over a store, flags only live in memory
+// when the store is nil
+func NewManager(store Store) *Manager {
+	return &Manager{
+		store:   store,
+		logger:  health.Default().Component("features"),
+		defined: make(map[string]Flag),
+		stored:  make(map[string]Flag),
+	}
+}
+
+// SetLogger - sets the logger of the manager, before it is started
+func (m *Manager) SetLogger(logger *health.Logger) {
+	m.logger = logger
+}
+
+// Define - defines a flag in code, used until a flag of the same name is stored
+func (m *Manager) Define(flag Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defined[flag.Name] = copyFlag(flag)
+	return nil
+}
+
+// Observe - registers a function called with every change, observers run on
+// the goroutine applying the change and must not block
+func (m *Manager) Observe(observer func(Change)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observers = append(m.observers, observer)
+}
+
+// Start - reads the stored flags and follows their changes until stopped
+func (m *Manager) Start() error {
+	if m.store == nil {
+		return nil
+	}
+
+	events, cancel, err := m.store.Watch(types.WatchFilter{Prefix: types.FlagNamespace})
+	if err != nil {
+		return err
+	}
+	// read after subscribing, so no change falls in between
+	if err := m.reload(); err != nil {
+		cancel()
+		return err
+	}
+
+	m.mu.Lock()
+	m.stop, m.done = make(chan struct{}), make(chan struct{})
+	stop, done := m.stop, m.done
+	m.mu.Unlock()
+	go m.follow(events, cancel, stop, done)
+	return nil
+}
+
+// Stop - stops following changes
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Get - the flag in effect, the stored one or else the defined one
+func (m *Manager) Get(name string) (Flag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	flag, ok := m.effective(name)
+	if !ok {
+		return Flag{}, fmt.Errorf("%w: %v", types.ErrFlagNotFound, name)
+	}
+	return copyFlag(flag), nil
+}
+
+// List - every flag in effect, by name
+func (m *Manager) List() []Flag {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make(map[string]bool)
+	for name := range m.defined {
+		names[name] = true
+	}
+	for name := range m.stored {
+		names[name] = true
+	}
+
+	flags := make([]Flag, 0, len(names))
+	for name := range names {
+		flag, _ := m.effective(name)
+		flags = append(flags, copyFlag(flag))
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// Set - stores a flag, observers are told once it is written
+func (m *Manager) Set(flag Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+
+	if m.store != nil {
+		document, err := json.Marshal(flag)
+		if err != nil {
+			return err
+		}
+		if err := m.store.SetDocument(types.FlagNamespace+flag.Name, document); err != nil {
+			return err
+		}
+	}
+	m.apply(flag.Name, &flag)
+	return nil
+}
+
+// Delete - deletes a stored flag, a defined flag of the same name takes over
+func (m *Manager) Delete(name string) error {
+	m.mu.RLock()
+	_, ok := m.stored[name]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %v", types.ErrFlagNotFound, name)
+	}
+
+	if m.store != nil {
+		if err := m.store.Delete(types.FlagNamespace + name); err != nil {
+			return err
+		}
+	}
+	m.apply(name, nil)
+	return nil
+}
+
+// IsEnabled - evaluates a flag for the default tenant, unknown flags are off
+func (m *Manager) IsEnabled(name string) bool {
+	return m.IsEnabledFor(name, types.DefaultTenant, "")
+}
+
+// IsEnabledFor - evaluates a flag for a tenant and a subject, unknown flags
+// are off
+func (m *Manager) IsEnabledFor(name string, tenant string, subject string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	flag, ok := m.effective(name)
+	return ok && flag.EnabledFor(tenant, subject)
+}
+
+// Helper Functions
+// effective - the flag in effect, callers hold the lock
+func (m *Manager) effective(name string) (Flag, bool) {
+	if flag, ok := m.stored[name]; ok {
+		return flag, true
+	}
+	flag, ok := m.defined[name]
+	return flag, ok
+}
+
+// follow - applies the changes of stored flags until stopped, a watch dropped
+// for falling behind is renewed and the flags read again
+func (m *Manager) follow(events <-chan types.ChangeEvent, cancel func(), stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	for {
+		select {
+		case <-stop:
+			cancel()
+			return
+		case event, ok := <-events:
+			if ok {
+				m.refresh(strings.TrimPrefix(event.Key, types.FlagNamespace))
+				continue
+			}
+		}
+
+		// dropped by the store
+		var err error
+		events, cancel, err = m.store.Watch(types.WatchFilter{Prefix: types.FlagNamespace})
+		if err != nil {
+			m.logger.Error("Error watching flags, changes are not followed", "error", err)
+			<-stop
+			return
+		}
+		if err := m.reload(); err != nil {
+			m.logger.Error("Error reading flags", "error", err)
+		}
+	}
+}
+
+// reload - reads every stored flag
+func (m *Manager) reload() error {
+	keys, err := m.store.Find(types.FlagNamespace)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]bool)
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, types.FlagNamespace)
+		found[name] = true
+		m.refresh(name)
+	}
+
+	// flags deleted while not followed
+	m.mu.RLock()
+	gone := []string{}
+	for name := range m.stored {
+		if !found[name] {
+			gone = append(gone, name)
+		}
+	}
+	m.mu.RUnlock()
+	for _, name := range gone {
+		m.apply(name, nil)
+	}
+	return nil
+}
+
+// refresh - reads one stored flag, a flag that cannot be read is gone
+func (m *Manager) refresh(name string) {
+	value, err := m.store.Get(types.FlagNamespace + name)
+	if err != nil {
+		m.apply(name, nil)
+		return
+	}
+
+	document, _ := value.([]byte)
+	flag, err := types.ParseFeatureFlag(document)
+	if err == nil && flag.Name != name {
+		err = fmt.Errorf("stored under another name %v", flag.Name)
+	}
+	if err != nil {
+		m.logger.Warn("Ignoring stored flag", "flag", name, "error", err)
+		return
+	}
+	m.apply(name, &flag)
+}
+
+// apply - sets, or removes when nil, a stored flag and tells the observers
+// when the flag in effect changed
+func (m *Manager) apply(name string, flag *Flag) {
+	m.mu.Lock()
+	before, existed := m.effective(name)
+	if flag != nil {
+		m.stored[name] = copyFlag(*flag)
+	} else {
+		delete(m.stored, name)
+	}
+	after, exists := m.effective(name)
+	observers := m.observers
+	m.mu.Unlock()
+
+	if existed == exists && reflect.DeepEqual(before, after) {
+		return
+	}
+	change := Change{Name: name, Flag: copyFlag(after), Deleted: !exists}
+	for _, observer := range observers {
+		observer(change)
+	}
+}
+
+// copyFlag - a copy of a flag sharing nothing with it
+func copyFlag(flag Flag) Flag {
+	if flag.Tenants != nil {
+		tenants := make(map[string]bool, len(flag.Tenants))
+		for tenant, enabled := range flag.Tenants {
+			tenants[tenant] = enabled
+		}
+		flag.Tenants = tenants
+	}
+	return flag
+}
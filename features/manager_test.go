@@ -0,0 +1,67 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Test flag evaluation for tenants and subjects
+func TestIsEnabledFor(t *testing.T) {
+	// Arrange
+	manager := NewManager(nil)
+	manager.Define(Flag{Name: "on", Enabled: true})
+	manager.Define(Flag{Name: "off", Enabled: false, Tenants: map[string]bool{"acme": true}})
+	manager.Define(Flag{Name: "listed", Enabled: true, Rollout: 1, Tenants: map[string]bool{"acme": true, "globex": false}})
+	manager.Define(Flag{Name: "everyone", Enabled: true, Rollout: 100})
+	manager.Define(Flag{Name: "overridden", Enabled: true})
+	manager.Set(Flag{Name: "overridden", Enabled: false})
+
+	cases := []struct {
+		flag    string
+		tenant  string
+		enabled bool
+	}{
+		{"on", "acme", true},
+		{"off", "acme", false},
+		{"listed", "acme", true},
+		{"listed", "globex", false},
+		{"everyone", "globex", true},
+		{"overridden", "acme", false},
+		{"unknown", "acme", false},
+	}
+
+	for _, c := range cases {
+		// Act
+		enabled := manager.IsEnabledFor(c.flag, c.tenant, "user-1")
+
+		// Assert
+		if enabled != c.enabled {
+			t.Errorf("%v for %v is %v instead of %v", c.flag, c.tenant, enabled, c.enabled)
+		}
+	}
+}
+
+// Test that a rollout is stable per subject and reaches some subjects only
+func TestRollout(t *testing.T) {
+	// Arrange
+	manager := NewManager(nil)
+	manager.Define(Flag{Name: "half", Enabled: true, Rollout: 50})
+
+	// Act
+	on := 0
+	for i := 0; i < 1000; i++ {
+		subject := fmt.Sprintf("user-%d", i)
+		enabled := manager.IsEnabledFor("half", "acme", subject)
+		if enabled != manager.IsEnabledFor("half", "acme", subject) {
+			t.Fatalf("%v landed on both sides of the rollout", subject)
+		}
+		if enabled {
+			on++
+		}
+	}
+
+	// Assert
+	if on < 400 || on > 600 {
+		t.Errorf("rollout of 50%% reached %d of 1000 subjects", on)
+	}
+}
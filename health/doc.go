@@ -2,7 +2,7 @@ package health
 
 /*
 Package health - This package is responsible for handling:
- - health checks
+ - health checks: liveness and readiness probes
  - cluster health checks
  - logging
  - metrics
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// Probes
+// liveness answers as long as the process serves requests, readiness runs every
+// check registered and fails when any of them does, so a load balancer only
+// sends requests to a server whose data is loaded and storage reachable
+
+// Status values of a report
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Check - a readiness check, nil when ready
+type Check func() error
+
+// Report - the outcome of the readiness checks, by check name
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Checker - named readiness checks
+type Checker struct {
+	mu     sync.RWMutex
+	names  []string
+	checks map[string]Check
+}
+
+// NewChecker creates a checker without checks, always ready
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]Check)}
+}
+
+// Add registers a check, replacing one of the same name
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.checks[name] = check
+}
+
+// Ready runs every check, in the order they were added
+func (c *Checker) Ready() Report {
+	c.mu.RLock()
+	names := append([]string{}, c.names...)
+	checks := make([]Check, len(names))
+	for i, name := range names {
+		checks[i] = c.checks[name]
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]string, len(names))}
+	for i, name := range names {
+		if err := checks[i](); err != nil {
+			report.Status = StatusUnavailable
+			report.Checks[name] = err.Error()
+			continue
+		}
+		report.Checks[name] = StatusOK
+	}
+	return report
+}
+
+// Live answers liveness probes, /healthz
+func Live(w http.ResponseWriter, r *http.Request) {
+	write(w, http.StatusOK, Report{Status: StatusOK})
+}
+
+// ServeHTTP answers readiness probes, /readyz, with 503 unless every check passes
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Ready()
+	status := http.StatusOK
+	if report.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	write(w, status, report)
+}
+
+// Helper Functions
+// write - writes a report as JSON
+func write(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
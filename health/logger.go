@@ -0,0 +1,340 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Logging
+// a record is a leveled message with key value fields, written as logfmt text
+// or as a JSON object per line, every component logs through a child of the
+// root logger carrying its name, the level applies to every component unless
+// the component has its own, levels change at runtime, debug messages are
+// sampled so a message repeated per record or per request cannot flood the log
+
+// Level - severity of a record, from the most verbose
+type Level int32
+
+// levels
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// sampleWindow - how long the debug messages of a sample are counted
+const sampleWindow = time.Second
+
+// String - the name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int32(l))
+}
+
+// ParseLevel - the level of a name: debug, info, warn or error
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// Sampling - of debug messages, the first First records of a message in every
+// second are written, then one in Thereafter, every record when First is 0
+type Sampling struct {
+	First      int
+	Thereafter int
+}
+
+// LogOptions - how a root logger writes
+type LogOptions struct {
+	// Output - where records go, standard output when nil
+	Output io.Writer
+	// Format - FormatText or FormatJSON
+	Format string
+	// Level - the least severe level written by components without their own
+	Level Level
+	// Levels - levels by component
+	Levels   map[string]Level
+	Sampling Sampling
+}
+
+// Logger - writes the records of a component, with the fields given to With
+type Logger struct {
+	sink      *logSink
+	component string
+	fields    []interface{}
+}
+
+// logSink - the output and levels shared by a root logger and its children
+type logSink struct {
+	mu     sync.Mutex
+	output io.Writer
+	format string
+
+	level  int32
+	levels atomic.Value
+
+	sampling Sampling
+	window   time.Time
+	counts   map[string]int
+	now      func() time.Time
+}
+
+// defaultLogger - the logger of components not given one
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(NewLogger(LogOptions{Level: LevelInfo}))
+}
+
+// NewLogger creates a root logger
+func NewLogger(options LogOptions) *Logger {
+	sink := &logSink{
+		output:   options.Output,
+		format:   options.Format,
+		sampling: options.Sampling,
+		counts:   make(map[string]int),
+		now:      time.Now,
+	}
+	if sink.format != FormatJSON {
+		sink.format = FormatText
+	}
+	logger := &Logger{sink: sink}
+	logger.SetLevels(options.Level, options.Levels)
+	return logger
+}
+
+// Default returns the logger of components not given one
+func Default() *Logger {
+	return defaultLogger.Load().(*Logger)
+}
+
+// SetDefault replaces the logger of components created from now on
+func SetDefault(logger *Logger) {
+	defaultLogger.Store(logger)
+}
+
+// Component returns a child logger of a component, keeping the fields
+func (l *Logger) Component(name string) *Logger {
+	return &Logger{sink: l.sink, component: name, fields: l.fields}
+}
+
+// With returns a child logger adding key value pairs to every record
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals))
+	fields = append(append(fields, l.fields...), keyvals...)
+	return &Logger{sink: l.sink, component: l.component, fields: fields}
+}
+
+// SetLevels changes the levels of the root logger and every child
+func (l *Logger) SetLevels(level Level, levels map[string]Level) {
+	copied := make(map[string]Level, len(levels))
+	for component, componentLevel := range levels {
+		copied[component] = componentLevel
+	}
+	atomic.StoreInt32(&l.sink.level, int32(level))
+	l.sink.levels.Store(copied)
+}
+
+// Enabled checks if records of a level are written for the component
+func (l *Logger) Enabled(level Level) bool {
+	least := Level(atomic.LoadInt32(&l.sink.level))
+	if componentLevel, ok := l.sink.levels.Load().(map[string]Level)[l.component]; ok {
+		least = componentLevel
+	}
+	return level >= least
+}
+
+// Debug writes a debug record, sampled
+func (l *Logger) Debug(msg string, keyvals ...interface{}) { l.Log(LevelDebug, msg, keyvals...) }
+
+// Info writes an info record
+func (l *Logger) Info(msg string, keyvals ...interface{}) { l.Log(LevelInfo, msg, keyvals...) }
+
+// Warn writes a warning record
+func (l *Logger) Warn(msg string, keyvals ...interface{}) { l.Log(LevelWarn, msg, keyvals...) }
+
+// Error writes an error record
+func (l *Logger) Error(msg string, keyvals ...interface{}) { l.Log(LevelError, msg, keyvals...) }
+
+// Log writes a record of a message and key value pairs, after the fields of
+// the logger, unless the level is not enabled or the record is sampled out
+func (l *Logger) Log(level Level, msg string, keyvals ...interface{}) {
+	if l == nil || !l.Enabled(level) {
+		return
+	}
+
+	fields := l.fields
+	if len(keyvals) > 0 {
+		fields = append(append(make([]interface{}, 0, len(l.fields)+len(keyvals)), l.fields...), keyvals...)
+	}
+
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	now := l.sink.now()
+	if level == LevelDebug && !l.sink.sample(now, l.component+"\xff"+msg) {
+		return
+	}
+	output := l.sink.output
+	if output == nil {
+		output = os.Stdout
+	}
+	output.Write(l.sink.encode(now, level, l.component, msg, fields))
+}
+
+// WithLogger returns a context carrying a logger, such as one with the fields
+// of a request
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// LoggerFrom returns the logger of a context, the fallback when it has none
+func LoggerFrom(ctx context.Context, fallback *Logger) *Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// Helper Functions
+// loggerContextKey - context key of a logger
+type loggerContextKey struct{}
+
+// sample - checks if a debug message is written, callers hold the lock
+func (s *logSink) sample(now time.Time, key string) bool {
+	if s.sampling.First <= 0 {
+		return true
+	}
+	if now.Sub(s.window) >= sampleWindow {
+		s.window = now
+		s.counts = make(map[string]int)
+	}
+
+	s.counts[key]++
+	count := s.counts[key]
+	if count <= s.sampling.First {
+		return true
+	}
+	return s.sampling.Thereafter > 0 && (count-s.sampling.First)%s.sampling.Thereafter == 0
+}
+
+// encode - a record as a line of the format
+func (s *logSink) encode(now time.Time, level Level, component string, msg string, fields []interface{}) []byte {
+	keys := []string{"time", "level"}
+	values := []interface{}{now.UTC().Format("2006-01-02T15:04:05.000Z07:00"), level.String()}
+	if component != "" {
+		keys, values = append(keys, "component"), append(values, component)
+	}
+	keys, values = append(keys, "msg"), append(values, msg)
+	for i := 0; i < len(fields); i += 2 {
+		key := fmt.Sprint(fields[i])
+		var value interface{} = "!MISSING"
+		if i+1 < len(fields) {
+			value = fields[i+1]
+		}
+		keys, values = append(keys, key), append(values, value)
+	}
+
+	line := &bytes.Buffer{}
+	if s.format == FormatJSON {
+		line.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			encoded, _ := json.Marshal(key)
+			line.Write(encoded)
+			line.WriteByte(':')
+			line.Write(jsonValue(values[i]))
+		}
+		line.WriteString("}\n")
+		return line.Bytes()
+	}
+
+	for i, key := range keys {
+		if i > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(key)
+		line.WriteByte('=')
+		line.WriteString(textValue(values[i]))
+	}
+	line.WriteByte('\n')
+	return line.Bytes()
+}
+
+// jsonValue - a field value as JSON, errors and stringers as their text
+func jsonValue(value interface{}) []byte {
+	switch typed := value.(type) {
+	case error:
+		value = typed.Error()
+	case fmt.Stringer:
+		value = typed.String()
+	case time.Duration:
+		value = typed.String()
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprint(value))
+	}
+	return encoded
+}
+
+// textValue - a field value as logfmt, quoted when it has spaces, quotes or
+// equal signs
+func textValue(value interface{}) string {
+	var text string
+	switch typed := value.(type) {
+	case string:
+		text = typed
+	case error:
+		text = typed.Error()
+	case map[string]string:
+		pairs := make([]string, 0, len(typed))
+		for key, item := range typed {
+			pairs = append(pairs, key+":"+item)
+		}
+		sort.Strings(pairs)
+		text = strings.Join(pairs, ",")
+	default:
+		text = fmt.Sprint(value)
+	}
+	if text == "" || strings.ContainsAny(text, " =\"\t\n\r") {
+		return strconv.Quote(text)
+	}
+	return text
+}
@@ -0,0 +1,67 @@
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test that records are leveled by component, formatted and sampled
+func TestLogger(t *testing.T) {
+	// Arrange
+	out := &bytes.Buffer{}
+	root := NewLogger(LogOptions{
+		Output:   out,
+		Level:    LevelInfo,
+		Levels:   map[string]Level{"rest": LevelDebug},
+		Sampling: Sampling{First: 2, Thereafter: 3},
+	})
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	root.sink.now = func() time.Time { return now }
+	rest := root.Component("rest").With("request_id", "abc")
+
+	// Act
+	root.Component("persistence").Debug("Writing record", "key", "k")
+	rest.Info("Request served", "route", "/kv/{key}", "status", 200)
+	root.Component("persistence").Error("Error syncing records", "error", errors.New("disk full"))
+	for i := 0; i < 10; i++ {
+		rest.Debug("No key provided")
+	}
+	root.SetLevels(LevelError, nil)
+	rest.Info("Request served")
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	expected := []string{
+		`time=2024-05-01T12:00:00.000Z level=INFO component=rest msg="Request served" request_id=abc route=/kv/{key} status=200`,
+		`time=2024-05-01T12:00:00.000Z level=ERROR component=persistence msg="Error syncing records" error="disk full"`,
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf("expected %q, got %q", line, lines[i])
+		}
+	}
+	// 2 first, then the 5th and 8th
+	if sampled := len(lines) - len(expected); sampled != 4 {
+		t.Errorf("expected 4 sampled debug records, got %d in\n%v", sampled, out.String())
+	}
+
+	// Arrange
+	out.Reset()
+	root = NewLogger(LogOptions{Output: out, Format: FormatJSON, Level: LevelDebug})
+
+	// Act
+	root.Component("grpc").Warn("Call served", "code", "OK", "duration", 1500*time.Millisecond)
+
+	// Assert
+	record := map[string]interface{}{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("invalid JSON record %q: %v", out.String(), err)
+	}
+	if record["level"] != "WARN" || record["component"] != "grpc" || record["msg"] != "Call served" || record["duration"] != "1.5s" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
@@ -0,0 +1,272 @@
+package health
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metrics
+// counters, gauges and histograms with labels, written in the Prometheus text
+// exposition format, collectors run before every scrape to set the values kept
+// elsewhere, such as sizes and queue depths
+
+// DefaultBuckets - upper bounds of latency histograms, in seconds
+var DefaultBuckets = []float64{.0005, .001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// kinds of metrics, as written in TYPE lines
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// Registry - metrics by name, in the order they were registered
+type Registry struct {
+	mu         sync.Mutex
+	metrics    []*metric
+	byName     map[string]*metric
+	collectors []func()
+}
+
+// metric - a family of series sharing a name and label names
+type metric struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// series - the value of one combination of label values
+type series struct {
+	values []string
+	value  float64
+	// counts - observations per bucket, the last one past every bound
+	counts []uint64
+	count  uint64
+}
+
+// Counter - a value that only goes up
+type Counter struct{ m *metric }
+
+// Gauge - a value that goes up and down
+type Gauge struct{ m *metric }
+
+// Histogram - observations counted in buckets
+type Histogram struct{ m *metric }
+
+// NewRegistry creates a registry without metrics
+func NewRegistry() *Registry {
+	return &Registry{byName: make(map[string]*metric)}
+}
+
+// Counter registers a counter, or returns the one of the same name
+func (r *Registry) Counter(name string, help string, labels ...string) Counter {
+	return Counter{r.register(name, help, kindCounter, nil, labels)}
+}
+
+// Gauge registers a gauge, or returns the one of the same name
+func (r *Registry) Gauge(name string, help string, labels ...string) Gauge {
+	return Gauge{r.register(name, help, kindGauge, nil, labels)}
+}
+
+// Histogram registers a histogram with bucket upper bounds in increasing
+// order, or returns the one of the same name
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) Histogram {
+	return Histogram{r.register(name, help, kindHistogram, buckets, labels)}
+}
+
+// OnCollect registers a function run before every scrape
+func (r *Registry) OnCollect(collect func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, collect)
+}
+
+// Inc adds one to the series of the label values
+func (c Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add adds a non-negative delta to the series of the label values
+func (c Counter) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		return
+	}
+	c.m.update(labels, func(s *series) { s.value += delta })
+}
+
+// Set sets a counter kept elsewhere, such as by the runtime, from a collector
+func (c Counter) Set(value float64, labels ...string) {
+	c.m.update(labels, func(s *series) { s.value = value })
+}
+
+// Set sets the series of the label values
+func (g Gauge) Set(value float64, labels ...string) {
+	g.m.update(labels, func(s *series) { s.value = value })
+}
+
+// Reset removes every series, so values no longer set are not written
+func (g Gauge) Reset() {
+	g.m.mu.Lock()
+	defer g.m.mu.Unlock()
+	g.m.series = make(map[string]*series)
+}
+
+// Observe counts a value in the series of the label values
+func (h Histogram) Observe(value float64, labels ...string) {
+	bucket := sort.SearchFloat64s(h.m.buckets, value)
+	h.m.update(labels, func(s *series) {
+		s.counts[bucket]++
+		s.count++
+		s.value += value
+	})
+}
+
+// Write writes every metric in the Prometheus text format
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := append([]*metric{}, r.metrics...)
+	collectors := append([]func(){}, r.collectors...)
+	r.mu.Unlock()
+
+	for _, collect := range collectors {
+		collect()
+	}
+
+	out := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(out)
+	}
+	return out.Flush()
+}
+
+// ServeHTTP answers scrapes, /metrics
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	r.Write(w)
+}
+
+// Helper Functions
+// register - adds a metric, names registered again return the first one
+func (r *Registry) register(name string, help string, kind string, buckets []float64, labels []string) *metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok := r.byName[name]; ok {
+		if m.kind != kind || len(m.labels) != len(labels) {
+			panic(fmt.Sprintf("metric %v registered again as a different metric", name))
+		}
+		return m
+	}
+
+	m := &metric{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.metrics = append(r.metrics, m)
+	r.byName[name] = m
+	return m
+}
+
+// update - changes the series of the label values, created on first use
+func (m *metric) update(values []string, change func(s *series)) {
+	if len(values) != len(m.labels) {
+		panic(fmt.Sprintf("metric %v takes %d labels, got %d", m.name, len(m.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.series[key]
+	if !ok {
+		s = &series{values: append([]string{}, values...)}
+		if m.kind == kindHistogram {
+			s.counts = make([]uint64, len(m.buckets)+1)
+		}
+		m.series[key] = s
+	}
+	change(s)
+}
+
+// write - writes the HELP and TYPE lines, then every series in label order
+func (m *metric) write(out *bufio.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintf(out, "# HELP %v %v\n", m.name, escapeHelp(m.help))
+	fmt.Fprintf(out, "# TYPE %v %v\n", m.name, m.kind)
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := m.series[key]
+		if m.kind != kindHistogram {
+			fmt.Fprintf(out, "%v%v %v\n", m.name, labelPairs(m.labels, s.values, ""), formatValue(s.value))
+			continue
+		}
+
+		cumulative := uint64(0)
+		for i, bound := range m.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(out, "%v_bucket%v %v\n", m.name, labelPairs(m.labels, s.values, formatValue(bound)), cumulative)
+		}
+		fmt.Fprintf(out, "%v_bucket%v %v\n", m.name, labelPairs(m.labels, s.values, "+Inf"), s.count)
+		fmt.Fprintf(out, "%v_sum%v %v\n", m.name, labelPairs(m.labels, s.values, ""), formatValue(s.value))
+		fmt.Fprintf(out, "%v_count%v %v\n", m.name, labelPairs(m.labels, s.values, ""), s.count)
+	}
+}
+
+// labelPairs - the labels of a series in braces, with the bucket bound as le
+// unless empty, nothing without labels
+func labelPairs(names []string, values []string, le string) string {
+	pairs := make([]string, 0, len(names)+1)
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%v=\"%v\"", name, escapeLabel(values[i])))
+	}
+	if le != "" {
+		pairs = append(pairs, fmt.Sprintf("le=\"%v\"", le))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatValue - a sample value as Prometheus reads it
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string   { return helpEscaper.Replace(help) }
+func escapeLabel(value string) string { return labelEscaper.Replace(value) }
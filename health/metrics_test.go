@@ -0,0 +1,51 @@
+package health
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// Test that metrics are written in the Prometheus text format
+func TestRegistryWrite(t *testing.T) {
+	// Arrange
+	registry := NewRegistry()
+	requests := registry.Counter("requests_total", "Requests.", "route", "code")
+	latency := registry.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	depth := registry.Gauge("depth", "Items\nwaiting.")
+	registry.OnCollect(func() { depth.Set(3) })
+
+	// Act
+	requests.Inc("/kv/{key}", "200")
+	requests.Add(2, "/kv/{key}", "200")
+	requests.Inc(`say "hi"`, "404")
+	latency.Observe(0.05, "/kv/{key}")
+	latency.Observe(0.1, "/kv/{key}")
+	latency.Observe(5, "/kv/{key}")
+	out := &bytes.Buffer{}
+	err := registry.Write(out)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	expected := []string{
+		"# HELP requests_total Requests.",
+		"# TYPE requests_total counter",
+		`requests_total{route="/kv/{key}",code="200"} 3`,
+		`requests_total{route="say \"hi\"",code="404"} 1`,
+		"# TYPE latency_seconds histogram",
+		`latency_seconds_bucket{route="/kv/{key}",le="0.1"} 2`,
+		`latency_seconds_bucket{route="/kv/{key}",le="1"} 2`,
+		`latency_seconds_bucket{route="/kv/{key}",le="+Inf"} 3`,
+		`latency_seconds_sum{route="/kv/{key}"} 5.15`,
+		`latency_seconds_count{route="/kv/{key}"} 3`,
+		`# HELP depth Items\nwaiting.`,
+		"depth 3",
+	}
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line+"\n") {
+			t.Errorf("missing %q in\n%v", line, out.String())
+		}
+	}
+}
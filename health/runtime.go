@@ -0,0 +1,43 @@
+package health
+
+import (
+	"runtime"
+	"time"
+)
+
+// Runtime metrics
+// Go runtime statistics read on every scrape, named as the Prometheus Go
+// client names them so existing dashboards apply
+
+// RegisterRuntime registers the Go runtime metrics
+func RegisterRuntime(r *Registry) {
+	info := r.Gauge("go_info", "Information about the Go environment.", "version")
+	goroutines := r.Gauge("go_goroutines", "Number of goroutines that currently exist.")
+	threads := r.Gauge("go_threads", "Number of OS threads created.")
+	alloc := r.Gauge("go_memstats_alloc_bytes", "Number of bytes allocated and still in use.")
+	sys := r.Gauge("go_memstats_sys_bytes", "Number of bytes obtained from system.")
+	heapInuse := r.Gauge("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.")
+	heapObjects := r.Gauge("go_memstats_heap_objects", "Number of allocated objects.")
+	gcCycles := r.Counter("go_gc_cycles_total", "Number of completed GC cycles.")
+	gcPause := r.Counter("go_gc_pause_seconds_total", "Time spent in GC stop-the-world pauses.")
+	lastGC := r.Gauge("go_memstats_last_gc_time_seconds", "Number of seconds since 1970 of last garbage collection.")
+	started := r.Gauge("process_start_time_seconds", "Start time of the process since unix epoch in seconds.")
+
+	info.Set(1, runtime.Version())
+	started.Set(float64(time.Now().Unix()))
+	r.OnCollect(func() {
+		memory := runtime.MemStats{}
+		runtime.ReadMemStats(&memory)
+		count, _ := runtime.ThreadCreateProfile(nil)
+
+		goroutines.Set(float64(runtime.NumGoroutine()))
+		threads.Set(float64(count))
+		alloc.Set(float64(memory.Alloc))
+		sys.Set(float64(memory.Sys))
+		heapInuse.Set(float64(memory.HeapInuse))
+		heapObjects.Set(float64(memory.HeapObjects))
+		gcCycles.Set(float64(memory.NumGC))
+		gcPause.Set(time.Duration(memory.PauseTotalNs).Seconds())
+		lastGC.Set(float64(memory.LastGC) / 1e9)
+	})
+}
@@ -0,0 +1,450 @@
+package kvserver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Bulk operations
+// every bulk operation can run dry, reporting the keys it would touch,
+// copies and renames run the hooks over every write, lock every key involved
+// and check all destinations and the quota before changing anything, so every
+// key is copied or none is, a rename always keeps history and never grows
+// usage, keys lose their lease when copied or renamed
+
+// bulkPair - a source key and its destination
+type bulkPair struct {
+	source      string
+	destination string
+}
+
+// DeletePrefix - A function that deletes every key under a prefix
+func (s *KVServer) DeletePrefix(prefix string, dryRun bool) (result types.BulkResult, err error) {
+	defer s.countOperation("delete_prefix", &err)
+	// check the prefix is valid
+	if err := checkKey("prefix", prefix); err != nil {
+		return types.BulkResult{}, err
+	}
+
+	keys, err := s.Find(prefix)
+	if err != nil {
+		return types.BulkResult{}, err
+	}
+	return s.deleteKeys(keys, dryRun)
+}
+
+// DeleteByMetadata - A function that deletes every key matching a metadata query
+func (s *KVServer) DeleteByMetadata(query string, dryRun bool) (result types.BulkResult, err error) {
+	defer s.countOperation("delete_by_metadata", &err)
+	keys, err := s.FindByMetadata(query)
+	if err != nil {
+		return types.BulkResult{}, err
+	}
+	return s.deleteKeys(keys, dryRun)
+}
+
+// deleteKeys - deletes keys one at a time, reporting the ones deleted
+func (s *KVServer) deleteKeys(keys []string, dryRun bool) (types.BulkResult, error) {
+	sort.Strings(keys)
+	result := types.BulkResult{Keys: []string{}, DryRun: dryRun}
+	if dryRun {
+		result.Keys = append(result.Keys, keys...)
+		result.Count = len(keys)
+		return result, nil
+	}
+
+	var failed []string
+	var firstErr error
+	for _, key := range keys {
+		if err := s.Delete(key); err != nil {
+			failed = append(failed, key)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		result.Keys = append(result.Keys, key)
+	}
+	result.Count = len(result.Keys)
+
+	if firstErr != nil {
+		return result, fmt.Errorf("failed to delete %d keys, %v: %w", len(failed), failed[0], firstErr)
+	}
+	return result, nil
+}
+
+// Copy - A function that copies a key, or every key under a prefix, to another
+// key or prefix, every key is copied or none is
+func (s *KVServer) Copy(source string, destination string, options types.CopyOptions) (result types.BulkResult, err error) {
+	defer s.countOperation("copy", &err)
+	return s.movePairs(source, destination, options, false)
+}
+
+// Rename - A function that moves a key, or every key under a prefix, to another
+// key or prefix with its history, every key is moved or none is
+func (s *KVServer) Rename(source string, destination string, options types.CopyOptions) (result types.BulkResult, err error) {
+	defer s.countOperation("rename", &err)
+	// a prefix moved inside itself would move some keys twice
+	if options.Prefix && (strings.HasPrefix(destination, source) || strings.HasPrefix(source, destination)) {
+		return types.BulkResult{}, fmt.Errorf("prefixes %v and %v overlap", source, destination)
+	}
+
+	options.History = true
+	return s.movePairs(source, destination, options, true)
+}
+
+// movePairs - copies, or moves, the pairs of a copy or rename: the hooks see
+// every write first, then under the locks of every key each pair is checked and
+// prepared, chunks are copied and removed again when one fails, and only then
+// are the records committed
+func (s *KVServer) movePairs(source string, destination string, options types.CopyOptions, moving bool) (types.BulkResult, error) {
+	pairs, err := s.bulkPairs(source, destination, options.Prefix)
+	if err != nil {
+		return types.BulkResult{}, err
+	}
+	if !options.DryRun {
+		if err := s.hookPairs(pairs, moving); err != nil {
+			return types.BulkResult{}, err
+		}
+	}
+
+	unlock := s.lockPairs(pairs)
+	defer unlock()
+
+	commits, err := s.preparePairs(pairs, options, moving)
+	if err != nil {
+		return types.BulkResult{}, err
+	}
+
+	result := pairsResult(pairs, options.DryRun)
+	if options.DryRun {
+		return result, nil
+	}
+	if err := s.copyPairChunks(commits); err != nil {
+		return types.BulkResult{}, err
+	}
+	s.commitPairs(commits, moving)
+	return result, nil
+}
+
+// bulkPairs - the keys a copy or rename moves and where they go
+func (s *KVServer) bulkPairs(source string, destination string, prefix bool) ([]bulkPair, error) {
+	if source == "" || destination == "" {
+		return nil, fmt.Errorf("source and destination cannot be empty")
+	}
+	if err := checkWritable(source); err != nil {
+		return nil, err
+	}
+	if err := checkWritable(destination); err != nil {
+		return nil, err
+	}
+	if source == destination {
+		return nil, fmt.Errorf("source and destination are the same")
+	}
+
+	if !prefix {
+		if record, ok := s.Records.Get(s.storageKey(source)); !ok || record.IsDeleted() {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, source)
+		}
+		return []bulkPair{{source: source, destination: destination}}, nil
+	}
+
+	keys, err := s.Find(source)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	pairs := make([]bulkPair, 0, len(keys))
+	for _, key := range keys {
+		pair := bulkPair{source: key, destination: destination + strings.TrimPrefix(key, source)}
+		if err := checkWritable(pair.source); err != nil {
+			return nil, err
+		}
+		if err := checkWritable(pair.destination); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	return pairs, nil
+}
+
+// lockPairs - locks every key of the pairs
+func (s *KVServer) lockPairs(pairs []bulkPair) func() {
+	keys := make([]string, 0, 2*len(pairs))
+	for _, pair := range pairs {
+		keys = append(keys, s.storageKey(pair.source), s.storageKey(pair.destination))
+	}
+	return s.locks.lockAll(keys)
+}
+
+// pairCommit - a pair ready to commit, the record copied to the destination
+// and the record it replaces
+type pairCommit struct {
+	pair     bulkPair
+	source   KVRecord
+	copied   KVRecord
+	existing KVRecord
+	exists   bool
+	// history - the copied record replaces the history of the destination
+	history bool
+	// blobs - chunk blobs copied to the destination
+	blobs []string
+}
+
+// hookPairs - runs the pre-write hooks over the writes of every pair, a set of
+// the destination and, when moving, a delete of the source, the value of a
+// copy is not changed by hooks
+func (s *KVServer) hookPairs(pairs []bulkPair, moving bool) error {
+	for _, pair := range pairs {
+		record, ok := s.Records.Get(s.storageKey(pair.source))
+		if !ok || record.IsDeleted() {
+			return fmt.Errorf("%w: %v", types.ErrKeyNotFound, pair.source)
+		}
+		value, err := record.GetValue(-1)
+		if err != nil {
+			return err
+		}
+		valueType, _ := record.Metadata.Get(types.MetadataValueType)
+
+		if err := s.checkWrite(pair.destination, value, valueType); err != nil {
+			return err
+		}
+		if moving {
+			if err := s.beforeWrite(&types.WriteOperation{Key: pair.source, Operation: types.OperationDelete}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// preparePairs - checks every source still exists, every destination is free
+// and accepts the value, builds the records to commit and checks them against
+// the quota all at once, moving skips the quota check as the sources are
+// deleted, callers hold the locks of the pairs
+func (s *KVServer) preparePairs(pairs []bulkPair, options types.CopyOptions, moving bool) ([]pairCommit, error) {
+	commits := make([]pairCommit, 0, len(pairs))
+	before, after := types.TenantUsage{}, types.TenantUsage{}
+	largest := int64(0)
+	for _, pair := range pairs {
+		record, ok := s.Records.Get(s.storageKey(pair.source))
+		if !ok || record.IsDeleted() {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, pair.source)
+		}
+
+		// a history copy replaces even a soft deleted destination
+		existing, exists := s.Records.Get(s.storageKey(pair.destination))
+		if exists && !options.Overwrite {
+			if !existing.IsDeleted() || options.History {
+				return nil, fmt.Errorf("%w: %v", types.ErrKeyExists, pair.destination)
+			}
+		}
+
+		value, err := record.GetValue(-1)
+		if err != nil {
+			return nil, err
+		}
+		valueType, _ := record.Metadata.Get(types.MetadataValueType)
+		if err := s.validateValue(pair.destination, value, valueType); err != nil {
+			return nil, err
+		}
+
+		commit := pairCommit{pair: pair, source: record, existing: existing, exists: exists, history: options.History || !exists}
+		if options.History {
+			commit.copied = record.Clone(s.tenant, pair.destination)
+		} else if !exists {
+			commit.copied = *types.NewKVRecord(pair.destination, value)
+			commit.copied.Tenant = s.tenant
+			copyMetadata(record, commit.copied)
+		} else {
+			// the existing record is updated on a copy, so nothing changes before the commit
+			commit.copied = existing.Clone(s.tenant, pair.destination)
+			commit.copied.Id = existing.Id
+			commit.copied.UpdateRecord(pair.destination, value)
+			copyMetadata(record, commit.copied)
+		}
+		if _, ok := commit.copied.Metadata.Get(types.MetadataLease); ok {
+			commit.copied.Metadata.Delete(types.MetadataLease)
+		}
+		commits = append(commits, commit)
+
+		if exists {
+			before = before.Add(footprint(existing))
+		}
+		after = after.Add(footprint(commit.copied))
+		if size := valueSize(value, valueType); size > largest {
+			largest = size
+		}
+	}
+
+	if !moving {
+		if err := s.checkQuota(before, after, int(largest)); err != nil {
+			return nil, err
+		}
+	}
+	return commits, nil
+}
+
+// copyPairChunks - copies the chunks of every pair to its destination, when
+// one fails the chunks already copied are deleted again and nothing is committed
+func (s *KVServer) copyPairChunks(commits []pairCommit) error {
+	for i := range commits {
+		commit := &commits[i]
+		kept := chunkBlobs(commit.existing, commit.exists)
+		for _, manifest := range chunkedValues(commit.source, commit.history) {
+			err := s.copyChunks(commit.source.StorageKey(), s.storageKey(commit.pair.destination), manifest)
+			if !kept[manifest.Blob] {
+				commit.blobs = append(commit.blobs, manifest.Blob)
+			}
+			if err != nil {
+				s.removePairChunks(commits[:i+1])
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removePairChunks - deletes the chunks copied to the destinations of pairs
+func (s *KVServer) removePairChunks(commits []pairCommit) {
+	for _, commit := range commits {
+		for _, blob := range commit.blobs {
+			if err := s.persistence.DeleteChunks(s.storageKey(commit.pair.destination), blob); err != nil {
+				s.logger.Error("Error deleting copied chunks", "key", commit.pair.destination, "error", err)
+			}
+		}
+	}
+}
+
+// commitPairs - commits the prepared records, deleting the sources when moving,
+// then writes them to the persistence layer, records kept in memory are written
+// again by the next sync when that fails
+func (s *KVServer) commitPairs(commits []pairCommit, moving bool) {
+	for _, commit := range commits {
+		before := noState
+		if commit.exists {
+			before = stateOf(commit.existing)
+		}
+		s.Records.Set(s.storageKey(commit.pair.destination), commit.copied)
+		s.publish(types.OperationSet, before, commit.copied)
+	}
+	if moving {
+		for _, commit := range commits {
+			if err := s.delete(commit.pair.source); err != nil {
+				s.logger.Error("Error deleting renamed key", "key", commit.pair.source, "error", err)
+			}
+		}
+	}
+
+	for _, commit := range commits {
+		destinationKey := s.storageKey(commit.pair.destination)
+		// a replaced record must not leave versions or chunks behind in persistence
+		if commit.exists && commit.history {
+			if err := s.persistence.DeleteRecord(destinationKey); err != nil {
+				s.logger.Error("Error deleting replaced record", "key", commit.pair.destination, "error", err)
+			}
+			copied := chunkBlobs(commit.copied, true)
+			for blob := range chunkBlobs(commit.existing, true) {
+				if copied[blob] {
+					continue
+				}
+				if err := s.persistence.DeleteChunks(destinationKey, blob); err != nil {
+					s.logger.Error("Error deleting replaced chunks", "key", commit.pair.destination, "error", err)
+				}
+			}
+		}
+		if err := s.persistence.Write(commit.copied); err != nil {
+			s.logger.Error("Error writing copied record", "key", commit.pair.destination, "error", err)
+		}
+	}
+}
+
+// copyMetadata - copies the metadata entries of a record, the version entry
+// belongs to each record
+func copyMetadata(from KVRecord, to KVRecord) {
+	metadata := from.Metadata.Copy()
+	for key, value := range metadata {
+		if key == "Version" {
+			continue
+		}
+		if current, ok := to.Metadata.Get(key); !ok || current != value {
+			to.Metadata.Set(key, value)
+		}
+	}
+	if _, tagged := metadata[types.MetadataValueType]; !tagged {
+		if _, ok := to.Metadata.Get(types.MetadataValueType); ok {
+			to.Metadata.Delete(types.MetadataValueType)
+		}
+	}
+}
+
+// copyChunks - copies the chunks of a chunked value to another key
+func (s *KVServer) copyChunks(from string, to string, manifest types.ChunkManifest) error {
+	for index := 0; index < manifest.Chunks; index++ {
+		data, err := s.persistence.ReadChunk(from, manifest.Blob, index)
+		if err != nil {
+			return err
+		}
+		if err := s.persistence.WriteChunk(to, manifest.Blob, index, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkedValues - the manifests of a record's chunked values, the latest value
+// only unless history is wanted
+func chunkedValues(record KVRecord, history bool) []types.ChunkManifest {
+	chunked := false
+	for _, change := range record.Metadata.GetHistory() {
+		if change.Key == types.MetadataValueType && change.Value == types.ValueTypeChunked {
+			chunked = true
+			break
+		}
+	}
+	if !chunked {
+		return nil
+	}
+
+	values, _, _ := record.Value.History()
+	if !history && len(values) > 0 {
+		values = values[len(values)-1:]
+	}
+	manifests := []types.ChunkManifest{}
+	for _, value := range values {
+		if manifest, err := types.ParseChunkManifest(value); err == nil {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests
+}
+
+// chunkBlobs - the blobs of every chunked value of a record, none when it does not exist
+func chunkBlobs(record KVRecord, exists bool) map[string]bool {
+	blobs := make(map[string]bool)
+	if !exists {
+		return blobs
+	}
+	for _, manifest := range chunkedValues(record, true) {
+		blobs[manifest.Blob] = true
+	}
+	return blobs
+}
+
+// pairsResult - reports the pairs of a copy or rename
+func pairsResult(pairs []bulkPair, dryRun bool) types.BulkResult {
+	result := types.BulkResult{
+		Count:        len(pairs),
+		Keys:         make([]string, 0, len(pairs)),
+		Destinations: make([]string, 0, len(pairs)),
+		DryRun:       dryRun,
+	}
+	for _, pair := range pairs {
+		result.Keys = append(result.Keys, pair.source)
+		result.Destinations = append(result.Destinations, pair.destination)
+	}
+	return result
+}
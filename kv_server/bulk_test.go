@@ -0,0 +1,142 @@
+package kvserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that a dry run reports the keys a prefix delete would remove
+func TestDeletePrefix(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.Set("logs/a", []byte("1"))
+	svr.Set("logs/b", []byte("2"))
+	svr.Set("users/a", []byte("3"))
+
+	// Act
+	dry, dryErr := svr.DeletePrefix("logs/", true)
+	_, afterDry := svr.Get("logs/a")
+	result, err := svr.DeletePrefix("logs/", false)
+
+	// Assert
+	if dryErr != nil || err != nil {
+		t.Fatalf("delete failed: %v, %v", dryErr, err)
+	}
+	if dry.Count != 2 || !dry.DryRun || afterDry != nil {
+		t.Errorf("dry run should report keys without deleting, got %+v (%v)", dry, afterDry)
+	}
+	if result.Count != 2 || result.Keys[0] != "logs/a" || result.Keys[1] != "logs/b" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if _, err := svr.Get("logs/b"); err == nil {
+		t.Errorf("keys under the prefix should be deleted")
+	}
+	if _, err := svr.Get("users/a"); err != nil {
+		t.Errorf("keys outside the prefix should stay, got %v", err)
+	}
+}
+
+// Test that copies take the latest value unless asked for history, and refuse
+// to overwrite
+func TestCopy(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.Set("config", []byte("v1"))
+	svr.Set("config", []byte("v2"))
+	svr.Set("taken", []byte("x"))
+
+	// Act
+	_, latestErr := svr.Copy("config", "latest", types.CopyOptions{})
+	_, historyErr := svr.Copy("config", "history", types.CopyOptions{History: true})
+	_, takenErr := svr.Copy("config", "taken", types.CopyOptions{})
+
+	// Assert
+	if latestErr != nil || historyErr != nil {
+		t.Fatalf("copy failed: %v, %v", latestErr, historyErr)
+	}
+	if value, _ := svr.Get("latest"); string(value.([]byte)) != "v2" {
+		t.Errorf("copy should hold the latest value, got %s", value)
+	}
+	if latest, _ := svr.Records.Get("latest"); latest.GetVersion() != 0 {
+		t.Errorf("copy without history should hold a single version, got %d", latest.GetVersion())
+	}
+	if history, _ := svr.Records.Get("history"); history.GetVersion() != 1 {
+		t.Errorf("history copy should keep old versions, got %d", history.GetVersion())
+	}
+	if !errors.Is(takenErr, types.ErrKeyExists) {
+		t.Errorf("copy onto an existing key should be refused, got %v", takenErr)
+	}
+}
+
+// Test that a prefix rename moves every key and refuses overlapping prefixes
+func TestRenamePrefix(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.Set("old/a", []byte("1"))
+	svr.Set("old/b", []byte("2"))
+
+	// Act
+	_, overlapErr := svr.Rename("old/", "old/new/", types.CopyOptions{Prefix: true})
+	result, err := svr.Rename("old/", "new/", types.CopyOptions{Prefix: true})
+
+	// Assert
+	if overlapErr == nil {
+		t.Errorf("overlapping prefixes should be refused")
+	}
+	if err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if result.Count != 2 || result.Destinations[1] != "new/b" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if value, _ := svr.Get("new/a"); string(value.([]byte)) != "1" {
+		t.Errorf("renamed key should keep its value, got %s", value)
+	}
+	if _, err := svr.Get("old/a"); err == nil {
+		t.Errorf("source keys should be gone after a rename")
+	}
+}
+
+// Test that a rename or copy failing part way through changes no key
+func TestRenameAllOrNothing(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "quota.acme.max_keys": "3"})
+	svr.Set("a/1", []byte("1"))
+	svr.Set("a/2", []byte("2"))
+	svr.RegisterHook(types.HookFuncs{HookName: "guard", Before: func(op *types.WriteOperation) error {
+		if op.Tenant == types.DefaultTenant && op.Key == "b/2" {
+			return errors.New("b/2 is reserved")
+		}
+		return nil
+	}})
+	acme, _ := svr.ForTenant("acme")
+	acme.Set("a/1", []byte("1"))
+	acme.Set("a/2", []byte("2"))
+
+	// Act
+	_, renameErr := svr.Rename("a/", "b/", types.CopyOptions{Prefix: true})
+	_, copyErr := acme.Copy("a/", "b/", types.CopyOptions{Prefix: true})
+
+	// Assert
+	if !errors.Is(renameErr, types.ErrHookRejected) {
+		t.Errorf("rename onto a rejected key should fail, got %v", renameErr)
+	}
+	if _, err := svr.Get("a/1"); err != nil {
+		t.Errorf("sources should stay after a failed rename, got %v", err)
+	}
+	if _, err := svr.Get("b/1"); err == nil {
+		t.Errorf("no key should be renamed when one fails")
+	}
+	if !errors.Is(copyErr, types.ErrQuotaExceeded) {
+		t.Errorf("copy past the quota should fail, got %v", copyErr)
+	}
+	if _, err := acme.Get("b/1"); err == nil {
+		t.Errorf("no key should be copied when the batch exceeds the quota")
+	}
+}
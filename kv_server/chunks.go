@@ -0,0 +1,235 @@
+package kvserver
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Large values
+// values longer than `values.chunk_size` (bytes) are split into chunks stored by the
+// persistence layer, the record keeps a manifest tagged as a chunked value,
+// `values.max_value_size` (bytes) refuses larger values, 0 accepts any size
+
+// SetStream - A function that sets a value read from a stream, values longer
+// than a chunk are stored as chunks, returns the length of the value
+func (s *KVServer) SetStream(key string, value io.Reader) (size int64, err error) {
+	defer s.countOperation("set_stream", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return 0, err
+	}
+	return s.setStream(key, value, false)
+}
+
+// setStream - stores a streamed value, running the pre-write hooks over values
+// past one chunk unless they already ran
+func (s *KVServer) setStream(key string, value io.Reader, hooked bool) (size int64, err error) {
+	// values within a chunk are stored as they are, copied so they do not keep
+	// the whole chunk buffer
+	chunk := make([]byte, s.chunkSize)
+	n, err := io.ReadFull(value, chunk)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return int64(n), s.Set(key, append([]byte(nil), chunk[:n]...))
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// otherwise write the chunks before committing the manifest
+	manifest := types.ChunkManifest{Blob: newBlobID(), ChunkSize: s.chunkSize}
+	storageKey := s.storageKey(key)
+	for n > 0 {
+		manifest.Size += int64(n)
+		if err := s.checkValueSize(manifest.Size); err != nil {
+			s.persistence.DeleteChunks(storageKey, manifest.Blob)
+			return 0, err
+		}
+		if err := s.persistence.WriteChunk(storageKey, manifest.Blob, manifest.Chunks, chunk[:n]); err != nil {
+			s.persistence.DeleteChunks(storageKey, manifest.Blob)
+			return 0, err
+		}
+		manifest.Chunks++
+
+		n, err = io.ReadFull(value, chunk)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			s.persistence.DeleteChunks(storageKey, manifest.Blob)
+			return 0, err
+		}
+	}
+
+	// hooks see the length of a streamed value, a value they return replaces it
+	if !hooked {
+		op := types.WriteOperation{Key: key, Operation: types.OperationSet, Size: manifest.Size}
+		if err := s.beforeWrite(&op); err != nil {
+			s.persistence.DeleteChunks(storageKey, manifest.Blob)
+			return 0, err
+		}
+		if op.Value != nil {
+			s.persistence.DeleteChunks(storageKey, manifest.Blob)
+			if len(op.Value) > s.chunkSize {
+				return s.setStream(key, bytes.NewReader(op.Value), true)
+			}
+			return int64(len(op.Value)), s.setValue(key, op.Value, "")
+		}
+	}
+
+	if err := s.setValue(key, manifest.Encode(), types.ValueTypeChunked); err != nil {
+		s.persistence.DeleteChunks(storageKey, manifest.Blob)
+		return 0, err
+	}
+	return manifest.Size, nil
+}
+
+// OpenValue - A function that opens the latest value of a key for reading,
+// chunked values are read one chunk at a time
+func (s *KVServer) OpenValue(key string) (reader io.ReadSeeker, err error) {
+	defer s.countOperation("open_value", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
+	}
+
+	// check if the key is in the store
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	value, err := record.GetValue(-1)
+	if err != nil {
+		return nil, err
+	}
+	if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType != types.ValueTypeChunked {
+		return bytes.NewReader(value), nil
+	}
+	return s.openChunked(record.StorageKey(), value)
+}
+
+// openChunked - a reader over the chunks named by a manifest
+func (s *KVServer) openChunked(storageKey string, raw []byte) (*chunkReader, error) {
+	manifest, err := types.ParseChunkManifest(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &chunkReader{
+		manifest: manifest,
+		index:    -1,
+		load: func(index int) ([]byte, error) {
+			return s.persistence.ReadChunk(storageKey, manifest.Blob, index)
+		},
+	}, nil
+}
+
+// readChunked - assembles a chunked value
+func (s *KVServer) readChunked(storageKey string, raw []byte) ([]byte, error) {
+	reader, err := s.openChunked(storageKey, raw)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, 0, reader.manifest.Size)
+	buf := bytes.NewBuffer(value)
+	if _, err := io.Copy(buf, reader); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// checkValueSize - refuses values past `values.max_value_size` or the tenant's quota
+func (s *KVServer) checkValueSize(size int64) error {
+	if s.maxValueSize > 0 && size > s.maxValueSize {
+		return fmt.Errorf("%w: more than %d bytes", types.ErrValueTooLarge, s.maxValueSize)
+	}
+	return s.checkQuota(types.TenantUsage{}, types.TenantUsage{}, int(size))
+}
+
+// versionType - the value type of a version, the value type is tagged just
+// after the value is written, so the tag of a version is the one current until
+// the next version
+func versionType(record KVRecord, version int) string {
+	taggedAt := time.Now().UTC()
+	if next, err := record.Value.GetTimestamp(version + 1); err == nil {
+		taggedAt = next.Add(-time.Nanosecond)
+	}
+	return record.Metadata.GetAllAt(taggedAt)[types.MetadataValueType]
+}
+
+// valueSize - the length of a value, chunked values count their whole length
+func valueSize(value []byte, valueType string) int64 {
+	if valueType == types.ValueTypeChunked {
+		if manifest, err := types.ParseChunkManifest(value); err == nil {
+			return manifest.Size
+		}
+	}
+	return int64(len(value))
+}
+
+// newBlobID - a random name for the chunks of one value
+func newBlobID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// loadChunkConfig - reads `values.chunk_size` and `values.max_value_size`
+func (s *KVServer) loadChunkConfig() {
+	settings := s.config.Settings().Values
+	s.chunkSize = settings.ChunkSize
+	s.maxValueSize = settings.MaxValueSize
+}
+
+// chunkReader - io.ReadSeeker over a chunked value, holding one chunk at a time
+type chunkReader struct {
+	manifest types.ChunkManifest
+	load     func(index int) ([]byte, error)
+	offset   int64
+	// index of the loaded chunk, -1 before the first load
+	index int
+	chunk []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if r.offset >= r.manifest.Size {
+		return 0, io.EOF
+	}
+
+	index := int(r.offset / int64(r.manifest.ChunkSize))
+	if index != r.index {
+		chunk, err := r.load(index)
+		if err != nil {
+			return 0, err
+		}
+		r.index, r.chunk = index, chunk
+	}
+
+	within := r.offset - int64(index)*int64(r.manifest.ChunkSize)
+	if within >= int64(len(r.chunk)) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, r.chunk[within:])
+	r.offset += int64(n)
+	return n, nil
+}
+
+func (r *chunkReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.manifest.Size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
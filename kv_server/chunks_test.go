@@ -0,0 +1,83 @@
+package kvserver
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that values longer than a chunk are stored as chunks and read back
+func TestChunkedValues(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "chunk_size": "4", "max_value_size": "16"})
+
+	// Act
+	size, err := svr.SetStream("blob", strings.NewReader("0123456789"))
+
+	// Assert
+	if err != nil || size != 10 {
+		t.Fatalf("upload stored %d bytes (%v)", size, err)
+	}
+	if valueType, _ := svr.GetMetadata("blob", types.MetadataValueType); valueType != types.ValueTypeChunked {
+		t.Errorf("value type is %q", valueType)
+	}
+	if value, err := svr.Get("blob"); err != nil || string(value.([]byte)) != "0123456789" {
+		t.Errorf("value is %s (%v)", value, err)
+	}
+	reader, _ := svr.OpenValue("blob")
+	reader.Seek(3, io.SeekStart)
+	part := make([]byte, 5)
+	if _, err := io.ReadFull(reader, part); err != nil || string(part) != "34567" {
+		t.Errorf("range read %s (%v)", part, err)
+	}
+	if _, err := svr.SetStream("small", strings.NewReader("abc")); err != nil {
+		t.Errorf("small upload failed: %v", err)
+	}
+	if valueType, _ := svr.GetMetadata("small", types.MetadataValueType); valueType != "" {
+		t.Errorf("small value should be stored as it is, value type is %q", valueType)
+	}
+	large := NewKVServer(map[string]string{"driver": "none"})
+	large.SetStream("tiny", strings.NewReader("tiny"))
+	if record, _ := large.Records.Get("tiny"); cap(record.Value.Value[0]) >= large.chunkSize {
+		t.Errorf("small value should not keep the chunk buffer, capacity %d", cap(record.Value.Value[0]))
+	}
+	if err := svr.Set("huge", []byte("0123456789abcdefg")); !errors.Is(err, types.ErrValueTooLarge) {
+		t.Errorf("value past max_value_size should be refused, got %v", err)
+	}
+	record, _ := svr.Records.Get("blob")
+	raw, _ := record.GetValue(-1)
+	manifest, _ := types.ParseChunkManifest(raw)
+	svr.Delete("blob")
+	if _, err := svr.persistence.ReadChunk("blob", manifest.Blob, 0); err == nil || manifest.Chunks != 3 {
+		t.Errorf("chunks should be deleted with their key")
+	}
+}
+
+// Test that point in time reads of a chunked key assemble the value
+func TestChunkedValueAsOf(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "chunk_size": "4"})
+	svr.SetStream("blob", strings.NewReader("0123456789"))
+	time.Sleep(2 * time.Millisecond)
+	chunked := time.Now().UTC()
+	time.Sleep(2 * time.Millisecond)
+	svr.Set("blob", []byte("abc"))
+
+	// Act
+	old, oldErr := svr.GetAsOf("blob", chunked)
+	current, currentErr := svr.GetAsOf("blob", time.Now().UTC())
+
+	// Assert
+	if oldErr != nil || string(old.([]byte)) != "0123456789" {
+		t.Errorf("chunked version should be assembled, got %s (%v)", old, oldErr)
+	}
+	if currentErr != nil || string(current.([]byte)) != "abc" {
+		t.Errorf("latest version is %s (%v)", current, currentErr)
+	}
+}
@@ -0,0 +1,252 @@
+package kvserver
+
+import (
+	"fmt"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// ListPush - appends values to the tail of a list, or prepends them to the
+// head when front is set, creating the list when missing, returns the new length
+func (s *KVServer) ListPush(key string, values []string, front bool) (length int, err error) {
+	defer s.countOperation("list_push", &err)
+	if len(values) == 0 {
+		return 0, fmt.Errorf("values cannot be empty")
+	}
+
+	err = s.updateTyped(key, types.ValueTypeList, func(current []byte) ([]byte, error) {
+		list, err := types.DecodeList(current)
+		if err != nil {
+			return nil, err
+		}
+		if front {
+			// pushing one at a time onto the head reverses the given order
+			pushed := make([]string, 0, len(values)+len(list))
+			for i := len(values) - 1; i >= 0; i-- {
+				pushed = append(pushed, values[i])
+			}
+			list = append(pushed, list...)
+		} else {
+			list = append(list, values...)
+		}
+		length = len(list)
+		return types.EncodeList(list), nil
+	})
+	return length, err
+}
+
+// ListPop - removes and returns the tail of a list, or its head when front is set
+func (s *KVServer) ListPop(key string, front bool) (value string, err error) {
+	defer s.countOperation("list_pop", &err)
+	err = s.updateTyped(key, types.ValueTypeList, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+		}
+		list, err := types.DecodeList(current)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) == 0 {
+			return nil, fmt.Errorf("%w: list %v", types.ErrEmptyCollection, key)
+		}
+		if front {
+			value, list = list[0], list[1:]
+		} else {
+			value, list = list[len(list)-1], list[:len(list)-1]
+		}
+		return types.EncodeList(list), nil
+	})
+	return value, err
+}
+
+// ListRange - elements of a list between start and stop inclusive, negative
+// indexes count from the end
+func (s *KVServer) ListRange(key string, start int, stop int) (values []string, err error) {
+	defer s.countOperation("list_range", &err)
+	current, err := s.getTyped(key, types.ValueTypeList)
+	if err != nil {
+		return nil, err
+	}
+	list, err := types.DecodeList(current)
+	if err != nil {
+		return nil, err
+	}
+	return types.ListRange(list, start, stop), nil
+}
+
+// SetAdd - adds members to a set, creating the set when missing, returns how
+// many were not already present
+func (s *KVServer) SetAdd(key string, members []string) (added int, err error) {
+	defer s.countOperation("set_add", &err)
+	if len(members) == 0 {
+		return 0, fmt.Errorf("members cannot be empty")
+	}
+
+	err = s.updateTyped(key, types.ValueTypeSet, func(current []byte) ([]byte, error) {
+		set, err := types.DecodeSet(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if !set[member] {
+				set[member] = true
+				added++
+			}
+		}
+		return types.EncodeSet(set), nil
+	})
+	return added, err
+}
+
+// SetRemove - removes members from a set, returns how many were present
+func (s *KVServer) SetRemove(key string, members []string) (removed int, err error) {
+	defer s.countOperation("set_remove", &err)
+	if len(members) == 0 {
+		return 0, fmt.Errorf("members cannot be empty")
+	}
+
+	err = s.updateTyped(key, types.ValueTypeSet, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+		}
+		set, err := types.DecodeSet(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if set[member] {
+				delete(set, member)
+				removed++
+			}
+		}
+		return types.EncodeSet(set), nil
+	})
+	return removed, err
+}
+
+// SetMembers - sorted members of a set
+func (s *KVServer) SetMembers(key string) (members []string, err error) {
+	defer s.countOperation("set_members", &err)
+	current, err := s.getTyped(key, types.ValueTypeSet)
+	if err != nil {
+		return nil, err
+	}
+	set, err := types.DecodeSet(current)
+	if err != nil {
+		return nil, err
+	}
+	return types.SetMembers(set), nil
+}
+
+// HashSet - sets a field of a hash, creating the hash when missing
+func (s *KVServer) HashSet(key string, field string, value string) (err error) {
+	defer s.countOperation("hash_set", &err)
+	if field == "" {
+		return fmt.Errorf("field cannot be empty")
+	}
+
+	return s.updateTyped(key, types.ValueTypeHash, func(current []byte) ([]byte, error) {
+		hash, err := types.DecodeHash(current)
+		if err != nil {
+			return nil, err
+		}
+		hash[field] = value
+		return types.EncodeHash(hash), nil
+	})
+}
+
+// HashGet - gets a field of a hash
+func (s *KVServer) HashGet(key string, field string) (value string, err error) {
+	defer s.countOperation("hash_get", &err)
+	hash, err := s.HashGetAll(key)
+	if err != nil {
+		return "", err
+	}
+	value, ok := hash[field]
+	if !ok {
+		return "", fmt.Errorf("field not found")
+	}
+	return value, nil
+}
+
+// HashDelete - removes a field from a hash
+func (s *KVServer) HashDelete(key string, field string) (err error) {
+	defer s.countOperation("hash_delete", &err)
+	return s.updateTyped(key, types.ValueTypeHash, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+		}
+		hash, err := types.DecodeHash(current)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := hash[field]; !ok {
+			return nil, fmt.Errorf("field not found")
+		}
+		delete(hash, field)
+		return types.EncodeHash(hash), nil
+	})
+}
+
+// HashGetAll - gets every field of a hash
+func (s *KVServer) HashGetAll(key string) (hash map[string]string, err error) {
+	defer s.countOperation("hash_get_all", &err)
+	current, err := s.getTyped(key, types.ValueTypeHash)
+	if err != nil {
+		return nil, err
+	}
+	return types.DecodeHash(current)
+}
+
+// getTyped - reads the latest value of a key holding the given value type
+func (s *KVServer) getTyped(key string, valueType string) ([]byte, error) {
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
+	}
+
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+	if current, _ := record.Metadata.Get(types.MetadataValueType); current != valueType {
+		return nil, fmt.Errorf("%w: %v is not a %v", types.ErrTypeMismatch, key, valueType)
+	}
+	return record.GetValue(-1)
+}
+
+// updateTyped - read-modify-write of a key holding the given value type under
+// the key lock, update receives nil for a missing key and each change commits
+// as a new version
+func (s *KVServer) updateTyped(key string, valueType string, update func(current []byte) ([]byte, error)) error {
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
+	}
+
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	var current []byte
+	if record, ok := s.Records.Get(s.storageKey(key)); ok && !record.IsDeleted() {
+		var err error
+		if current, err = s.getTyped(key, valueType); err != nil {
+			return err
+		}
+		// never hand back nil for an existing key
+		if current == nil {
+			current = []byte{}
+		}
+	}
+
+	updated, err := update(current)
+	if err != nil {
+		return err
+	}
+	if err := s.checkWrite(key, updated, valueType); err != nil {
+		return err
+	}
+
+	_, err = s.set(key, updated, valueType)
+	return err
+}
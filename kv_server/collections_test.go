@@ -0,0 +1,62 @@
+package kvserver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test list, set and hash operations and their versioning
+func TestStructuredValues(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+
+	// Act
+	svr.ListPush("queue", []string{"b", "c"}, false)
+	svr.ListPush("queue", []string{"a"}, true)
+	head, _ := svr.ListPop("queue", true)
+	svr.SetAdd("tags", []string{"red", "blue", "red"})
+	svr.SetRemove("tags", []string{"blue"})
+	svr.HashSet("user", "name", "ada")
+
+	// Assert
+	if head != "a" {
+		t.Errorf("popped %v instead of a", head)
+	}
+	if values, _ := svr.ListRange("queue", 0, -1); !reflect.DeepEqual(values, []string{"b", "c"}) {
+		t.Errorf("list is %v instead of [b c]", values)
+	}
+	if members, _ := svr.SetMembers("tags"); !reflect.DeepEqual(members, []string{"red"}) {
+		t.Errorf("set is %v instead of [red]", members)
+	}
+	if value, err := svr.HashGet("user", "name"); err != nil || value != "ada" {
+		t.Errorf("hash field is %v (%v) instead of ada", value, err)
+	}
+	record, _ := svr.Records.Get("queue")
+	if record.Value.Len() != 3 {
+		t.Errorf("list has %d versions instead of 3", record.Value.Len())
+	}
+}
+
+// Test that operations on the wrong value type are rejected
+func TestStructuredValueTypeMismatch(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.HashSet("user", "name", "ada")
+	svr.Set("plain", []byte("text"))
+
+	// Act & Assert
+	if _, err := svr.ListPush("user", []string{"x"}, false); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("list push on a hash should be a type mismatch, got %v", err)
+	}
+	if _, err := svr.SetMembers("plain"); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("set members on a plain value should be a type mismatch, got %v", err)
+	}
+	if _, err := svr.ListPop("empty", false); err == nil {
+		t.Errorf("pop on a missing list should fail")
+	}
+}
@@ -0,0 +1,112 @@
+package kvserver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Increment - atomically adds delta to a counter, creating it from the initial
+// value when missing, and returns the new value, values of another type such as
+// sequences, locks or collections are refused
+func (s *KVServer) Increment(key string, delta int64, options types.CounterOptions) (value int64, err error) {
+	defer s.countOperation("increment", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return 0, err
+	}
+
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	current := options.Initial
+	record, ok := s.Records.Get(s.storageKey(key))
+	if ok && !record.IsDeleted() {
+		if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType != "" && valueType != types.ValueTypeCounter {
+			return 0, fmt.Errorf("%w: %v is a %v", types.ErrTypeMismatch, key, valueType)
+		}
+
+		current, err = parseCounter(record)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// refuse to wrap around
+	if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+		return 0, fmt.Errorf("%w: %v overflows", types.ErrOutOfBounds, key)
+	}
+	value = current + delta
+
+	if options.Min != nil && value < *options.Min {
+		return 0, fmt.Errorf("%w: %d is below %d", types.ErrOutOfBounds, value, *options.Min)
+	}
+	if options.Max != nil && value > *options.Max {
+		return 0, fmt.Errorf("%w: %d is above %d", types.ErrOutOfBounds, value, *options.Max)
+	}
+
+	bValue := []byte(strconv.FormatInt(value, 10))
+	if err := s.checkWrite(key, bValue, types.ValueTypeCounter); err != nil {
+		return 0, err
+	}
+	if _, err := s.set(key, bValue, types.ValueTypeCounter); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// NextSequence - atomically advances a sequence starting at 1 and returns the
+// new value, sequences never repeat or move backwards while the key exists,
+// deleting the key restarts the sequence at 1
+func (s *KVServer) NextSequence(key string) (value int64, err error) {
+	defer s.countOperation("next_sequence", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return 0, err
+	}
+
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	record, ok := s.Records.Get(s.storageKey(key))
+	if ok && !record.IsDeleted() {
+		if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType != types.ValueTypeSequence {
+			return 0, fmt.Errorf("%w: %v is not a sequence", types.ErrTypeMismatch, key)
+		}
+
+		value, err = parseCounter(record)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if value == math.MaxInt64 {
+		return 0, fmt.Errorf("%w: sequence %v is exhausted", types.ErrOutOfBounds, key)
+	}
+	value++
+
+	bValue := []byte(strconv.FormatInt(value, 10))
+	if err := s.checkWrite(key, bValue, types.ValueTypeSequence); err != nil {
+		return 0, err
+	}
+	if _, err := s.set(key, bValue, types.ValueTypeSequence); err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+// parseCounter - reads the latest value of a record as a decimal integer
+func parseCounter(record KVRecord) (int64, error) {
+	bValue, err := record.GetValue(-1)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseInt(string(bValue), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: value of %v is not an integer", types.ErrTypeMismatch, record.Key)
+	}
+	return value, nil
+}
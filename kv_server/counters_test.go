@@ -0,0 +1,85 @@
+package kvserver
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that concurrent increments are not lost and each one is a version
+func TestIncrementConcurrent(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	wg := &sync.WaitGroup{}
+
+	// Act
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svr.Increment("hits", 2, types.CounterOptions{Initial: 10})
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	value, err := svr.Get("hits")
+	if err != nil || string(value.([]byte)) != "110" {
+		t.Errorf("counter is %v (%v) instead of 110", value, err)
+	}
+	record, _ := svr.Records.Get("hits")
+	if record.Value.Len() != 50 {
+		t.Errorf("counter has %d versions instead of 50", record.Value.Len())
+	}
+}
+
+// Test bounds, type checks and sequences
+func TestIncrementBoundsAndSequence(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	max := int64(3)
+	svr.Set("name", []byte("abc"))
+
+	// Act & Assert
+	if _, err := svr.Increment("name", 1, types.CounterOptions{}); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("incrementing text should be a type mismatch, got %v", err)
+	}
+	if value, err := svr.Increment("stock", 3, types.CounterOptions{Max: &max}); err != nil || value != 3 {
+		t.Errorf("increment returned %d (%v) instead of 3", value, err)
+	}
+	if _, err := svr.Increment("stock", 1, types.CounterOptions{Max: &max}); !errors.Is(err, types.ErrOutOfBounds) {
+		t.Errorf("increment past max should be out of bounds, got %v", err)
+	}
+	for want := int64(1); want <= 3; want++ {
+		if value, err := svr.NextSequence("ids"); err != nil || value != want {
+			t.Errorf("sequence returned %d (%v) instead of %d", value, err, want)
+		}
+	}
+	if err := svr.Set("ids", []byte("1")); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("setting a sequence should be a type mismatch, got %v", err)
+	}
+	if _, err := svr.Increment("ids", 1, types.CounterOptions{}); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("incrementing a sequence should be a type mismatch, got %v", err)
+	}
+	svr.ListPush("queue", []string{"7"}, false)
+	if _, err := svr.Increment("queue", 1, types.CounterOptions{}); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("incrementing a list should be a type mismatch, got %v", err)
+	}
+	if err := svr.DeleteMetadata("ids", types.MetadataValueType); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("untagging a sequence should be refused, got %v", err)
+	}
+	if err := svr.SetMetadata("queue", types.MetadataValueType, types.ValueTypeCounter); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("retagging a list should be refused, got %v", err)
+	}
+	if value, err := svr.NextSequence("ids"); err != nil || value != 4 {
+		t.Errorf("sequence returned %d (%v) instead of 4", value, err)
+	}
+	svr.Delete("ids")
+	if value, err := svr.NextSequence("ids"); err != nil || value != 1 {
+		t.Errorf("deleted sequence returned %d (%v) instead of restarting at 1", value, err)
+	}
+}
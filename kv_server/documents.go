@@ -0,0 +1,81 @@
+package kvserver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// SetDocument - sets a JSON document, tagging the record as a document
+func (s *KVServer) SetDocument(key string, document []byte) (err error) {
+	defer s.countOperation("set_document", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
+	}
+
+	if !json.Valid(document) {
+		return fmt.Errorf("%w: value is not JSON", types.ErrInvalidDocument)
+	}
+
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	// sequences only move forward through NextSequence
+	if record, ok := s.Records.Get(s.storageKey(key)); ok && !record.IsDeleted() {
+		if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType == types.ValueTypeSequence {
+			return fmt.Errorf("%w: %v is a sequence", types.ErrTypeMismatch, key)
+		}
+	}
+
+	if err := s.checkWrite(key, document, types.ValueTypeDocument); err != nil {
+		return err
+	}
+	_, err = s.set(key, document, types.ValueTypeDocument)
+	return err
+}
+
+// GetDocument - gets a document, or the part of it selected by a JSON Pointer
+// or dotted path
+func (s *KVServer) GetDocument(key string, path string) (value []byte, err error) {
+	defer s.countOperation("get_document", &err)
+	document, err := s.getTyped(key, types.ValueTypeDocument)
+	if err != nil {
+		return nil, err
+	}
+	return types.DocumentGet(document, path)
+}
+
+// PatchDocument - applies an RFC 6902 JSON Patch, or an RFC 7386 merge patch
+// when merge is set, committing the result as a new version
+func (s *KVServer) PatchDocument(key string, patch []byte, merge bool) (document []byte, err error) {
+	defer s.countOperation("patch_document", &err)
+	err = s.updateTyped(key, types.ValueTypeDocument, func(current []byte) ([]byte, error) {
+		if current == nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+		}
+		if merge {
+			document, err = types.ApplyMergePatch(current, patch)
+		} else {
+			document, err = types.ApplyJSONPatch(current, patch)
+		}
+		return document, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// FindByDocument - finds documents by their fields, the query follows
+// FindByMetadata with dotted paths as keys, e.g. "address.city:==:Paris"
+func (s *KVServer) FindByDocument(query string) (keys []string, err error) {
+	defer s.countOperation("find_by_document", &err)
+	// check if the query is empty
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	return s.scopeKeys(s.Records.FindByDocument(query)), nil
+}
@@ -0,0 +1,46 @@
+package kvserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that document patches commit atomically as new versions and can be queried
+func TestDocumentPatchAndFind(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.SetDocument("user/1", []byte(`{"name":"ada","address":{"city":"London"}}`))
+	svr.SetDocument("user/2", []byte(`{"name":"bob","address":{"city":"Paris"}}`))
+
+	// Act
+	_, err := svr.PatchDocument("user/1", []byte(`[{"op":"replace","path":"/address/city","value":"Paris"},{"op":"test","path":"/name","value":"eve"}]`), false)
+
+	// Assert
+	if !errors.Is(err, types.ErrPatchTestFailed) {
+		t.Errorf("failing test operation should abort the patch, got %v", err)
+	}
+	if city, _ := svr.GetDocument("user/1", "address.city"); string(city) != `"London"` {
+		t.Errorf("aborted patch changed the document, city is %s", city)
+	}
+
+	// Act
+	document, err := svr.PatchDocument("user/1", []byte(`{"address":{"city":"Paris"}}`), true)
+
+	// Assert
+	if err != nil || string(document) != `{"address":{"city":"Paris"},"name":"ada"}` {
+		t.Errorf("merge patch returned %s (%v)", document, err)
+	}
+	record, _ := svr.Records.Get("user/1")
+	if record.Value.Len() != 2 {
+		t.Errorf("document has %d versions instead of 2", record.Value.Len())
+	}
+	if keys, _ := svr.FindByDocument("address.city:==:Paris"); len(keys) != 2 {
+		t.Errorf("found %v instead of both users", keys)
+	}
+	if _, err := svr.PatchDocument("plain", []byte(`{}`), true); err == nil {
+		t.Errorf("patching a missing key should fail")
+	}
+}
@@ -0,0 +1,95 @@
+package kvserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aawadall/simple-kv/config"
+	"github.com/aawadall/simple-kv/features"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Feature flags
+// flags are stored under the reserved flag namespace of the default tenant and
+// shared by every tenant view, a flag is evaluated for the tenant of the view
+
+// softDeleteFlag - turns soft delete on or off at runtime, per tenant or for a
+// rollout of keys, defined from the soft_delete setting
+const softDeleteFlag = "soft_delete"
+
+// ListFlags - A function that lists every flag in effect
+func (s *KVServer) ListFlags() (flags []types.FeatureFlag, err error) {
+	defer s.countOperation("list_flags", &err)
+	return s.flags.List(), nil
+}
+
+// GetFlag - A function that gets the flag in effect
+func (s *KVServer) GetFlag(name string) (flag types.FeatureFlag, err error) {
+	defer s.countOperation("get_flag", &err)
+	return s.flags.Get(name)
+}
+
+// SetFlag - A function that stores a flag, taking effect right away
+func (s *KVServer) SetFlag(flag types.FeatureFlag) (err error) {
+	defer s.countOperation("set_flag", &err)
+	return s.flags.Set(flag)
+}
+
+// DeleteFlag - A function that deletes a stored flag, a built-in flag goes
+// back to its default
+func (s *KVServer) DeleteFlag(name string) (err error) {
+	defer s.countOperation("delete_flag", &err)
+	return s.flags.Delete(name)
+}
+
+// EvaluateFlag - A function that evaluates a flag for the tenant of the server
+// and a subject, such as a key or a user
+func (s *KVServer) EvaluateFlag(name string, subject string) (enabled bool, err error) {
+	defer s.countOperation("evaluate_flag", &err)
+	if _, err := s.flags.Get(name); err != nil {
+		return false, err
+	}
+	return s.flags.IsEnabledFor(name, s.tenant, subject), nil
+}
+
+// loadFeatureFlags - defines the built-in flags from their settings, stored
+// flags of the same name win
+func (s *KVServer) loadFeatureFlags() {
+	s.defineFeatureFlags(s.config.Settings())
+
+	s.flags.Observe(func(change features.Change) {
+		if change.Deleted {
+			s.logger.Info("Feature flag deleted", "flag", change.Name)
+			return
+		}
+		s.logger.Info("Feature flag changed", "flag", change.Name, "enabled", change.Flag.Enabled,
+			"rollout", change.Flag.Rollout, "tenants", change.Flag.Tenants)
+	})
+}
+
+// defineFeatureFlags - defines the built-in flags from settings, again on reload
+func (s *KVServer) defineFeatureFlags(settings *config.Config) {
+	s.flags.Define(types.FeatureFlag{
+		Name:        softDeleteFlag,
+		Description: "Delete writes a tombstone version instead of dropping the record",
+		Enabled:     settings.SoftDelete.Enabled,
+	})
+}
+
+// softDeleteFor - checks if deleting a key writes a tombstone
+func (s *KVServer) softDeleteFor(key string) bool {
+	return s.flags.IsEnabledFor(softDeleteFlag, s.tenant, key)
+}
+
+// validateFlag - checks a value written under the flag namespace is a flag
+// named after its key
+func validateFlag(key string, value []byte) error {
+	flag, err := types.ParseFeatureFlag(value)
+	if err != nil {
+		return err
+	}
+	if name := strings.TrimPrefix(key, types.FlagNamespace); flag.Name != name {
+		return fmt.Errorf("%w: flag %q stored under %q", types.ErrInvalidFlag, flag.Name, key)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package kvserver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/features"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that the soft delete flag is evaluated per tenant at runtime
+func TestSoftDeleteFlag(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	if err := svr.flags.Start(); err != nil {
+		t.Fatalf("flags did not start: %v", err)
+	}
+	defer svr.flags.Stop()
+	acme := svr.inTenant("acme")
+	svr.Set("key", []byte("value"))
+	acme.Set("key", []byte("value"))
+
+	// Act
+	err := svr.SetFlag(types.FeatureFlag{Name: softDeleteFlag, Enabled: true, Tenants: map[string]bool{"acme": false}})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("set flag returned error %v", err)
+	}
+	svr.Delete("key")
+	acme.Delete("key")
+	if _, ok := svr.Records.Get("key"); !ok {
+		t.Errorf("the default tenant should keep a tombstone")
+	}
+	if _, ok := svr.Records.Get(types.StorageKey("acme", "key")); ok {
+		t.Errorf("acme is excluded from soft delete")
+	}
+
+	// Act
+	err = svr.DeleteFlag(softDeleteFlag)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("delete flag returned error %v", err)
+	}
+	if flag, err := svr.GetFlag(softDeleteFlag); err != nil || flag.Enabled {
+		t.Errorf("soft delete should be back to its disabled default, got %+v (%v)", flag, err)
+	}
+	if err := svr.DeleteFlag(softDeleteFlag); !errors.Is(err, types.ErrFlagNotFound) {
+		t.Errorf("deleting a flag that is not stored returned %v", err)
+	}
+}
+
+// Test that flags written as records are validated, followed and observed
+func TestFlagRecords(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	changes := make(chan features.Change, 10)
+	svr.flags.Observe(func(change features.Change) { changes <- change })
+	if err := svr.flags.Start(); err != nil {
+		t.Fatalf("flags did not start: %v", err)
+	}
+	defer svr.flags.Stop()
+
+	// Act
+	err := svr.SetDocument(types.FlagNamespace+"beta", []byte(`{"name":"beta","enabled":true,"rollout":50}`))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("set document returned error %v", err)
+	}
+	select {
+	case change := <-changes:
+		if change.Name != "beta" || change.Flag.Rollout != 50 {
+			t.Errorf("unexpected change %+v", change)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the flag change")
+	}
+	enabled := 0
+	for subject := 0; subject < 1000; subject++ {
+		if svr.flags.IsEnabledFor("beta", types.DefaultTenant, fmt.Sprint(subject)) {
+			enabled++
+		}
+	}
+	if enabled < 400 || enabled > 600 {
+		t.Errorf("a 50%% rollout enabled %d of 1000 subjects", enabled)
+	}
+	if _, err := svr.EvaluateFlag("missing", ""); !errors.Is(err, types.ErrFlagNotFound) {
+		t.Errorf("evaluating a missing flag returned %v", err)
+	}
+
+	// Act
+	err = svr.SetDocument(types.FlagNamespace+"gamma", []byte(`{"name":"other","enabled":true}`))
+
+	// Assert
+	if !errors.Is(err, types.ErrInvalidFlag) {
+		t.Errorf("a flag stored under another name returned %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package kvserver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Hooks
+// pre-write hooks run in registration order before every client write is
+// applied, each sees the operation as changed by the ones before it and the
+// first error rejects the write, sets computed by the server, such as counters,
+// collections, documents and copies, are checked under the key lock and a value
+// a hook returns for them is not applied, writes the server makes on its own,
+// lease expiry, locks, purges of soft deleted keys, bypass the hooks,
+// post-write hooks see every committed change in revision order on a delivery
+// goroutine, so a slow hook never holds up writes, until the server stops
+
+// hookRegistry - registered hooks and the changes waiting for their post-write calls
+type hookRegistry struct {
+	mu      sync.Mutex
+	hooks   []types.Hook
+	pending []types.ChangeEvent
+	queued  *sync.Cond
+	running bool
+	stopped bool
+}
+
+func newHookRegistry() *hookRegistry {
+	r := &hookRegistry{}
+	r.queued = sync.NewCond(&r.mu)
+	return r
+}
+
+// RegisterHook - A function that registers a hook invoked around the writes of
+// every tenant
+func (s *KVServer) RegisterHook(hook types.Hook) {
+	s.hooks.mu.Lock()
+	defer s.hooks.mu.Unlock()
+	s.hooks.hooks = append(s.hooks.hooks, hook)
+
+	if !s.hooks.running && !s.hooks.stopped {
+		s.hooks.running = true
+		go s.hooks.deliver()
+	}
+}
+
+// registered - a snapshot of the registered hooks
+func (r *hookRegistry) registered() []types.Hook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.hooks
+}
+
+// depth - changes waiting for their post-write calls
+func (r *hookRegistry) depth() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending)
+}
+
+// beforeWrite - runs the pre-write hooks over an operation of the server's tenant
+func (s *KVServer) beforeWrite(op *types.WriteOperation) error {
+	op.Tenant = s.tenant
+	for _, hook := range s.hooks.registered() {
+		if err := hook.BeforeWrite(op); err != nil {
+			return fmt.Errorf("%w: %v: %v", types.ErrHookRejected, hook.Name(), err)
+		}
+	}
+	return nil
+}
+
+// checkWrite - runs the pre-write hooks over a set computed by the server,
+// hooks may reject it but a value they return is not applied
+func (s *KVServer) checkWrite(key string, value []byte, valueType string) error {
+	return s.beforeWrite(&types.WriteOperation{Key: key, Operation: types.OperationSet, Value: value, Size: valueSize(value, valueType)})
+}
+
+// enqueue - queues a committed change for the post-write hooks, called by the
+// watch hub in revision order
+func (r *hookRegistry) enqueue(event types.ChangeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.hooks) == 0 || r.stopped {
+		return
+	}
+	r.pending = append(r.pending, event)
+	r.queued.Signal()
+}
+
+// stop - stops the delivery goroutine once the queued changes are delivered
+func (r *hookRegistry) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	r.queued.Broadcast()
+}
+
+// deliver - calls the post-write hooks with queued changes, one change at a
+// time, until stopped
+func (r *hookRegistry) deliver() {
+	for {
+		r.mu.Lock()
+		for len(r.pending) == 0 && !r.stopped {
+			r.queued.Wait()
+		}
+		if len(r.pending) == 0 {
+			r.running = false
+			r.mu.Unlock()
+			return
+		}
+		events, hooks := r.pending, r.hooks
+		r.pending = nil
+		r.mu.Unlock()
+
+		for _, event := range events {
+			for _, hook := range hooks {
+				hook.AfterWrite(event)
+			}
+		}
+	}
+}
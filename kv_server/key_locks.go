@@ -0,0 +1,52 @@
+package kvserver
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+// number of lock stripes, keys hashing to the same stripe share a lock
+const keyLockStripes = 64
+
+// keyLocks - striped per key locks serializing read-modify-write operations
+type keyLocks struct {
+	stripes [keyLockStripes]sync.Mutex
+}
+
+// lock - locks the stripe for a key, returning the unlock function
+func (l *keyLocks) lock(key string) func() {
+	stripe := &l.stripes[stripeOf(key)]
+	stripe.Lock()
+	return stripe.Unlock
+}
+
+// lockAll - locks the stripes of several keys in stripe order, so concurrent
+// callers cannot deadlock, returning the unlock function
+func (l *keyLocks) lockAll(keys []string) func() {
+	seen := make(map[uint32]bool)
+	var stripes []int
+	for _, key := range keys {
+		if stripe := stripeOf(key); !seen[stripe] {
+			seen[stripe] = true
+			stripes = append(stripes, int(stripe))
+		}
+	}
+	sort.Ints(stripes)
+
+	for _, stripe := range stripes {
+		l.stripes[stripe].Lock()
+	}
+	return func() {
+		for i := len(stripes) - 1; i >= 0; i-- {
+			l.stripes[stripes[i]].Unlock()
+		}
+	}
+}
+
+// stripeOf - the stripe guarding a key
+func stripeOf(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % keyLockStripes
+}
@@ -0,0 +1,393 @@
+package kvserver
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Leases and locks
+// a lease lives for its TTL unless kept alive, keys attached to it are deleted
+// and locks held by it are released when it expires or is revoked,
+// leases live in memory, keys attached to a lease are deleted on restart,
+// a lock record holds its holder's lease and fencing token, tokens come from a
+// server wide counter starting at 1 that is persisted before it is handed out,
+// lock records are only written by Lock and Unlock
+
+// leaseManager - live leases of every tenant
+type leaseManager struct {
+	mu     sync.Mutex
+	nextID int64
+	leases map[int64]*lease
+	// fence - the latest fencing token handed out
+	fence uint64
+}
+
+// lease - a live lease, expiring when its timer fires
+type lease struct {
+	tenant    string
+	ttl       time.Duration
+	expiresAt time.Time
+	keys      map[string]bool
+	locks     map[string]bool
+	timer     *time.Timer
+}
+
+func newLeaseManager() *leaseManager {
+	// ids start from the clock, so they are not reused across restarts
+	return &leaseManager{
+		nextID: time.Now().UnixNano() / int64(time.Millisecond),
+		leases: make(map[int64]*lease),
+	}
+}
+
+// snapshot - the lease as reported to clients, callers hold the lock
+func (l *lease) snapshot(id int64) types.Lease {
+	snapshot := types.Lease{
+		ID:        id,
+		TTL:       int64(l.ttl / time.Second),
+		ExpiresAt: l.expiresAt,
+		Keys:      []string{},
+		Locks:     []string{},
+	}
+	for key := range l.keys {
+		snapshot.Keys = append(snapshot.Keys, key)
+	}
+	for name := range l.locks {
+		snapshot.Locks = append(snapshot.Locks, name)
+	}
+	sort.Strings(snapshot.Keys)
+	sort.Strings(snapshot.Locks)
+	return snapshot
+}
+
+// live - a lease of the tenant, callers hold the lock
+func (m *leaseManager) live(id int64, tenant string) (*lease, error) {
+	l, ok := m.leases[id]
+	if !ok || l.tenant != tenant {
+		return nil, fmt.Errorf("%w: %d", types.ErrLeaseNotFound, id)
+	}
+	return l, nil
+}
+
+// resumeFence - continues fencing tokens from the latest one handed out
+func (m *leaseManager) resumeFence(fence uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if fence > m.fence {
+		m.fence = fence
+	}
+}
+
+// alive - checks a lease of the tenant exists
+func (m *leaseManager) alive(id int64, tenant string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := m.live(id, tenant)
+	return err == nil
+}
+
+// take - removes a lease, stopping its timer
+func (m *leaseManager) take(id int64) (*lease, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l, ok := m.leases[id]
+	if !ok {
+		return nil, false
+	}
+	l.timer.Stop()
+	delete(m.leases, id)
+	return l, true
+}
+
+// GrantLease - A function that grants a lease living for the given TTL
+func (s *KVServer) GrantLease(ttl time.Duration) (result types.Lease, err error) {
+	defer s.countOperation("grant_lease", &err)
+	if ttl < time.Second {
+		return types.Lease{}, fmt.Errorf("lease TTL must be at least one second")
+	}
+
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	s.leases.nextID++
+	id := s.leases.nextID
+	l := &lease{
+		tenant:    s.tenant,
+		ttl:       ttl,
+		expiresAt: time.Now().UTC().Add(ttl),
+		keys:      make(map[string]bool),
+		locks:     make(map[string]bool),
+	}
+	l.timer = time.AfterFunc(ttl, func() { s.expireLease(id) })
+	s.leases.leases[id] = l
+	return l.snapshot(id), nil
+}
+
+// KeepAliveLease - A function that restarts the TTL of a lease
+func (s *KVServer) KeepAliveLease(id int64) (result types.Lease, err error) {
+	defer s.countOperation("keep_alive_lease", &err)
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	l, err := s.leases.live(id, s.tenant)
+	if err != nil {
+		return types.Lease{}, err
+	}
+
+	// a timer that already fired is expiring the lease
+	if !l.timer.Stop() {
+		return types.Lease{}, fmt.Errorf("%w: %d", types.ErrLeaseNotFound, id)
+	}
+	l.timer.Reset(l.ttl)
+	l.expiresAt = time.Now().UTC().Add(l.ttl)
+	return l.snapshot(id), nil
+}
+
+// GetLease - A function that returns a lease with its keys and locks
+func (s *KVServer) GetLease(id int64) (result types.Lease, err error) {
+	defer s.countOperation("get_lease", &err)
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	l, err := s.leases.live(id, s.tenant)
+	if err != nil {
+		return types.Lease{}, err
+	}
+	return l.snapshot(id), nil
+}
+
+// RevokeLease - A function that ends a lease now, deleting its keys and
+// releasing its locks
+func (s *KVServer) RevokeLease(id int64) (err error) {
+	defer s.countOperation("revoke_lease", &err)
+	if !s.leases.alive(id, s.tenant) {
+		return fmt.Errorf("%w: %d", types.ErrLeaseNotFound, id)
+	}
+	if l, ok := s.leases.take(id); ok {
+		s.endLease(id, l)
+	}
+	return nil
+}
+
+// AttachLease - A function that attaches an existing key to a lease, the key is
+// deleted when the lease ends
+func (s *KVServer) AttachLease(key string, id int64) (err error) {
+	defer s.countOperation("attach_lease", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
+	}
+	if !s.leases.alive(id, s.tenant) {
+		return fmt.Errorf("%w: %d", types.ErrLeaseNotFound, id)
+	}
+
+	err = s.setMetadata(key, types.MetadataLease, strconv.FormatInt(id, 10))
+	if err != nil {
+		return err
+	}
+
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	l, err := s.leases.live(id, s.tenant)
+	if err != nil {
+		return err
+	}
+	l.keys[key] = true
+	return nil
+}
+
+// expireLease - ends a lease whose TTL ran out
+func (s *KVServer) expireLease(id int64) {
+	if l, ok := s.leases.take(id); ok {
+		s.logger.Info("Lease expired", "lease", id)
+		s.endLease(id, l)
+	}
+}
+
+// endLease - deletes the keys still attached to an ended lease and releases its locks
+func (s *KVServer) endLease(id int64, l *lease) {
+	view := s.inTenant(l.tenant)
+	leaseID := strconv.FormatInt(id, 10)
+	for key := range l.keys {
+		// keys attached to another lease since are left alone
+		if attached, err := view.GetMetadata(key, types.MetadataLease); err != nil || attached != leaseID {
+			continue
+		}
+		if err := view.Delete(key); err != nil {
+			view.logger.Error("Error deleting key of lease", "key", key, "lease", id, "error", err)
+		}
+	}
+	for name := range l.locks {
+		if err := view.release(name, id); err != nil {
+			view.logger.Error("Error releasing lock of lease", "lock", name, "lease", id, "error", err)
+		}
+	}
+}
+
+// sweepLeasedKeys - deletes loaded keys attached to a lease, leases do not
+// survive a restart
+func (s *KVServer) sweepLeasedKeys() {
+	for _, storageKey := range s.Records.FindByMetadata(types.MetadataLease) {
+		tenant, key := types.SplitStorageKey(storageKey)
+		if err := s.inTenant(tenant).Delete(key); err != nil {
+			s.logger.Error("Error deleting leased key", "tenant", tenant, "key", key, "error", err)
+		}
+	}
+}
+
+// Lock - A function that acquires a named lock for a lease, returning a fencing
+// token that grows with every acquisition of any lock, acquiring a held lock
+// again returns its current token
+func (s *KVServer) Lock(name string, leaseID int64) (token int64, err error) {
+	defer s.countOperation("lock", &err)
+	// check if the name is empty
+	if err := checkKey("lock name", name); err != nil {
+		return 0, err
+	}
+
+	key := types.LockNamespace + name
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	if !s.leases.alive(leaseID, s.tenant) {
+		return 0, fmt.Errorf("%w: %d", types.ErrLeaseNotFound, leaseID)
+	}
+
+	// a lock is free when released or when its holder's lease is gone
+	record, ok := s.Records.Get(s.storageKey(key))
+	if ok && !record.IsDeleted() {
+		holder, current := lockHolder(record)
+		if holder == leaseID {
+			return current, nil
+		}
+		if holder != 0 && s.leases.alive(holder, s.tenant) {
+			return 0, fmt.Errorf("%w: %v", types.ErrLockHeld, name)
+		}
+	}
+
+	token, err = s.nextFence()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.set(key, []byte(fmt.Sprintf("%d:%d", leaseID, token)), types.ValueTypeLock); err != nil {
+		return 0, err
+	}
+
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	if l, err := s.leases.live(leaseID, s.tenant); err == nil {
+		l.locks[name] = true
+	}
+	return token, nil
+}
+
+// Unlock - A function that releases a lock, the token must be the one returned
+// by the acquisition that holds it
+func (s *KVServer) Unlock(name string, token int64) (err error) {
+	defer s.countOperation("unlock", &err)
+	// check if the name is empty
+	if err := checkKey("lock name", name); err != nil {
+		return err
+	}
+
+	key := types.LockNamespace + name
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return fmt.Errorf("%w: %v", types.ErrLockNotHeld, name)
+	}
+	holder, current := lockHolder(record)
+	if holder == 0 || current != token {
+		return fmt.Errorf("%w: %v", types.ErrLockNotHeld, name)
+	}
+
+	if _, err := s.set(key, []byte{}, types.ValueTypeLock); err != nil {
+		return err
+	}
+
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	if l, err := s.leases.live(holder, s.tenant); err == nil {
+		delete(l.locks, name)
+	}
+	return nil
+}
+
+// release - releases a lock if the lease still holds it
+func (s *KVServer) release(name string, leaseID int64) error {
+	key := types.LockNamespace + name
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return nil
+	}
+	if holder, _ := lockHolder(record); holder != leaseID {
+		return nil
+	}
+	_, err := s.set(key, []byte{}, types.ValueTypeLock)
+	return err
+}
+
+// nextFence - the next fencing token, persisted before it is handed out so
+// tokens keep growing after a restart
+func (s *KVServer) nextFence() (int64, error) {
+	s.leases.mu.Lock()
+	defer s.leases.mu.Unlock()
+	if err := s.saveCounter(fenceKey, s.leases.fence+1); err != nil {
+		return 0, fmt.Errorf("error saving fencing token: %w", err)
+	}
+	s.leases.fence++
+	return int64(s.leases.fence), nil
+}
+
+// lockHolder - the lease id and fencing token held in a lock record, 0 when
+// released
+func lockHolder(record KVRecord) (holder int64, token int64) {
+	value, err := record.GetValue(-1)
+	if err != nil || len(value) == 0 {
+		return 0, 0
+	}
+	parts := strings.SplitN(string(value), ":", 2)
+	if len(parts) != 2 {
+		return 0, 0
+	}
+	holder, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	token, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0
+	}
+	return holder, token
+}
+
+// checkWritable - checks the key of a write, lock records are only written
+// through Lock and Unlock, so a fencing token cannot be forged or a held lock
+// dropped
+func checkWritable(key string) error {
+	if err := checkKey("key", key); err != nil {
+		return err
+	}
+	if strings.HasPrefix(key, types.LockNamespace) {
+		return fmt.Errorf("%w: %v is reserved for locks", types.ErrTypeMismatch, key)
+	}
+	return nil
+}
+
+// checkMetadataKey - refuses client writes of the metadata keys tagging value
+// types and leases, so a sequence cannot be reset or a type check bypassed by
+// retagging its key
+func checkMetadataKey(metadataKey string) error {
+	if metadataKey == types.MetadataValueType || metadataKey == types.MetadataLease {
+		return fmt.Errorf("%w: metadata key %v is reserved", types.ErrInvalidKey, metadataKey)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package kvserver
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that locks exclude other leases and hand out growing fencing tokens
+func TestLockFencing(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	first, _ := svr.GrantLease(time.Minute)
+	second, _ := svr.GrantLease(time.Minute)
+
+	// Act
+	token, err := svr.Lock("jobs/nightly", first.ID)
+	_, heldErr := svr.Lock("jobs/nightly", second.ID)
+	wrongErr := svr.Unlock("jobs/nightly", token+1)
+	svr.Unlock("jobs/nightly", token)
+	next, nextErr := svr.Lock("jobs/nightly", second.ID)
+
+	// Assert
+	if err != nil || nextErr != nil {
+		t.Fatalf("lock failed: %v, %v", err, nextErr)
+	}
+	if !errors.Is(heldErr, types.ErrLockHeld) {
+		t.Errorf("held lock should be refused, got %v", heldErr)
+	}
+	if !errors.Is(wrongErr, types.ErrLockNotHeld) {
+		t.Errorf("unlock with a stale token should be refused, got %v", wrongErr)
+	}
+	if next <= token {
+		t.Errorf("fencing token should grow, got %d after %d", next, token)
+	}
+	if err := svr.Set(types.LockNamespace+"jobs/nightly", []byte("1")); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("lock records should not be set directly, got %v", err)
+	}
+	if err := svr.Delete(types.LockNamespace + "jobs/nightly"); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("lock records should not be deleted directly, got %v", err)
+	}
+	if _, err := svr.Rename(types.LockNamespace, "stolen/", types.CopyOptions{Prefix: true}); !errors.Is(err, types.ErrTypeMismatch) {
+		t.Errorf("lock records should not be renamed, got %v", err)
+	}
+}
+
+// Test that fencing tokens start at 1 and keep growing when a lock record is lost
+func TestLockFencingAfterDelete(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "mock"})
+	lease, _ := svr.GrantLease(time.Minute)
+	first, _ := svr.Lock("jobs/nightly", lease.ID)
+
+	// Act
+	svr.delete(types.LockNamespace + "jobs/nightly")
+	second, err := svr.Lock("jobs/nightly", lease.ID)
+
+	// Assert
+	if first != 1 || err != nil || second != 2 {
+		t.Errorf("tokens are %d and %d (%v) instead of 1 and 2", first, second, err)
+	}
+	saved, err := svr.persistence.Read(fenceKey)
+	if value, _ := saved.GetValue(-1); err != nil || string(value) != "2" {
+		t.Errorf("latest token saved as %s (%v)", value, err)
+	}
+}
+
+// Test that an ended lease deletes its keys and frees its locks
+func TestLeaseExpiry(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	lease, _ := svr.GrantLease(time.Minute)
+	other, _ := svr.GrantLease(time.Minute)
+	svr.Set("workers/a", []byte("alive"))
+	svr.AttachLease("workers/a", lease.ID)
+	token, _ := svr.Lock("leader", lease.ID)
+
+	// Act
+	svr.expireLease(lease.ID)
+
+	// Assert
+	if _, err := svr.Get("workers/a"); err == nil {
+		t.Errorf("attached key should be deleted with its lease")
+	}
+	if next, err := svr.Lock("leader", other.ID); err != nil || next <= token {
+		t.Errorf("lock should be free after its lease ended, got %d (%v)", next, err)
+	}
+	if _, err := svr.KeepAliveLease(lease.ID); !errors.Is(err, types.ErrLeaseNotFound) {
+		t.Errorf("ended lease should not be kept alive, got %v", err)
+	}
+	acme, _ := svr.ForTenant("acme")
+	if _, err := acme.GetLease(other.ID); !errors.Is(err, types.ErrLeaseNotFound) {
+		t.Errorf("leases should not be visible to other tenants, got %v", err)
+	}
+}
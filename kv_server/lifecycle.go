@@ -0,0 +1,114 @@
+package kvserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Lifecycle
+// the server moves Unknown -> Starting -> Running -> Stopping -> Stopped, a
+// failed start or stop ends in Error, from which the server can only be stopped,
+// a stopped server is not started again
+
+// allowedTransitions - the states each state may move to
+var allowedTransitions = map[ServerState][]ServerState{
+	types.ServerUnknownState: {types.ServerStarting},
+	types.ServerStarting:     {types.ServerRunning, types.ServerError},
+	types.ServerRunning:      {types.ServerStopping, types.ServerError},
+	types.ServerStopping:     {types.ServerStopped, types.ServerError},
+	types.ServerError:        {types.ServerStopping},
+}
+
+// lifecycle - the guarded state of the server and the loop running while it is up
+type lifecycle struct {
+	mu    sync.Mutex
+	state ServerState
+	// closed and replaced on every transition
+	changed chan struct{}
+
+	// stops the sync loop, done closes once it returned
+	cancel context.CancelFunc
+	done   chan struct{}
+	// interval - a new sync interval for the loop, holds the latest only
+	interval chan time.Duration
+	// started - when the server last started running
+	started time.Time
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{
+		state:    types.ServerUnknownState,
+		changed:  make(chan struct{}),
+		interval: make(chan time.Duration, 1),
+	}
+}
+
+// State - A function that returns the current state of the KV Server
+func (s *KVServer) State() ServerState {
+	s.lifecycle.mu.Lock()
+	defer s.lifecycle.mu.Unlock()
+	return s.lifecycle.state
+}
+
+// WaitForState - A function that blocks until the KV Server reaches one of the
+// given states or the context ends, returning the state reached
+func (s *KVServer) WaitForState(ctx context.Context, states ...ServerState) (ServerState, error) {
+	for {
+		s.lifecycle.mu.Lock()
+		current, changed := s.lifecycle.state, s.lifecycle.changed
+		s.lifecycle.mu.Unlock()
+
+		for _, state := range states {
+			if current == state {
+				return current, nil
+			}
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return current, ctx.Err()
+		}
+	}
+}
+
+// transition - moves the server to a new state, refusing moves the lifecycle
+// does not allow
+func (s *KVServer) transition(to ServerState) error {
+	s.lifecycle.mu.Lock()
+	defer s.lifecycle.mu.Unlock()
+
+	from := s.lifecycle.state
+	allowed := false
+	for _, state := range allowedTransitions[from] {
+		if state == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %v to %v", types.ErrInvalidTransition, from, to)
+	}
+
+	s.lifecycle.state = to
+	if to == types.ServerRunning {
+		s.lifecycle.started = time.Now()
+	}
+	close(s.lifecycle.changed)
+	s.lifecycle.changed = make(chan struct{})
+	s.logger.Info("KV Server changed state", "state", to)
+	return nil
+}
+
+// fail - moves the server to the error state and returns the error that caused it
+func (s *KVServer) fail(err error) error {
+	s.logger.Error("KV Server failed", "error", err)
+	if transitionErr := s.transition(types.ServerError); transitionErr != nil {
+		s.logger.Error("Error moving to the error state", "error", transitionErr)
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package kvserver
+
+import (
+	"github.com/aawadall/simple-kv/config"
+	"github.com/aawadall/simple-kv/health"
+)
+
+// Logging
+// the server logs as the server component and hands every component a child
+// of the same logger named after it, tenant views add the tenant to every
+// record, `log.level` and `log.levels` change on reload
+
+// SetLogger - A function that sets the logger of the server and its components,
+// before the server is started
+func (s *KVServer) SetLogger(logger *health.Logger) {
+	s.logger = logger.Component("server")
+	s.config.SetLogger(logger.Component("config"))
+	s.rest.SetLogger(logger.Component("rest"))
+	s.grpc.SetLogger(logger.Component("grpc"))
+	s.persistence.SetLogger(logger.Component("persistence"))
+	s.flags.SetLogger(logger.Component("features"))
+	if s.cdc != nil {
+		s.cdc.SetLogger(logger.Component("cdc"))
+	}
+
+	s.hooks.mu.Lock()
+	defer s.hooks.mu.Unlock()
+	for _, hook := range s.hooks.hooks {
+		if w, ok := hook.(*webhook); ok {
+			w.logger = logger.Component("webhooks").With("endpoint", w.endpoint)
+		}
+	}
+}
+
+// setLogLevels - applies `log.level` and `log.levels` to every component
+func (s *KVServer) setLogLevels(settings *config.Config) {
+	options := settings.LogOptions()
+	s.logger.SetLevels(options.Level, options.Levels)
+}
@@ -0,0 +1,74 @@
+package kvserver
+
+import (
+	"context"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Memory limit
+// `cache.memory_limit` (bytes) caps the memory held by records, cold records are
+// evicted to the persistence driver and faulted back in on read,
+// `cache.eviction_policy` picks which records are cold, lru (default) or lfu,
+// at start records are read one at a time so only the key index and the
+// records within the limit are ever held
+
+// loadMemoryConfig - reads `cache.memory_limit` and `cache.eviction_policy`
+func (s *KVServer) loadMemoryConfig() {
+	settings := s.config.Settings().Cache
+	if settings.MemoryLimit <= 0 {
+		return
+	}
+
+	// evicted records must be readable again
+	if !s.persistence.Readable() {
+		s.logger.Warn("Persistence driver cannot read records back, ignoring memory_limit")
+		return
+	}
+
+	err := s.Records.SetMemoryLimit(types.MemoryLimit{
+		Bytes:  settings.MemoryLimit,
+		Policy: settings.EvictionPolicy,
+		Load:   s.persistence.Read,
+		Store:  s.persistence.Write,
+	})
+	if err != nil {
+		s.logger.Error("Error setting memory limit, keeping every record in memory", "error", err)
+	}
+}
+
+// loadIndex - reads the records on disk one at a time, counting their usage,
+// the container keeps those within the limit and the keys of the others, until
+// the context ends
+func (s *KVServer) loadIndex(ctx context.Context) (int, error) {
+	keys, err := s.persistence.Keys()
+	if err != nil {
+		return 0, err
+	}
+
+	s.usage.reset(nil)
+	loaded := 0
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return loaded, err
+		}
+		record, err := s.persistence.Read(key)
+		if err != nil {
+			return loaded, err
+		}
+
+		// revisions and fencing tokens continue from the previous run
+		records, counters := takeCounters([]KVRecord{record})
+		if len(records) == 0 {
+			s.resumeCounters(counters)
+			continue
+		}
+
+		if err := s.Records.BulkLoad(records); err != nil {
+			return loaded, err
+		}
+		s.usage.add(record.TenantName(), footprint(record))
+		loaded++
+	}
+	return loaded, nil
+}
@@ -0,0 +1,119 @@
+package kvserver
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that records past the memory limit are evicted and faulted back in
+func TestMemoryLimitEviction(t *testing.T) {
+	defer quiet()()
+	// Arrange, each record is about 27 bytes
+	svr := NewKVServer(map[string]string{"driver": "mock", "memory_limit": "60"})
+	for i := 0; i < 5; i++ {
+		svr.Set(fmt.Sprintf("k%d", i), []byte("0123456789"))
+	}
+
+	// Act
+	value, err := svr.Get("k0")
+	keys, _ := svr.Find("k")
+
+	// Assert
+	if err != nil || string(value.([]byte)) != "0123456789" {
+		t.Fatalf("evicted record should fault back in, got %s (%v)", value, err)
+	}
+	stats, _ := svr.Records.CacheStats()
+	if stats.ResidentKeys != 2 || stats.EvictedKeys != 3 || stats.ResidentBytes > 60 {
+		t.Errorf("cache holds %+v", stats)
+	}
+	if stats.Misses != 1 || stats.Evictions != 4 {
+		t.Errorf("expected one miss and four evictions, got %+v", stats)
+	}
+	sort.Strings(keys)
+	if len(keys) != 5 || keys[0] != "k0" || keys[4] != "k4" {
+		t.Errorf("find should include evicted keys, got %v", keys)
+	}
+}
+
+// Test that the lfu policy keeps frequently read records resident
+func TestMemoryLimitLFU(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "mock", "memory_limit": "60", "eviction_policy": "lfu"})
+	svr.Set("hot", []byte("0123456789"))
+	for i := 0; i < 3; i++ {
+		svr.Get("hot")
+	}
+
+	// Act
+	for i := 0; i < 4; i++ {
+		svr.Set(fmt.Sprintf("k%d", i), []byte("0123456789"))
+	}
+
+	// Assert
+	if _, ok := svr.Records.Records["hot"]; !ok {
+		t.Errorf("frequently read record was evicted")
+	}
+	if stats, _ := svr.Records.CacheStats(); stats.Misses != 0 || stats.Policy != "lfu" {
+		t.Errorf("cache holds %+v", stats)
+	}
+}
+
+// Test that a memory limited server only keeps the records within the limit
+// when it starts
+func TestMemoryLimitStart(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	dir, err := ioutil.TempDir("", "memory")
+	if err != nil {
+		t.Fatalf("temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	configuration := map[string]string{
+		"driver":       "sqlite",
+		"db_location":  filepath.Join(dir, "kv.sqlite"),
+		"rest_port":    "0",
+		"grpc_port":    "0",
+		"memory_limit": "60",
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	svr := NewKVServer(configuration)
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		svr.Set(fmt.Sprintf("k%d", i), []byte("0123456789"))
+	}
+	if err := svr.Stop(ctx); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	// Act
+	svr = NewKVServer(configuration)
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("restart failed: %v", err)
+	}
+	defer svr.Stop(ctx)
+
+	// Assert
+	stats, _ := svr.Records.CacheStats()
+	if stats.ResidentBytes > 60 || stats.ResidentKeys+stats.EvictedKeys != 5 {
+		t.Errorf("cache holds %+v", stats)
+	}
+	if usage := svr.usage.get(types.DefaultTenant); usage.Keys != 5 {
+		t.Errorf("usage counts %d keys instead of 5", usage.Keys)
+	}
+	if value, err := svr.Get("k0"); err != nil || string(value.([]byte)) != "0123456789" {
+		t.Errorf("evicted record should fault back in, got %s (%v)", value, err)
+	}
+}
@@ -0,0 +1,94 @@
+package kvserver
+
+import (
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Metrics
+// the REST API serves every metric on /metrics: request counts and latencies of
+// both APIs, operations of the server, committed changes, the size of the
+// records, persistence latency and failures, queue depths and Go runtime stats,
+// sizes and depths are read when scraped
+
+// serverMetrics - the metrics the server keeps, shared by tenant views
+type serverMetrics struct {
+	registry *health.Registry
+
+	operations  health.Counter
+	changes     health.Counter
+	persistence health.Histogram
+	failures    health.Counter
+}
+
+// loadMetrics - registers the metrics and hands the registry to the APIs
+func (s *KVServer) loadMetrics() {
+	registry := health.NewRegistry()
+	s.metrics = &serverMetrics{
+		registry:    registry,
+		operations:  registry.Counter("kv_operations_total", "Operations of the server by operation and result.", "operation", "result"),
+		changes:     registry.Counter("kv_changes_total", "Committed changes by operation.", "operation"),
+		persistence: registry.Histogram("kv_persistence_duration_seconds", "Latency of persistence operations by operation.", health.DefaultBuckets, "operation"),
+		failures:    registry.Counter("kv_persistence_failures_total", "Failed persistence operations by operation.", "operation"),
+	}
+
+	records := registry.Gauge("kv_records", "Records held, evicted ones included.")
+	bytes := registry.Gauge("kv_record_bytes", "Bytes of the records of every tenant, by kind: value or history.", "kind")
+	resident := registry.Gauge("kv_cache_resident_bytes", "Bytes of the records resident in memory, when memory is limited.")
+	queues := registry.Gauge("kv_queue_depth", "Items waiting in a queue: cdc, hooks or watch.", "queue")
+	watchers := registry.Gauge("kv_watchers", "Open watch subscriptions.")
+	dropped := registry.Gauge("kv_cdc_dropped_events", "Change events dropped for CDC sinks lagging past cdc.max_lag.")
+	up := registry.Gauge("kv_server_running", "1 while the server is running.")
+	registry.OnCollect(func() {
+		records.Set(float64(s.Records.Len()))
+		usage := types.TenantUsage{}
+		for _, tenant := range s.usage.all() {
+			usage = usage.Add(tenant)
+		}
+		bytes.Set(float64(usage.ValueBytes), "value")
+		bytes.Set(float64(usage.HistoryBytes), "history")
+		if stats, ok := s.Records.CacheStats(); ok {
+			resident.Set(float64(stats.ResidentBytes))
+		}
+
+		open, buffered := s.watches.depth()
+		queues.Set(float64(s.cdc.QueueDepth()), "cdc")
+		dropped.Set(float64(s.cdc.Dropped()))
+		queues.Set(float64(s.hooks.depth()), "hooks")
+		queues.Set(float64(buffered), "watch")
+		watchers.Set(float64(open))
+
+		running := 0.0
+		if s.State() == types.ServerRunning {
+			running = 1
+		}
+		up.Set(running)
+	})
+	health.RegisterRuntime(registry)
+
+	s.persistence.SetObserver(s.observePersistence)
+	s.watches.observe(func(event types.ChangeEvent) {
+		s.metrics.changes.Inc(event.Operation)
+	})
+	s.rest.SetMetrics(registry)
+	s.grpc.SetMetrics(registry)
+}
+
+// countOperation - counts an operation of the server once it returned
+func (s *KVServer) countOperation(operation string, err *error) {
+	result := "ok"
+	if *err != nil {
+		result = "error"
+	}
+	s.metrics.operations.Inc(operation, result)
+}
+
+// observePersistence - records the latency and failures of the persistence driver
+func (s *KVServer) observePersistence(operation string, duration time.Duration, err error) {
+	s.metrics.persistence.Observe(duration.Seconds(), operation)
+	if err != nil {
+		s.metrics.failures.Inc(operation)
+	}
+}
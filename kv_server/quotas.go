@@ -0,0 +1,160 @@
+package kvserver
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// usageTracker - per tenant usage, kept up to date by every committed change
+type usageTracker struct {
+	mu      sync.Mutex
+	tenants map[string]types.TenantUsage
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{tenants: make(map[string]types.TenantUsage)}
+}
+
+// add - applies a usage change to a tenant
+func (u *usageTracker) add(tenant string, delta types.TenantUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.tenants[tenant] = u.tenants[tenant].Add(delta)
+}
+
+// get - the usage of a tenant
+func (u *usageTracker) get(tenant string) types.TenantUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.tenants[tenant]
+}
+
+// all - the usage of every tenant seen
+func (u *usageTracker) all() map[string]types.TenantUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	usage := make(map[string]types.TenantUsage, len(u.tenants))
+	for tenant, tenantUsage := range u.tenants {
+		usage[tenant] = tenantUsage
+	}
+	return usage
+}
+
+// reset - recounts usage from loaded records
+func (u *usageTracker) reset(records []KVRecord) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.tenants = make(map[string]types.TenantUsage)
+	for _, record := range records {
+		tenant := record.TenantName()
+		u.tenants[tenant] = u.tenants[tenant].Add(footprint(record))
+	}
+}
+
+// Usage - A function that reports usage and limits per tenant, a tenant view
+// only reports its own tenant
+func (s *KVServer) Usage() (map[string]types.UsageReport, error) {
+	report := make(map[string]types.UsageReport)
+	if s.tenant != types.DefaultTenant {
+		report[s.tenant] = types.UsageReport{Usage: s.usage.get(s.tenant), Quota: s.quotaFor(s.tenant)}
+		return report, nil
+	}
+
+	for tenant, usage := range s.usage.all() {
+		report[tenant] = types.UsageReport{Usage: usage, Quota: s.quotaFor(tenant)}
+	}
+	return report, nil
+}
+
+// quotaFor - the limits of a tenant, read from the settings in effect so a
+// reload applies to the next write
+func (s *KVServer) quotaFor(tenant string) types.TenantQuota {
+	settings := s.config.Settings().Quota
+	if quota, ok := settings.Tenants[tenant]; ok {
+		return quota
+	}
+	return settings.Defaults
+}
+
+// checkQuota - refuses a write that would take this server's tenant past its
+// limits, limits are checked against committed usage, so concurrent writes to
+// different keys may each pass
+func (s *KVServer) checkQuota(before types.TenantUsage, after types.TenantUsage, valueSize int) error {
+	quota := s.quotaFor(s.tenant)
+	if quota.MaxValueSize > 0 && int64(valueSize) > quota.MaxValueSize {
+		return fmt.Errorf("%w: value of %d bytes is larger than the tenant limit of %d", types.ErrValueTooLarge, valueSize, quota.MaxValueSize)
+	}
+
+	delta := after.Sub(before)
+	usage := s.usage.get(s.tenant).Add(delta)
+	switch {
+	case delta.Keys > 0 && quota.MaxKeys > 0 && usage.Keys > quota.MaxKeys:
+		return fmt.Errorf("%w: tenant %v is limited to %d keys", types.ErrQuotaExceeded, s.tenant, quota.MaxKeys)
+	case delta.ValueBytes > 0 && quota.MaxValueBytes > 0 && usage.ValueBytes > quota.MaxValueBytes:
+		return fmt.Errorf("%w: tenant %v is limited to %d value bytes", types.ErrQuotaExceeded, s.tenant, quota.MaxValueBytes)
+	case delta.HistoryBytes > 0 && quota.MaxHistoryBytes > 0 && usage.HistoryBytes > quota.MaxHistoryBytes:
+		return fmt.Errorf("%w: tenant %v is limited to %d history bytes", types.ErrQuotaExceeded, s.tenant, quota.MaxHistoryBytes)
+	}
+	return nil
+}
+
+// checkSetQuota - checks a new value of the given size for a record, exists is
+// false for new keys
+func (s *KVServer) checkSetQuota(record KVRecord, exists bool, size int64) error {
+	if !exists {
+		return s.checkQuota(types.TenantUsage{}, types.TenantUsage{Keys: 1, ValueBytes: size}, int(size))
+	}
+
+	// the current value, or the whole history of a soft deleted key, becomes history
+	total, _ := record.Value.Size()
+	after := types.TenantUsage{
+		Keys:         1,
+		ValueBytes:   size + metadataBytes(record.Metadata.Copy()),
+		HistoryBytes: total,
+	}
+	return s.checkQuota(footprint(record), after, int(size))
+}
+
+// checkMetadataQuota - checks a new metadata entry for a record
+func (s *KVServer) checkMetadataQuota(record KVRecord, metadataKey string, metadataValue string) error {
+	metadata := record.Metadata.Copy()
+	before := footprint(record)
+	after := before
+	after.ValueBytes += metadataBytes(map[string]string{metadataKey: metadataValue})
+	if previous, ok := metadata[metadataKey]; ok {
+		after.ValueBytes -= metadataBytes(map[string]string{metadataKey: previous})
+	}
+	return s.checkQuota(before, after, len(metadataValue))
+}
+
+// footprint - the usage a record accounts for, a chunked value counts its whole
+// length while its history counts the manifests
+func footprint(record KVRecord) types.TenantUsage {
+	total, latest := record.Value.Size()
+	if record.IsDeleted() {
+		return types.TenantUsage{HistoryBytes: total}
+	}
+
+	size := latest
+	if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType == types.ValueTypeChunked {
+		if value, err := record.GetValue(-1); err == nil {
+			size = valueSize(value, valueType)
+		}
+	}
+	return types.TenantUsage{
+		Keys:         1,
+		ValueBytes:   size + metadataBytes(record.Metadata.Copy()),
+		HistoryBytes: total - latest,
+	}
+}
+
+// metadataBytes - bytes held by metadata entries
+func metadataBytes(metadata map[string]string) int64 {
+	var size int64
+	for key, value := range metadata {
+		size += int64(len(key) + len(value))
+	}
+	return size
+}
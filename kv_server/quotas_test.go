@@ -0,0 +1,60 @@
+package kvserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that writes past a tenant's quota are refused
+func TestQuotaLimits(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "quota_max_value_size": "8", "quota.acme.max_keys": "2"})
+	acme, _ := svr.ForTenant("acme")
+	acme.Set("a", []byte("1"))
+	acme.Set("b", []byte("2"))
+
+	// Act
+	err := acme.Set("c", []byte("3"))
+
+	// Assert
+	if !errors.Is(err, types.ErrQuotaExceeded) {
+		t.Errorf("third key should exceed the quota, got %v", err)
+	}
+	if err := acme.Set("a", []byte("11")); err != nil {
+		t.Errorf("updating an existing key should be allowed: %v", err)
+	}
+	if err := svr.Set("c", []byte("3")); err != nil {
+		t.Errorf("default tenant should not be limited to 2 keys: %v", err)
+	}
+	if err := svr.Set("d", []byte("too large")); !errors.Is(err, types.ErrValueTooLarge) {
+		t.Errorf("value over the size limit should be refused, got %v", err)
+	}
+	if err := svr.SetMetadata("c", "owner", "platform team"); !errors.Is(err, types.ErrValueTooLarge) {
+		t.Errorf("metadata over the size limit should be refused, got %v", err)
+	}
+}
+
+// Test that usage follows sets, metadata and deletes
+func TestUsageAccounting(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.Set("a", []byte("abc"))
+	svr.Set("a", []byte("de"))
+	svr.SetMetadata("a", "k", "v")
+	svr.Set("b", []byte("xyz"))
+
+	// Act
+	svr.Delete("b")
+	report, _ := svr.Usage()
+
+	// Assert
+	// "de" plus the k=v and Version=N metadata entries, "abc" is history
+	want := types.TenantUsage{Keys: 1, ValueBytes: 12, HistoryBytes: 3}
+	if got := report[types.DefaultTenant].Usage; got != want {
+		t.Errorf("usage is %+v, want %+v", got, want)
+	}
+}
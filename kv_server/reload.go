@@ -0,0 +1,61 @@
+package kvserver
+
+import (
+	"github.com/aawadall/simple-kv/api"
+	"github.com/aawadall/simple-kv/config"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Configuration reload
+// reloadable settings take effect on reload: the sync interval, the log levels,
+// rate limits and the soft delete flag through the subscriber below, quotas and
+// the soft delete grace period are read from the settings in effect when used,
+// other settings wait for a restart
+
+// GetConfig - A function that returns the settings in effect, secrets redacted,
+// and those waiting for a restart
+func (s *KVServer) GetConfig() (types.ConfigReport, error) {
+	report := types.ConfigReport{Pending: s.config.Pending()}
+	for _, setting := range s.config.Settings().Settings() {
+		report.Settings = append(report.Settings, types.ConfigSetting{
+			Name:   setting.Name,
+			Value:  setting.Value,
+			Source: string(setting.Source),
+			Secret: setting.Secret,
+		})
+	}
+	return report, nil
+}
+
+// loadReloadableConfig - applies the reloadable settings and subscribes to reloads
+func (s *KVServer) loadReloadableConfig() {
+	settings := s.config.Settings()
+	s.setLogLevels(settings)
+	s.limiter = api.NewRateLimiter(settings.RateLimit.RequestsPerSecond, settings.RateLimit.Burst)
+	s.rest.SetRateLimiter(s.limiter)
+	s.grpc.SetRateLimiter(s.limiter)
+
+	s.config.Subscribe(s.applyConfig)
+}
+
+// applyConfig - applies the reloadable settings a reload changed
+func (s *KVServer) applyConfig(change config.Change) {
+	settings := change.New
+	if change.Changed("log.level") || change.Changed("log.levels") {
+		s.setLogLevels(settings)
+	}
+	if change.Changed("rate_limit") {
+		s.limiter.SetLimit(settings.RateLimit.RequestsPerSecond, settings.RateLimit.Burst)
+	}
+	if change.Changed("soft_delete.enabled") {
+		s.defineFeatureFlags(settings)
+	}
+	if change.Changed("server.sync_interval") {
+		// the loop takes the latest interval, replacing one not taken yet
+		select {
+		case <-s.lifecycle.interval:
+		default:
+		}
+		s.lifecycle.interval <- settings.Server.SyncInterval
+	}
+}
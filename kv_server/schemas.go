@@ -0,0 +1,123 @@
+package kvserver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// SetSchema - registers a schema for a key prefix as a new schema version,
+// schemas live in the KV under the reserved schema namespace
+func (s *KVServer) SetSchema(prefix string, schema []byte) (err error) {
+	defer s.countOperation("set_schema", &err)
+	// check the prefix is valid
+	if err := checkKey("prefix", prefix); err != nil {
+		return err
+	}
+
+	return s.SetDocument(types.SchemaNamespace+prefix, schema)
+}
+
+// GetSchema - gets a version of the schema for a key prefix, -1 for the latest
+func (s *KVServer) GetSchema(prefix string, version int) (schema []byte, err error) {
+	defer s.countOperation("get_schema", &err)
+	// check the prefix is valid
+	if err := checkKey("prefix", prefix); err != nil {
+		return nil, err
+	}
+
+	record, ok := s.Records.Get(s.storageKey(types.SchemaNamespace + prefix))
+	if !ok || record.IsDeleted() {
+		return nil, fmt.Errorf("schema not found")
+	}
+
+	values, _, tombstones := record.Value.History()
+	if version < 0 {
+		version = len(values) - 1
+	}
+	if version >= len(values) || tombstones[version] {
+		return nil, fmt.Errorf("schema version %d not found", version)
+	}
+	return values[version], nil
+}
+
+// DeleteSchema - stops validating a key prefix
+func (s *KVServer) DeleteSchema(prefix string) (err error) {
+	defer s.countOperation("delete_schema", &err)
+	// check the prefix is valid
+	if err := checkKey("prefix", prefix); err != nil {
+		return err
+	}
+
+	return s.Delete(types.SchemaNamespace + prefix)
+}
+
+// validate - checks a value against the schema registered for the longest
+// matching prefix of its key, schemas themselves must parse
+func (s *KVServer) validate(key string, value []byte) error {
+	if strings.HasPrefix(key, types.SchemaNamespace) {
+		_, err := types.ParseSchema(value)
+		return err
+	}
+	if strings.HasPrefix(key, types.FlagNamespace) {
+		return validateFlag(key, value)
+	}
+
+	schema, record, prefix, err := s.schemaFor(key)
+	if err != nil || schema == nil {
+		return err
+	}
+
+	violations := schema.Validate(value)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &types.ValidationError{
+		Key:           key,
+		Prefix:        prefix,
+		SchemaVersion: record.GetVersion(),
+		Violations:    violations,
+	}
+}
+
+// validateValue - validates a value about to be set, chunked values are too
+// large to validate and are refused under a schema
+func (s *KVServer) validateValue(key string, value []byte, valueType string) error {
+	if valueType != types.ValueTypeChunked {
+		return s.validate(key, value)
+	}
+
+	schema, record, prefix, err := s.schemaFor(key)
+	if err != nil || schema == nil {
+		return err
+	}
+	return &types.ValidationError{
+		Key:           key,
+		Prefix:        prefix,
+		SchemaVersion: record.GetVersion(),
+		Violations:    []types.SchemaViolation{{Message: "values stored as chunks cannot be validated"}},
+	}
+}
+
+// schemaFor - the schema of the longest matching prefix of a key, nil when no
+// schema applies
+func (s *KVServer) schemaFor(key string) (*types.Schema, KVRecord, string, error) {
+	for end := len(key); end > 0; end-- {
+		record, ok := s.Records.Get(s.storageKey(types.SchemaNamespace + key[:end]))
+		if !ok || record.IsDeleted() {
+			continue
+		}
+
+		raw, err := record.GetValue(-1)
+		if err != nil {
+			return nil, record, "", err
+		}
+		schema, err := types.ParseSchema(raw)
+		if err != nil {
+			return nil, record, "", err
+		}
+		return schema, record, key[:end], nil
+	}
+	return nil, KVRecord{}, "", nil
+}
@@ -0,0 +1,67 @@
+package kvserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that writes under a prefix are validated against its latest schema
+func TestSchemaValidation(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.SetSchema("orders/", []byte(`{"type":"object","required":["id","qty"],"properties":{"id":{"type":"string"},"qty":{"type":"integer","minimum":1}}}`))
+	svr.SetSchema("counts/", []byte(`"integer"`))
+
+	// Act
+	err := svr.Set("orders/1", []byte(`{"id":7,"qty":0}`))
+
+	// Assert
+	var validationErr *types.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("invalid order should fail validation, got %v", err)
+	}
+	if len(validationErr.Violations) != 2 || validationErr.Violations[0].Path != "/id" || validationErr.Violations[1].Path != "/qty" {
+		t.Errorf("violations are %+v", validationErr.Violations)
+	}
+	if _, err := svr.Get("orders/1"); err == nil {
+		t.Errorf("rejected value should not be stored")
+	}
+	if err := svr.Set("orders/2", []byte(`{"id":"a","qty":2}`)); err != nil {
+		t.Errorf("valid order was rejected: %v", err)
+	}
+	if _, err := svr.Increment("counts/a", 1, types.CounterOptions{}); err != nil {
+		t.Errorf("counter was rejected: %v", err)
+	}
+	if err := svr.Set("counts/b", []byte("many")); !errors.Is(err, types.ErrSchemaViolation) {
+		t.Errorf("text under an integer schema should fail validation, got %v", err)
+	}
+	if err := svr.Set("other", []byte("free")); err != nil {
+		t.Errorf("keys without a schema should not be validated: %v", err)
+	}
+}
+
+// Test that schemas must parse and keep their versions
+func TestSchemaVersions(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+
+	// Act
+	svr.SetSchema("users/", []byte(`"string"`))
+	svr.SetSchema("users/", []byte(`"integer"`))
+	err := svr.SetSchema("users/", []byte(`{"type":"text"}`))
+
+	// Assert
+	if !errors.Is(err, types.ErrInvalidSchema) {
+		t.Errorf("unknown type should be an invalid schema, got %v", err)
+	}
+	if schema, err := svr.GetSchema("users/", 0); err != nil || string(schema) != `"string"` {
+		t.Errorf("schema version 0 is %s (%v)", schema, err)
+	}
+	if schema, err := svr.GetSchema("users/", -1); err != nil || string(schema) != `"integer"` {
+		t.Errorf("latest schema is %s (%v)", schema, err)
+	}
+}
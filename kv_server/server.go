@@ -1,14 +1,16 @@
 package kvserver
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"strconv"
-	"sync"
 	"time"
 
 	"github.com/aawadall/simple-kv/api"
+	"github.com/aawadall/simple-kv/cdc"
 	"github.com/aawadall/simple-kv/config"
+	"github.com/aawadall/simple-kv/features"
+	"github.com/aawadall/simple-kv/health"
 	"github.com/aawadall/simple-kv/persistence"
 	"github.com/aawadall/simple-kv/types"
 )
@@ -22,174 +24,254 @@ type KVServer struct {
 	// TODO - Add fields here
 	//Records map[string]KVRecord
 	Records     *types.Container
-	logger      *log.Logger
-	state       ServerState
+	logger      *health.Logger
+	lifecycle   *lifecycle
 	config      *config.ConfigurationManager
 	rest        *api.RestApi
+	grpc        *api.GrpcApi
 	persistence *persistence.PersistenceManager
+
+	// tenant the server operates on, views from ForTenant share everything else
+	tenant string
+
+	// large values
+	chunkSize    int
+	maxValueSize int64
+
+	// per tenant usage, limits are read from the settings
+	usage *usageTracker
+	// requests allowed per tenant over both APIs
+	limiter *api.RateLimiter
+	// metrics served by the REST API
+	metrics *serverMetrics
+
+	// leases and the keys and locks attached to them
+	leases *leaseManager
+
+	// plugins invoked around writes
+	hooks *hookRegistry
+
+	// feature flags, stored in the KV
+	flags *features.Manager
+
+	// serializes read-modify-write operations per key
+	locks *keyLocks
+
+	// change subscriptions
+	watches *watchHub
+	cdc     *cdc.Manager
 }
 
-// NewKVServer - A function that creates a new KV Server
+// NewKVServer - A function that creates a new KV Server, configured by
+// settings by dotted or flat name over the environment and the defaults
 func NewKVServer(configuration map[string]string) *KVServer {
+	return newKVServer(config.NewConfigurationManager(configuration))
+}
+
+// NewKVServerFrom - A function that creates a new KV Server from loaded settings
+func NewKVServerFrom(settings *config.Config) *KVServer {
+	return newKVServer(config.NewConfigurationManagerFrom(settings))
+}
+
+// newKVServer - creates a server over a configuration, problems found in the
+// configuration are reported by Start
+func newKVServer(configuration *config.ConfigurationManager) *KVServer {
 	server := &KVServer{
 		//Records: make(map[string]KVRecord),
-		Records: types.NewContainer(),
-		logger:  log.New(log.Writer(), "KVServer", log.LstdFlags),
-		config:  config.NewConfigurationManager(configuration),
-		state:   types.ServerUnknownState,
+		Records:   types.NewContainer(),
+		logger:    health.Default().Component("server"),
+		config:    configuration,
+		lifecycle: newLifecycle(),
+		tenant:    types.DefaultTenant,
+		locks:     &keyLocks{},
+		usage:     newUsageTracker(),
+		leases:    newLeaseManager(),
+		hooks:     newHookRegistry(),
+		watches:   newWatchHub(),
 	}
+	server.flags = features.NewManager(server)
 	server.rest = api.NewRestApi(server)
+	server.grpc = api.NewGrpcApi(server)
+	server.loadListenConfig()
 	server.persistence = persistence.NewPersistenceManager(server.config.GetConfig())
+	server.loadHealthChecks()
+	server.loadMetrics()
+	server.loadChunkConfig()
+	server.loadMemoryConfig()
+	server.loadWebhookConfig()
+	server.loadFeatureFlags()
+	server.loadReloadableConfig()
+
+	// revisions are reserved on disk before watchers see a change
+	server.watches.observe(server.saveRevision)
+
+	// change data capture sees every change, continuing its revision numbering
+	server.cdc = cdc.NewManager(server.config.GetConfig())
+	if server.cdc.Enabled() {
+		server.watches.resumeFrom(server.cdc.LastRevision())
+		server.watches.observe(server.cdc.Publish)
+	}
+	server.watches.observe(server.hooks.enqueue)
 
 	return server
 }
 
-// Start - A function that starts the KV Server
-func (s *KVServer) Start() {
-	// TODO - Start the KV Server here
-	wg := &sync.WaitGroup{}
+// Start - A function that starts the KV Server, returning once the REST and gRPC
+// APIs listen, the context bounds loading the records, read one at a time under
+// a memory limit, and is checked again before the APIs start
+func (s *KVServer) Start(ctx context.Context) error {
+	if err := s.transition(types.ServerStarting); err != nil {
+		return err
+	}
 
-	wg.Add(1)
-	// Event loop in a goroutine
-	go func() {
-		s.state = types.ServerStarting
+	// every problem of the configuration at once
+	if err := s.config.Validate(); err != nil {
+		return s.fail(err)
+	}
+	syncInterval := s.config.Settings().Server.SyncInterval
 
-		// Load the data from the persistence layer
-		records, err := s.persistence.Load()
+	// Load the data from the persistence layer
+	loaded, err := s.load(ctx)
+	if err != nil {
+		return s.fail(fmt.Errorf("error loading data from persistence layer: %w", err))
+	}
 
-		if err != nil {
-			s.logger.Printf("Error loading data from persistence layer: %v", err)
-			s.state = types.ServerError
-			wg.Done()
-			return
-		}
+	s.logger.Info("Loaded records from persistence layer", "records", loaded)
+	s.sweepLeasedKeys()
+	if err := s.flags.Start(); err != nil {
+		return s.fail(fmt.Errorf("error reading feature flags: %w", err))
+	}
 
-		// Add the records to the container
-		s.logger.Printf("Loading %d records from persistence layer", len(records))
-		err = s.Records.BulkLoad(records, s.logger)
+	if err := ctx.Err(); err != nil {
+		s.flags.Stop()
+		return s.fail(err)
+	}
 
-		if err != nil {
-			s.logger.Printf("Error loading data from persistence layer: %v", err)
-			s.state = types.ServerError
-			wg.Done()
-			return
-		}
+	// Start the REST and gRPC APIs
+	if err := s.rest.Start(); err != nil {
+		s.flags.Stop()
+		return s.fail(fmt.Errorf("error starting REST API: %w", err))
+	}
+	if err := s.grpc.Serve(); err != nil {
+		s.rest.Stop(ctx)
+		s.flags.Stop()
+		return s.fail(fmt.Errorf("error starting gRPC API: %w", err))
+	}
+	s.cdc.Start()
 
-		s.logger.Printf("Loaded %d records from persistence layer", len(records))
+	// sync in the background until stopped
+	loop, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.lifecycle.mu.Lock()
+	s.lifecycle.cancel, s.lifecycle.done = cancel, done
+	s.lifecycle.mu.Unlock()
+	go s.run(loop, syncInterval, done)
+	s.config.Start()
 
-		// DEBUG - inspect records
-		for i, record := range s.Records.GetAll(s.logger) {
-			s.logger.Printf("[%d] Record Key: %v", i, record.Key)
-			// print values
-			for k, v := range record.Value.Value {
-				s.logger.Printf("[%d] Record Value Key: %v, Value: %v", i, k, v)
-			}
-			// print metadata
-			for k, v := range record.Metadata.GetAll() {
-				s.logger.Printf("[%d] Record Metadata Key: %v, Value: %v", i, k, v)
-			}
-		}
-		// Start the REST API
-		s.rest.Start()
+	return s.transition(types.ServerRunning)
+}
 
-		// TODO - Start the KV Server here
+// load - reads the records from the persistence layer into the container,
+// returning how many were read
+func (s *KVServer) load(ctx context.Context) (int, error) {
+	if _, limited := s.Records.CacheStats(); limited {
+		return s.loadIndex(ctx)
+	}
 
-		s.state = types.ServerRunning
-		iSyncInterval, err := s.config.Get("sync_interval")
-		if err != nil {
-			iSyncInterval = "10"
-		}
+	records, err := s.persistence.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	// revisions and fencing tokens continue from the previous run
+	records, counters := takeCounters(records)
+	s.resumeCounters(counters)
 
-		sSyncInterval := fmt.Sprintf("%s", iSyncInterval)
+	// Add the records to the container
+	err = s.Records.BulkLoad(records)
+	s.usage.reset(records)
+	return len(records), err
+}
 
-		// get int value from sSyncInterval
-		syncInterval, err := strconv.Atoi(sSyncInterval)
+// run - purges expired tombstones and syncs records to the persistence layer
+// every interval until the context ends
+func (s *KVServer) run(ctx context.Context, interval time.Duration, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer func() { ticker.Stop() }()
 
-		if err != nil {
-			s.logger.Printf("Error converting sync_interval to int: %v", err)
-			s.state = types.ServerError
-			wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case interval := <-s.lifecycle.interval:
+			ticker.Stop()
+			ticker = time.NewTicker(interval)
+		case <-ticker.C:
+			s.purgeDeleted()
+			if err := s.persistence.Sync(s.Records.GetAll(), s.retained); err != nil {
+				s.logger.Error("Error syncing records", "error", err)
+			}
 		}
+	}
+}
 
-		for s.state != types.ServerStopped &&
-			s.state != types.ServerError {
-			// TODO - Event loop code here
-			time.Sleep(time.Duration(syncInterval) * time.Second)
-
-			go func() {
-				s.persistence.Sync(s.Records.GetAll(s.logger))
-			}()
-			// translate state to string
-			//state := stateToString(s)
-			// Write to log
-
-			//s.logger.Printf("KV Server is %s", state)
-		}
-		wg.Done()
-	}()
-	wg.Wait()
+// loadListenConfig - reads `server.rest_port` and `server.grpc_port`
+func (s *KVServer) loadListenConfig() {
+	settings := s.config.Settings().Server
+	s.rest.SetAddress(fmt.Sprintf(":%d", settings.RestPort))
+	s.grpc.SetAddress(fmt.Sprintf(":%d", settings.GrpcPort))
 }
 
 func stateToString(s *KVServer) string {
-	state := ""
-
-	switch s.state {
-	case types.ServerError:
-		state = "Error"
-	case types.ServerRunning:
-		state = "Running"
-	case types.ServerStarting:
-		state = "Starting"
-	case types.ServerStopping:
-		state = "Stopping"
-	case types.ServerStopped:
-		state = "Stopped"
-	case types.ServerUnknownState:
-		state = "Unknown"
-
-	}
-	return state
+	return s.State().String()
 }
 
-// Stop - A function that stops the KV Server
-func (s *KVServer) Stop() {
-	// TODO - Stop the KV Server here
-	s.logger.Println("KV Server Stopping")
-	s.state = types.ServerStopping
-	// TODO - Stop the KV Server here
-	wg := &sync.WaitGroup{}
-	defer wg.Wait()
-	wg.Add(1)
-	go func() {
-		defer s.logger.Println("Stopped REST API")
-		s.rest.Stop()
-		wg.Done()
-	}()
-	// Save the data to the persistence layer
-	wg.Add(1)
-	go func() {
-		defer s.logger.Println("Saved data to persistence layer")
-		s.persistence.Sync(s.Records.GetAll(s.logger))
-		wg.Done()
-	}()
-
-	// Write to log
-	s.state = types.ServerStopped
-	defer s.logger.Println("KV Server Stopped")
-
-	s.logger.Println("Waiting for last sync to complete")
-	for s.state != types.ServerStopped {
-		s.logger.Print(".")
-		time.Sleep(1 * time.Second)
+// Stop - A function that stops the KV Server, draining in-flight REST and gRPC
+// requests until the context ends, then flushing the records to the
+// persistence layer
+func (s *KVServer) Stop(ctx context.Context) error {
+	if err := s.transition(types.ServerStopping); err != nil {
+		return err
 	}
 
-}
+	errs := []error{}
+	if err := s.rest.Stop(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error stopping REST API: %w", err))
+	}
+	if err := s.grpc.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error stopping gRPC API: %w", err))
+	}
+	s.logger.Info("Stopped REST and gRPC APIs")
 
-// GetStatus - A function that returns the status of the KV Server
-func (s *KVServer) GetStatus() (interface{}, error) {
-	// TODO - Return the status of the KV Server here
-	status := make(map[string]string)
-	status["state"] = stateToString(s)
-	return status, nil
+	// a server that never finished starting has nothing to flush
+	s.lifecycle.mu.Lock()
+	cancel, done := s.lifecycle.cancel, s.lifecycle.done
+	s.lifecycle.cancel = nil
+	s.lifecycle.mu.Unlock()
+	if cancel != nil {
+		// let a running sync finish before the final flush
+		cancel()
+		<-done
+		s.config.Stop()
+		s.flags.Stop()
+
+		if err := s.watches.settle(func(revision uint64) error {
+			return s.saveCounter(revisionKey, revision)
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("error saving revision: %w", err))
+		}
+		if err := s.persistence.Flush(s.Records.GetAll(), s.retained); err != nil {
+			errs = append(errs, fmt.Errorf("error flushing records: %w", err))
+		} else {
+			s.logger.Info("Saved data to persistence layer")
+		}
+		s.cdc.Stop()
+	}
+	s.hooks.stop()
+
+	if len(errs) > 0 {
+		return s.fail(errors.Join(errs...))
+	}
+	return s.transition(types.ServerStopped)
 }
@@ -1,8 +1,10 @@
 package kvserver
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/aawadall/simple-kv/types"
 )
@@ -11,30 +13,37 @@ import (
 
 // Get - A function that gets a value from the KV Server
 func (s *KVServer) Get(key string) (value interface{}, err error) {
+	defer s.countOperation("get", &err)
 
-	// check if the key is empty
-	if key == "" {
-		return nil, fmt.Errorf("key cannot be empty")
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
 	}
 
 	// check if the key is in the store
-	record, ok := s.Records.Get(key)
-	if !ok {
-		return nil, fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
 	}
 	// otherwise return the value
-	value, err = record.GetValue(-1)
-	return value, err
+	bValue, err := record.GetValue(-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// chunked values are assembled from their chunks
+	if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType == types.ValueTypeChunked {
+		return s.readChunked(record.StorageKey(), bValue)
+	}
+	return bValue, nil
 }
 
 // Set - A function that sets a value in the KV Server
 func (s *KVServer) Set(key string, value interface{}) (err error) {
-	wg := &sync.WaitGroup{}
-	defer wg.Wait()
-
-	// check if the key is empty
-	if key == "" {
-		return fmt.Errorf("key cannot be empty")
+	defer s.countOperation("set", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
 	}
 
 	// check if the value is empty
@@ -45,40 +54,133 @@ func (s *KVServer) Set(key string, value interface{}) (err error) {
 	// cast value to bytes
 	bValue := value.([]byte)
 
+	// let the hooks check or change the write
+	op := types.WriteOperation{Key: key, Operation: types.OperationSet, Value: bValue, Size: int64(len(bValue))}
+	if err := s.beforeWrite(&op); err != nil {
+		return err
+	}
+	bValue = op.Value
+
+	// values longer than a chunk are stored as chunks
+	if len(bValue) > s.chunkSize {
+		_, err = s.setStream(key, bytes.NewReader(bValue), true)
+		return err
+	}
+	return s.setValue(key, bValue, "")
+}
+
+// setValue - commits a value set by a client, refusing to overwrite sequences
+func (s *KVServer) setValue(key string, bValue []byte, valueType string) (err error) {
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	// sequences only move forward through NextSequence, locks through Lock and Unlock
+	if record, ok := s.Records.Get(s.storageKey(key)); ok && !record.IsDeleted() {
+		if valueType, _ := record.Metadata.Get(types.MetadataValueType); valueType == types.ValueTypeSequence || valueType == types.ValueTypeLock {
+			return fmt.Errorf("%w: %v is a %v", types.ErrTypeMismatch, key, valueType)
+		}
+	}
+
+	_, err = s.set(key, bValue, valueType)
+	return err
+}
+
+// set - validates and commits a new value tagged with its value type, an empty
+// type marks a plain value, callers hold the key lock
+func (s *KVServer) set(key string, bValue []byte, valueType string) (KVRecord, error) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	// check the size of the value
+	size := valueSize(bValue, valueType)
+	if err := s.checkValueSize(size); err != nil {
+		return KVRecord{}, err
+	}
+
+	// check the value against the schema of its prefix
+	if err := s.validateValue(key, bValue, valueType); err != nil {
+		return KVRecord{}, err
+	}
+
 	// check if the key is in the store
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		s.persistence.Write(*types.NewKVRecord(key, bValue))
-	}()
-	record, ok := s.Records.Get(key)
+	record, ok := s.Records.Get(s.storageKey(key))
+
+	// check the write against the tenant's quota
+	if err := s.checkSetQuota(record, ok, size); err != nil {
+		return KVRecord{}, err
+	}
+
+	before := noState
 	if !ok {
 		// if not, create a new record
-		s.Records.Set(key, *types.NewKVRecord(key, bValue))
+		record = *types.NewKVRecord(key, bValue)
+		record.Tenant = s.tenant
 	} else {
 		// otherwise update the value
 		// Update the record
+		before = stateOf(record)
 		record.UpdateRecord(key, bValue)
+	}
 
-		s.Records.Set(key, record)
+	// keep the value type tag in step with the value
+	current, tagged := record.Metadata.Get(types.MetadataValueType)
+	if valueType != "" && current != valueType {
+		record.Metadata.Set(types.MetadataValueType, valueType)
+	} else if valueType == "" && tagged {
+		record.Metadata.Delete(types.MetadataValueType)
 	}
+	s.Records.Set(s.storageKey(key), record)
+	s.publish(types.OperationSet, before, record)
 
-	// TODO - Implement this function
-	return nil
+	// persist the committed record, so version timestamps match memory
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.persistence.Write(record)
+	}()
+
+	return record, nil
 }
 
 // Delete - A function that deletes a value from the KV Server
 func (s *KVServer) Delete(key string) (err error) {
-	// check if the key is empty
-	wg := &sync.WaitGroup{}
-	if key == "" {
-		return fmt.Errorf("key cannot be empty")
+	defer s.countOperation("delete", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
+	}
+
+	// let the hooks check the delete
+	if err := s.beforeWrite(&types.WriteOperation{Key: key, Operation: types.OperationDelete}); err != nil {
+		return err
 	}
 
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+	return s.delete(key)
+}
+
+// delete - deletes a key, callers hold the key lock
+func (s *KVServer) delete(key string) (err error) {
+	wg := &sync.WaitGroup{}
+
 	// check if the key is in the store
-	_, ok := s.Records.Get(key)
-	if !ok {
-		return fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	before := stateOf(record)
+
+	// soft delete keeps the history behind a tombstone
+	if s.softDeleteFor(key) {
+		_, err = record.MarkDeleted()
+		if err != nil {
+			return err
+		}
+		s.Records.Set(s.storageKey(key), record)
+		s.publish(types.OperationDelete, before, record)
+		return s.persistence.Write(record)
 	}
 
 	// delete @ persistence
@@ -86,10 +188,11 @@ func (s *KVServer) Delete(key string) (err error) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		s.persistence.Delete(key)
+		s.persistence.Delete(s.storageKey(key))
 	}()
 	// otherwise delete the record
-	s.Records.Delete(key)
+	s.Records.Delete(s.storageKey(key))
+	s.publish(types.OperationDelete, before, record)
 
 	return nil
 }
@@ -97,9 +200,19 @@ func (s *KVServer) Delete(key string) (err error) {
 // Advanced Methods
 // Set Metadata
 func (s *KVServer) SetMetadata(key string, metadataKey string, metadataValue string) (err error) {
-	// check if the key is empty
-	if key == "" {
-		return fmt.Errorf("key cannot be empty")
+	defer s.countOperation("set_metadata", &err)
+	// value types and leases are only tagged by the server
+	if err := checkMetadataKey(metadataKey); err != nil {
+		return err
+	}
+	return s.setMetadata(key, metadataKey, metadataValue)
+}
+
+// setMetadata - sets a metadata value of a key, reserved metadata keys included
+func (s *KVServer) setMetadata(key string, metadataKey string, metadataValue string) (err error) {
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
 	}
 
 	// check if the metadata key is empty
@@ -112,13 +225,28 @@ func (s *KVServer) SetMetadata(key string, metadataKey string, metadataValue str
 		return fmt.Errorf("metadata value cannot be empty")
 	}
 
+	// let the hooks check or change the metadata value
+	op := types.WriteOperation{Key: key, Operation: types.OperationSetMetadata, MetadataKey: metadataKey, MetadataValue: metadataValue}
+	if err := s.beforeWrite(&op); err != nil {
+		return err
+	}
+	if metadataValue = op.MetadataValue; metadataValue == "" {
+		return fmt.Errorf("metadata value cannot be empty")
+	}
+
 	// check if the key is in the store
-	record, ok := s.Records.Get(key)
-	if !ok {
-		return fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	// check the write against the tenant's quota
+	if err := s.checkMetadataQuota(record, metadataKey, metadataValue); err != nil {
+		return err
 	}
 
 	// otherwise set the metadata
+	before := stateOf(record)
 	newVer, err := record.SetMetadata(metadataKey, metadataValue)
 
 	if err != nil {
@@ -129,15 +257,17 @@ func (s *KVServer) SetMetadata(key string, metadataKey string, metadataValue str
 	record.SetMetadata("Version", fmt.Sprintf("%d", newVer))
 
 	// update the record
-	s.Records.Set(key, record)
+	s.Records.Set(s.storageKey(key), record)
+	s.publish(types.OperationSetMetadata, before, record)
 	return nil
 }
 
 // Get Metadata
 func (s *KVServer) GetMetadata(key string, metadataKey string) (value string, err error) {
-	// check if the key is empty
-	if key == "" {
-		return "", fmt.Errorf("key cannot be empty")
+	defer s.countOperation("get_metadata", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return "", err
 	}
 
 	// check if the metadata key is empty
@@ -146,15 +276,15 @@ func (s *KVServer) GetMetadata(key string, metadataKey string) (value string, er
 	}
 
 	// check if the key is in the store
-	record, ok := s.Records.Get(key)
-	if !ok {
-		return "", fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return "", fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
 	}
 
 	// check if the metadata key is in the store
 	metadata, ok := record.Metadata.Get(metadataKey)
 	if !ok {
-		return "", fmt.Errorf("metadata key not found")
+		return "", fmt.Errorf("%w: metadata key %v", types.ErrKeyNotFound, metadataKey)
 	}
 
 	// otherwise get the metadata
@@ -163,9 +293,10 @@ func (s *KVServer) GetMetadata(key string, metadataKey string) (value string, er
 
 // Delete Metadata
 func (s *KVServer) DeleteMetadata(key string, metadataKey string) (err error) {
-	// check if the key is empty
-	if key == "" {
-		return fmt.Errorf("key cannot be empty")
+	defer s.countOperation("delete_metadata", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
 	}
 
 	// check if the metadata key is empty
@@ -173,32 +304,45 @@ func (s *KVServer) DeleteMetadata(key string, metadataKey string) (err error) {
 		return fmt.Errorf("metadata key cannot be empty")
 	}
 
+	// value types and leases are only tagged by the server
+	if err := checkMetadataKey(metadataKey); err != nil {
+		return err
+	}
+
+	// let the hooks check the delete
+	if err := s.beforeWrite(&types.WriteOperation{Key: key, Operation: types.OperationDeleteMetadata, MetadataKey: metadataKey}); err != nil {
+		return err
+	}
+
 	// check if the key is in the store
-	record, ok := s.Records.Get(key)
-	if !ok {
-		return fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
 	}
 
 	// otherwise delete the metadata
+	before := stateOf(record)
 	record.DeleteMetadata(metadataKey)
 
 	// update the record
-	s.Records.Set(key, record)
+	s.Records.Set(s.storageKey(key), record)
+	s.publish(types.OperationDeleteMetadata, before, record)
 
 	return nil
 }
 
 // Get All Metadata
 func (s *KVServer) GetAllMetadata(key string) (metadata map[string]string, err error) {
-	// check if the key is empty
-	if key == "" {
-		return nil, fmt.Errorf("key cannot be empty")
+	defer s.countOperation("get_all_metadata", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
 	}
 
 	// check if the key is in the store
-	record, ok := s.Records.Get(key)
-	if !ok {
-		return nil, fmt.Errorf("key not found")
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok || record.IsDeleted() {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
 	}
 
 	// otherwise get all metadata
@@ -207,18 +351,20 @@ func (s *KVServer) GetAllMetadata(key string) (metadata map[string]string, err e
 
 // Find by partial key
 func (s *KVServer) Find(partialKey string) (keys []string, err error) {
-	// check if the partial key is empty
-	if partialKey == "" {
-		return nil, fmt.Errorf("partial key cannot be empty")
+	defer s.countOperation("find", &err)
+	// check the partial key is valid
+	if err := checkKey("partial key", partialKey); err != nil {
+		return nil, err
 	}
 
-	matchingKeys := s.Records.Find(partialKey)
+	matchingKeys := s.scopeKeys(s.Records.Find(s.storageKey(partialKey)))
 
 	return matchingKeys, nil
 }
 
 // Find by Metadata and comparison operators
 func (s *KVServer) FindByMetadata(query string) (keys []string, err error) {
+	defer s.countOperation("find_by_metadata", &err)
 	// Assuming query is commma separated entries, each in the format of "key:operator:value"
 	// e.g. "name:contains:John,age:>=:18"
 	// also assuming that queries are ANDed together
@@ -261,6 +407,103 @@ func (s *KVServer) FindByMetadata(query string) (keys []string, err error) {
 	// 	keys = append(keys, key)
 	// }
 
-	keys = s.Records.FindByMetadata(query)
+	keys = s.scopeKeys(s.Records.FindByMetadata(query))
 	return keys, nil
 }
+
+// Point in time reads
+
+// GetAsOf - A function that gets the value of a key as it was at the given instant
+func (s *KVServer) GetAsOf(key string, asOf time.Time) (value interface{}, err error) {
+	defer s.countOperation("get_as_of", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
+	}
+
+	// check if the key is in the store
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	// a tombstone means the key was deleted at that instant
+	bValue, version, err := record.Value.GetAt(asOf)
+	if err != nil {
+		return nil, err
+	}
+	if record.Value.IsTombstone(version) {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	// chunked values are assembled from their chunks, as Get does
+	if versionType(record, version) == types.ValueTypeChunked {
+		return s.readChunked(record.StorageKey(), bValue)
+	}
+	return bValue, nil
+}
+
+// GetAllMetadataAsOf - A function that gets all metadata of a key as it was at the given instant
+func (s *KVServer) GetAllMetadataAsOf(key string, asOf time.Time) (metadata map[string]string, err error) {
+	defer s.countOperation("get_all_metadata_as_of", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
+	}
+
+	// check if the key is in the store
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	return record.ListMetadataAt(asOf)
+}
+
+// FindAsOf - A function that finds the keys matching a prefix that existed at the given instant
+func (s *KVServer) FindAsOf(partialKey string, asOf time.Time) (keys []string, err error) {
+	defer s.countOperation("find_as_of", &err)
+	// check the partial key is valid
+	if err := checkKey("partial key", partialKey); err != nil {
+		return nil, err
+	}
+
+	return s.scopeKeys(s.Records.FindAt(s.storageKey(partialKey), asOf)), nil
+}
+
+// History - A function that lists every retained version of a key, oldest first,
+// chunked versions are listed by size only
+func (s *KVServer) History(key string) (versions []types.ValueVersion, err error) {
+	defer s.countOperation("history", &err)
+	// check the key is valid
+	if err := checkKey("key", key); err != nil {
+		return nil, err
+	}
+
+	// check if the key is in the store
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	values, timestamps, tombstones := record.Value.History()
+	for version, value := range values {
+		entry := types.ValueVersion{
+			Version:   version,
+			Value:     value,
+			Size:      int64(len(value)),
+			Timestamp: timestamps[version],
+			Deleted:   tombstones[version],
+		}
+
+		if !entry.Deleted && versionType(record, version) == types.ValueTypeChunked {
+			if manifest, err := types.ParseChunkManifest(value); err == nil {
+				entry.Value = nil
+				entry.Size = manifest.Size
+				entry.Chunked = true
+			}
+		}
+		versions = append(versions, entry)
+	}
+	return versions, nil
+}
@@ -1,6 +1,8 @@
 package kvserver
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -34,36 +36,53 @@ func TestNewKVServer(t *testing.T) {
 func TestKVServerStates(t *testing.T) {
 	defer quiet()()
 	// Arrange
-	config := map[string]string{}
+	os.Setenv("KV_SERVER_PORT", "0")
+	os.Setenv("KV_GRPC_PORT", "0")
+	defer os.Unsetenv("KV_SERVER_PORT")
+	defer os.Unsetenv("KV_GRPC_PORT")
+	config := map[string]string{"driver": "mock"}
 	svr := NewKVServer(config)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	// Assert that server is in the correct state
-	if svr.state != types.ServerUnknownState {
-		t.Errorf("server is in state %s instead of %s", stringState(svr.state), stringState(types.ServerUnknownState))
+	if svr.State() != types.ServerUnknownState {
+		t.Errorf("server is in state %s instead of %s", stringState(svr.State()), stringState(types.ServerUnknownState))
 	}
 
 	// Act
-	svr.Start()
-
-	// sleep for 17 seconds to allow server to start
-	time.Sleep(12 * time.Second)
+	err := svr.Start(ctx)
 
-	// Assert that server is in the correct state
-	if svr.state != types.ServerRunning {
-		t.Errorf("server is in state %s instead of %s", stringState(svr.state), stringState(types.ServerRunning))
+	// Assert that server is in the correct state once Start returns
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if svr.State() != types.ServerRunning {
+		t.Errorf("server is in state %s instead of %s", stringState(svr.State()), stringState(types.ServerRunning))
+	}
+	if err := svr.Start(ctx); !errors.Is(err, types.ErrInvalidTransition) {
+		t.Errorf("starting a running server should be refused, got %v", err)
 	}
 
 	// Act
-	svr.Stop()
-
-	// sleep for 17 seconds to allow server to stop
-	time.Sleep(12 * time.Second)
-
-	// Assert that server is in the correct state
-	if svr.state != types.ServerStopped {
-		t.Errorf("server is in state %s instead of %s", stringState(svr.state), stringState(types.ServerStopped))
+	svr.Set("flushed", []byte("on stop"))
+	stopped := make(chan ServerState, 1)
+	go func() {
+		state, _ := svr.WaitForState(ctx, types.ServerStopped, types.ServerError)
+		stopped <- state
+	}()
+	err = svr.Stop(ctx)
+
+	// Assert that server is in the correct state and flushed its records
+	if err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	if state := <-stopped; state != types.ServerStopped {
+		t.Errorf("server is in state %s instead of %s", stringState(state), stringState(types.ServerStopped))
+	}
+	if _, err := svr.persistence.Read("flushed"); err != nil {
+		t.Errorf("records should be flushed on stop, got %v", err)
 	}
-
 }
 
 // Helper function to convert state to string
@@ -0,0 +1,61 @@
+package kvserver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Soft delete
+// when enabled, Delete writes a tombstone version instead of dropping the record,
+// the history is kept for a grace period during which the key can be undeleted
+
+// Undelete - A function that restores the last live version of a soft deleted key
+func (s *KVServer) Undelete(key string) (err error) {
+	defer s.countOperation("undelete", &err)
+	// check the key is valid
+	if err := checkWritable(key); err != nil {
+		return err
+	}
+
+	// let the hooks check the undelete
+	if err := s.beforeWrite(&types.WriteOperation{Key: key, Operation: types.OperationUndelete}); err != nil {
+		return err
+	}
+
+	unlock := s.locks.lock(s.storageKey(key))
+	defer unlock()
+
+	// check if the key is in the store
+	record, ok := s.Records.Get(s.storageKey(key))
+	if !ok {
+		return fmt.Errorf("%w: %v", types.ErrKeyNotFound, key)
+	}
+
+	// restore the last live value as a new version
+	before := stateOf(record)
+	_, err = record.Restore()
+	if err != nil {
+		return err
+	}
+
+	s.Records.Set(s.storageKey(key), record)
+	s.publish(types.OperationUndelete, before, record)
+	return s.persistence.Write(record)
+}
+
+// purgeDeleted - removes tombstoned records that outlived the grace period,
+// including those left while the soft delete flag was on
+func (s *KVServer) purgeDeleted() {
+	cutoff := time.Now().UTC().Add(-s.config.Settings().SoftDelete.GracePeriod)
+	for _, record := range s.Records.PurgeDeleted(cutoff) {
+		key := record.Key
+		s.logger.Debug("Purging deleted record", "key", key)
+		s.publish(types.OperationPurge, stateOf(record), record)
+		err := s.persistence.Delete(record.StorageKey())
+		if err != nil {
+			s.logger.Error("Error purging record", "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package kvserver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that soft deleted keys are hidden and can be undeleted
+func TestSoftDeleteAndUndelete(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "soft_delete": "true"})
+	svr.Set("team/a", []byte("one"))
+
+	// Act
+	err := svr.Delete("team/a")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("delete returned error %v", err)
+	}
+	if _, err := svr.Get("team/a"); !errors.Is(err, types.ErrKeyNotFound) {
+		t.Errorf("deleted key should not be found, got %v", err)
+	}
+	if _, err := svr.GetMetadata("team/a", "Version"); !errors.Is(err, types.ErrKeyNotFound) {
+		t.Errorf("metadata of a deleted key should not be found, got %v", err)
+	}
+	if keys, _ := svr.Find("team/"); len(keys) != 0 {
+		t.Errorf("deleted key should not be found, got %v", keys)
+	}
+	if _, ok := svr.Records.Get("team/a"); !ok {
+		t.Errorf("soft deleted record should be retained")
+	}
+
+	// Act
+	err = svr.Undelete("team/a")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("undelete returned error %v", err)
+	}
+	value, err := svr.Get("team/a")
+	if err != nil || string(value.([]byte)) != "one" {
+		t.Errorf("undeleted value is %v (%v) instead of one", value, err)
+	}
+}
+
+// Test that tombstones are purged after the grace period
+func TestSoftDeletePurge(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "soft_delete": "true", "soft_delete_grace_period": "0"})
+	svr.Set("key", []byte("value"))
+	svr.Delete("key")
+
+	// Act
+	svr.purgeDeleted()
+
+	// Assert
+	if _, ok := svr.Records.Get("key"); ok {
+		t.Errorf("tombstone should have been purged")
+	}
+	if err := svr.Undelete("key"); err == nil {
+		t.Errorf("purged key should not be undeletable")
+	}
+}
+
+// Test that history lists every version, tombstones and chunked values included
+func TestHistory(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "soft_delete": "true", "chunk_size": "4"})
+	svr.Set("key", []byte("one"))
+	svr.Delete("key")
+	svr.Undelete("key")
+	svr.SetStream("key", strings.NewReader("0123456789"))
+
+	// Act
+	versions, err := svr.History("key")
+
+	// Assert
+	if err != nil || len(versions) != 4 {
+		t.Fatalf("history is %v (%v)", versions, err)
+	}
+	if string(versions[0].Value) != "one" || versions[0].Deleted {
+		t.Errorf("first version is %+v", versions[0])
+	}
+	if !versions[1].Deleted {
+		t.Errorf("second version should be a tombstone, got %+v", versions[1])
+	}
+	if last := versions[3]; !last.Chunked || last.Size != 10 || last.Value != nil {
+		t.Errorf("last version should be chunked, got %+v", last)
+	}
+	if _, err := svr.History("missing"); err == nil {
+		t.Errorf("history of a missing key should fail")
+	}
+}
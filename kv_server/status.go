@@ -0,0 +1,75 @@
+package kvserver
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Status and probes
+// the status is a snapshot of the server, readiness on /readyz requires the
+// records to be loaded and the persistence driver to be reachable, liveness on
+// /healthz only that the REST API answers
+
+// Version - build version reported in the status, set by the command
+var Version = "dev"
+
+// clusterRole - every server is on its own until the cluster package elects leaders
+const clusterRole = "standalone"
+
+// GetStatus - A function that returns the status of the KV Server
+func (s *KVServer) GetStatus() (types.ServerStatus, error) {
+	status := types.ServerStatus{
+		State:       stateToString(s),
+		Version:     Version,
+		Records:     s.Records.Len(),
+		Listeners:   map[string]string{"rest": s.rest.Addr(), "grpc": s.grpc.Addr()},
+		ClusterRole: clusterRole,
+	}
+
+	s.lifecycle.mu.Lock()
+	started := s.lifecycle.started
+	s.lifecycle.mu.Unlock()
+	if !started.IsZero() && s.State() == types.ServerRunning {
+		status.UptimeSeconds = int64(time.Since(started).Seconds())
+	}
+
+	memory := runtime.MemStats{}
+	runtime.ReadMemStats(&memory)
+	status.Memory = types.MemoryStatus{
+		AllocBytes: memory.Alloc,
+		SysBytes:   memory.Sys,
+		GCCycles:   memory.NumGC,
+		Goroutines: runtime.NumGoroutine(),
+	}
+
+	status.Persistence.Driver = s.persistence.Driver()
+	if sync := s.persistence.LastSync(); !sync.At.IsZero() {
+		status.Persistence.LastSync = sync.At.UTC().Format(time.RFC3339)
+		status.Persistence.LastSyncMillis = sync.Duration.Milliseconds()
+		if sync.Err != nil {
+			status.Persistence.LastSyncError = sync.Err.Error()
+		}
+	}
+
+	if stats, ok := s.Records.CacheStats(); ok {
+		status.Cache = &stats
+	}
+	return status, nil
+}
+
+// loadHealthChecks - registers the readiness checks served by the REST API
+func (s *KVServer) loadHealthChecks() {
+	checker := health.NewChecker()
+	checker.Add("data", func() error {
+		if state := s.State(); state != types.ServerRunning {
+			return fmt.Errorf("server is %v", state)
+		}
+		return nil
+	})
+	checker.Add("persistence", s.persistence.Ping)
+	s.rest.SetHealth(checker)
+}
@@ -0,0 +1,113 @@
+package kvserver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that the status describes the running server and readiness follows it
+func TestStatus(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "rest_port": "0", "grpc_port": "0"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	defer svr.Stop(ctx)
+	svr.Set("key", []byte("value"))
+
+	// Act
+	status, err := svr.GetStatus()
+	ready := probe(t, "http://"+svr.rest.Addr()+"/readyz")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if status.State != types.ServerRunning.String() || status.Records != 1 || status.Persistence.Driver != "none" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if strings.HasSuffix(status.Listeners["rest"], ":0") || strings.HasSuffix(status.Listeners["grpc"], ":0") {
+		t.Errorf("listeners should be the bound addresses, got %v", status.Listeners)
+	}
+	if ready.Status != health.StatusOK || ready.Checks["persistence"] != health.StatusOK {
+		t.Errorf("a running server should be ready, got %+v", ready)
+	}
+
+	// Act
+	svr.persistence.Sync(svr.Records.GetAll(), svr.Records.Has)
+	status, _ = svr.GetStatus()
+
+	// Assert
+	if status.Persistence.LastSync == "" || status.Persistence.LastSyncError != "" {
+		t.Errorf("the status should carry the last sync, got %+v", status.Persistence)
+	}
+	if live := probe(t, "http://"+svr.rest.Addr()+"/healthz"); live.Status != health.StatusOK {
+		t.Errorf("a running server should be live, got %+v", live)
+	}
+}
+
+// probe - gets a probe report
+func probe(t *testing.T, url string) health.Report {
+	response, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("probe failed: %v", err)
+	}
+	defer response.Body.Close()
+	report := health.Report{}
+	json.NewDecoder(response.Body).Decode(&report)
+	return report
+}
+
+// Test that requests, operations and sizes are exposed on /metrics
+func TestMetrics(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none", "rest_port": "0", "grpc_port": "0"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	defer svr.Stop(ctx)
+
+	// Act
+	svr.Set("key", []byte("value"))
+	svr.Get("missing")
+	svr.Increment("hits", 1, types.CounterOptions{})
+	probe(t, "http://"+svr.rest.Addr()+"/api/server/status")
+	response, err := http.Get("http://" + svr.rest.Addr() + "/metrics")
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer response.Body.Close()
+	body, _ := ioutil.ReadAll(response.Body)
+
+	// Assert
+	expected := []string{
+		`kv_http_requests_total{route="/api/server/status",method="GET",code="200"} 1`,
+		`kv_operations_total{operation="set",result="ok"} 1`,
+		`kv_operations_total{operation="get",result="error"} 1`,
+		`kv_operations_total{operation="increment",result="ok"} 1`,
+		`kv_changes_total{operation="set"} 2`,
+		`kv_records 2`,
+		`kv_queue_depth{queue="cdc"} 0`,
+		`kv_server_running 1`,
+		`# TYPE go_goroutines gauge`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("missing %q in\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package kvserver
+
+import (
+	"strconv"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// System records
+// server wide counters are persisted as records of a tenant name no tenant can
+// take, they are written by the server alone, kept out of the container and
+// read back at start: the latest watch revision and the latest fencing token
+
+// systemTenant - tenant of system records, not a valid tenant name
+const systemTenant = "kv:system"
+
+// storage keys of the system records
+var (
+	revisionKey = types.StorageKey(systemTenant, "revision")
+	fenceKey    = types.StorageKey(systemTenant, "fence")
+)
+
+// saveCounter - persists the value of a counter
+func (s *KVServer) saveCounter(storageKey string, value uint64) error {
+	tenant, key := types.SplitStorageKey(storageKey)
+	record := types.NewKVRecord(key, []byte(strconv.FormatUint(value, 10)))
+	record.Tenant = tenant
+	return s.persistence.Write(*record)
+}
+
+// retained - keys kept on disk although not held in memory: evicted records
+// and system records
+func (s *KVServer) retained(storageKey string) bool {
+	tenant, _ := types.SplitStorageKey(storageKey)
+	return tenant == systemTenant || s.Records.Has(storageKey)
+}
+
+// takeCounters - removes system records from loaded records, returning the
+// counters they hold by storage key
+func takeCounters(records []KVRecord) ([]KVRecord, map[string]uint64) {
+	counters := make(map[string]uint64)
+	kept := records[:0]
+	for _, record := range records {
+		if record.TenantName() != systemTenant {
+			kept = append(kept, record)
+			continue
+		}
+		if value, err := record.GetValue(-1); err == nil {
+			counters[record.StorageKey()], _ = strconv.ParseUint(string(value), 10, 64)
+		}
+	}
+	return kept, counters
+}
+
+// resumeCounters - continues the counters of the previous run
+func (s *KVServer) resumeCounters(counters map[string]uint64) {
+	s.watches.resumeFrom(counters[revisionKey])
+	s.leases.resumeFence(counters[fenceKey])
+}
@@ -0,0 +1,50 @@
+package kvserver
+
+import (
+	"fmt"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// ForTenant - A function that returns a view of the server scoped to a tenant,
+// the view shares records, persistence and watchers with the server
+func (s *KVServer) ForTenant(tenant string) (types.Server, error) {
+	if err := types.ValidateTenant(tenant); err != nil {
+		return nil, err
+	}
+
+	return s.inTenant(tenant), nil
+}
+
+// inTenant - a view of the server scoped to an already validated tenant
+func (s *KVServer) inTenant(tenant string) *KVServer {
+	view := *s
+	view.tenant = tenant
+	view.logger = s.logger.With("tenant", tenant)
+	return &view
+}
+
+// storageKey - the key a record of this server's tenant is stored under
+func (s *KVServer) storageKey(key string) string {
+	return types.StorageKey(s.tenant, key)
+}
+
+// checkKey - refuses an empty key, or prefix or name, and one that could
+// reach the records of another tenant
+func checkKey(what string, key string) error {
+	if key == "" {
+		return fmt.Errorf("%v cannot be empty", what)
+	}
+	return types.ValidateKey(key)
+}
+
+// scopeKeys - keeps the storage keys of this server's tenant, as plain keys
+func (s *KVServer) scopeKeys(storageKeys []string) []string {
+	var keys []string
+	for _, storageKey := range storageKeys {
+		if tenant, key := types.SplitStorageKey(storageKey); tenant == s.tenant {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,78 @@
+package kvserver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that tenants see only their own keys
+func TestTenantIsolation(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	acme, _ := svr.ForTenant("acme")
+	svr.Set("team/a", []byte("default"))
+	acme.Set("team/a", []byte("acme"))
+	acme.Set("team/b", []byte("acme"))
+	acme.SetMetadata("team/b", "owner", "ops")
+
+	// Act
+	value, _ := svr.Get("team/a")
+	acmeValue, _ := acme.Get("team/a")
+	keys, _ := svr.Find("team/")
+	acmeKeys, _ := acme.FindByMetadata("owner")
+
+	// Assert
+	if string(value.([]byte)) != "default" || string(acmeValue.([]byte)) != "acme" {
+		t.Errorf("values leaked across tenants: %s and %s", value, acmeValue)
+	}
+	if !reflect.DeepEqual(keys, []string{"team/a"}) {
+		t.Errorf("default tenant found %v", keys)
+	}
+	if !reflect.DeepEqual(acmeKeys, []string{"team/b"}) {
+		t.Errorf("acme found %v", acmeKeys)
+	}
+	record, ok := svr.Records.Get(types.StorageKey("acme", "team/b"))
+	if !ok || record.Tenant != "acme" || record.Key != "team/b" {
+		t.Errorf("acme record stored as %+v", record)
+	}
+	for _, tenant := range []string{"bad/tenant", "Acme", "acme.eu"} {
+		if _, err := svr.ForTenant(tenant); err == nil {
+			t.Errorf("tenant %q cannot be configured and should be rejected", tenant)
+		}
+	}
+}
+
+// Test that keys holding the tenant separator cannot reach another tenant
+func TestTenantSeparatorRejected(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	acme, _ := svr.ForTenant("acme")
+	acme.Set("secret", []byte("acme"))
+	stolen := "acme\x00secret"
+
+	// Act & Assert
+	if _, err := svr.Get(stolen); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("reading %q should be an invalid key, got %v", stolen, err)
+	}
+	if err := svr.Set(stolen, []byte("default")); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("writing %q should be an invalid key, got %v", stolen, err)
+	}
+	if err := svr.Delete(stolen); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("deleting %q should be an invalid key, got %v", stolen, err)
+	}
+	if _, err := svr.Copy(stolen, "copied", types.CopyOptions{}); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("copying %q should be an invalid key, got %v", stolen, err)
+	}
+	if _, _, err := svr.Watch(types.WatchFilter{Key: stolen}); !errors.Is(err, types.ErrInvalidKey) {
+		t.Errorf("watching %q should be an invalid key, got %v", stolen, err)
+	}
+	value, _ := acme.Get("secret")
+	if string(value.([]byte)) != "acme" {
+		t.Errorf("acme secret changed to %s", value)
+	}
+}
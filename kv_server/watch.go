@@ -0,0 +1,248 @@
+package kvserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Watch
+// every committed change is stamped with a server wide revision and fanned out to
+// the subscribers whose filter matches, a bounded history of recent events lets
+// clients resume from the last revision they saw after reconnecting, revisions
+// are reserved in batches so the revision record is written once per batch and
+// numbering skips ahead of the reserved ones after a crash, a clean stop saves
+// the latest revision instead, events before the restart are not retained, a
+// client that saw every one resumes live and one that missed some is told they
+// were compacted
+
+const (
+	// number of recent events retained for resuming watchers
+	watchHistorySize = 1024
+	// events buffered per watcher before it is considered too slow and dropped
+	watchBufferSize = 256
+	// revisions reserved by each write of the revision record
+	revisionBatch = 1024
+)
+
+// watcher - a single subscription
+type watcher struct {
+	filter types.WatchFilter
+	events chan types.ChangeEvent
+}
+
+// watchHub - publishes change events to watchers
+type watchHub struct {
+	mu       sync.Mutex
+	revision uint64
+	// revisions up to reserved are persisted and may be handed out
+	reserved uint64
+	history  []types.ChangeEvent
+	watchers map[int]*watcher
+	nextId   int
+	// observers see every event in revision order, under the hub lock
+	observers []func(types.ChangeEvent)
+}
+
+// newWatchHub - creates an empty watch hub
+func newWatchHub() *watchHub {
+	return &watchHub{
+		history:  make([]types.ChangeEvent, 0, watchHistorySize),
+		watchers: make(map[int]*watcher),
+	}
+}
+
+// publish - stamps the event with the next revision and delivers it
+func (h *watchHub) publish(event types.ChangeEvent) types.ChangeEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.revision++
+	event.Revision = h.revision
+
+	// keep a bounded history for resuming watchers
+	if len(h.history) == watchHistorySize {
+		h.history = append(h.history[:0], h.history[1:]...)
+	}
+	h.history = append(h.history, event)
+
+	for _, observe := range h.observers {
+		observe(event)
+	}
+
+	for id, w := range h.watchers {
+		if !w.filter.Matches(event) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		default:
+			// slow watcher, drop it so it can resume from its last revision
+			close(w.events)
+			delete(h.watchers, id)
+		}
+	}
+	return event
+}
+
+// observe - registers a function called with every event, in revision order
+func (h *watchHub) observe(observer func(types.ChangeEvent)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.observers = append(h.observers, observer)
+}
+
+// depth - the watchers and the events buffered for them
+func (h *watchHub) depth() (watchers int, events int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, w := range h.watchers {
+		events += len(w.events)
+	}
+	return len(h.watchers), events
+}
+
+// resumeFrom - continues revision numbering after a previous run
+func (h *watchHub) resumeFrom(revision uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if revision > h.revision {
+		h.revision = revision
+	}
+}
+
+// settle - saves the latest revision, giving back the unused reserved ones so a
+// clean restart continues without a gap
+func (h *watchHub) settle(save func(uint64) error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := save(h.revision); err != nil {
+		return err
+	}
+	h.reserved = h.revision
+	return nil
+}
+
+// subscribe - registers a watcher, replaying retained events from the requested revision
+func (h *watchHub) subscribe(filter types.WatchFilter) (<-chan types.ChangeEvent, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	replay := []types.ChangeEvent{}
+	if filter.FromRevision > 0 {
+		if filter.FromRevision > h.revision+1 {
+			return nil, nil, fmt.Errorf("revision %d is ahead of current revision %d", filter.FromRevision, h.revision)
+		}
+		oldest := h.revision - uint64(len(h.history)) + 1
+		if filter.FromRevision < oldest {
+			return nil, nil, fmt.Errorf("%w: revision %d, oldest retained revision is %d",
+				types.ErrCompacted, filter.FromRevision, oldest)
+		}
+		for _, event := range h.history {
+			if event.Revision >= filter.FromRevision && filter.Matches(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	w := &watcher{
+		filter: filter,
+		events: make(chan types.ChangeEvent, len(replay)+watchBufferSize),
+	}
+	for _, event := range replay {
+		w.events <- event
+	}
+
+	id := h.nextId
+	h.nextId++
+	h.watchers[id] = w
+
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.watchers[id]; ok {
+			close(w.events)
+			delete(h.watchers, id)
+		}
+	}
+	return w.events, cancel, nil
+}
+
+// Watch - A function that subscribes to committed changes matching the filter,
+// the returned function cancels the subscription
+func (s *KVServer) Watch(filter types.WatchFilter) (events <-chan types.ChangeEvent, cancel func(), err error) {
+	defer s.countOperation("watch", &err)
+	if err := types.ValidateKey(filter.Key + filter.Prefix); err != nil {
+		return nil, nil, err
+	}
+	filter.Tenant = s.tenant
+	return s.watches.subscribe(filter)
+}
+
+// recordState - the parts of a record captured before a mutation
+type recordState struct {
+	version  int
+	metadata map[string]string
+	usage    types.TenantUsage
+}
+
+// noState - the state before a record was created
+var noState = recordState{version: -1}
+
+// stateOf - captures the current state of a record
+func stateOf(record KVRecord) recordState {
+	return recordState{
+		version:  record.GetVersion(),
+		metadata: record.Metadata.Copy(),
+		usage:    footprint(record),
+	}
+}
+
+// publish - reports a committed change on a record to watchers
+func (s *KVServer) publish(operation string, before recordState, record KVRecord) {
+	after := stateOf(record)
+	event := types.ChangeEvent{
+		Tenant:          record.TenantName(),
+		Key:             record.Key,
+		Operation:       operation,
+		Version:         after.version,
+		PreviousVersion: before.version,
+		Metadata:        after.metadata,
+		Timestamp:       time.Now().UTC(),
+	}
+
+	// removed records have neither a version nor metadata left
+	gone := operation == types.OperationPurge ||
+		(operation == types.OperationDelete && !record.IsDeleted())
+	if gone {
+		event.Version = -1
+		event.Metadata = nil
+		after.usage = types.TenantUsage{}
+	}
+	s.usage.add(event.Tenant, after.usage.Sub(before.usage))
+	event.MetadataDiff = types.DiffMetadata(before.metadata, event.Metadata)
+
+	// deletes carry no value
+	if operation != types.OperationDelete && operation != types.OperationPurge {
+		event.Value, _ = record.GetValue(-1)
+	}
+
+	s.watches.publish(event)
+}
+
+// saveRevision - reserves the next batch of revisions before watchers see a
+// change beyond the reserved ones, called by the watch hub in revision order
+// under its lock
+func (s *KVServer) saveRevision(event types.ChangeEvent) {
+	if event.Revision <= s.watches.reserved {
+		return
+	}
+	reserved := event.Revision + revisionBatch - 1
+	if err := s.saveCounter(revisionKey, reserved); err != nil {
+		s.logger.Error("Error saving revision", "revision", event.Revision, "error", err)
+		return
+	}
+	s.watches.reserved = reserved
+}
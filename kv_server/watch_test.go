@@ -0,0 +1,157 @@
+package kvserver
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that watchers only receive matching events
+func TestWatchPrefix(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	events, cancel, err := svr.Watch(types.WatchFilter{Prefix: "config/"})
+	if err != nil {
+		t.Fatalf("watch returned error %v", err)
+	}
+	defer cancel()
+
+	// Act
+	svr.Set("other", []byte("x"))
+	svr.Set("config/a", []byte("1"))
+	svr.SetMetadata("config/a", "owner", "team")
+	svr.Delete("config/a")
+
+	// Assert
+	expected := []string{types.OperationSet, types.OperationSetMetadata, types.OperationDelete}
+	for _, operation := range expected {
+		select {
+		case event := <-events:
+			if event.Key != "config/a" || event.Operation != operation {
+				t.Errorf("got %s on %s instead of %s on config/a", event.Operation, event.Key, operation)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", operation)
+		}
+	}
+}
+
+// Test that watchers can resume from a past revision
+func TestWatchResume(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "none"})
+	svr.Set("a", []byte("1"))
+	svr.Set("a", []byte("2"))
+	svr.Set("a", []byte("3"))
+
+	// Act
+	events, cancel, err := svr.Watch(types.WatchFilter{Key: "a", FromRevision: 2})
+	if err != nil {
+		t.Fatalf("watch returned error %v", err)
+	}
+	defer cancel()
+
+	// Assert
+	for _, value := range []string{"2", "3"} {
+		event := <-events
+		if string(event.Value) != value {
+			t.Errorf("replayed value %s instead of %s", event.Value, value)
+		}
+	}
+
+	if _, _, err := svr.Watch(types.WatchFilter{FromRevision: 10}); err == nil || errors.Is(err, types.ErrCompacted) {
+		t.Errorf("resuming from a future revision should fail without being compacted, got %v", err)
+	}
+}
+
+// Test that revisions continue after a restart without change data capture
+func TestWatchResumeAfterRestart(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	dir, err := ioutil.TempDir("", "watch")
+	if err != nil {
+		t.Fatalf("temp dir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	configuration := map[string]string{
+		"driver":      "sqlite",
+		"db_location": filepath.Join(dir, "kv.sqlite"),
+		"rest_port":   "0",
+		"grpc_port":   "0",
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	svr := NewKVServer(configuration)
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	svr.Set("a", []byte("1"))
+	svr.Set("a", []byte("2"))
+	if err := svr.Stop(ctx); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	// Act
+	svr = NewKVServer(configuration)
+	if err := svr.Start(ctx); err != nil {
+		t.Fatalf("restart failed: %v", err)
+	}
+	defer svr.Stop(ctx)
+	events, stop, err := svr.Watch(types.WatchFilter{Key: "a", FromRevision: 3})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("a client that saw revision 2 should resume, got %v", err)
+	}
+	defer stop()
+	if _, _, err := svr.Watch(types.WatchFilter{FromRevision: 2}); !errors.Is(err, types.ErrCompacted) {
+		t.Errorf("revisions from before the restart should be reported compacted")
+	}
+	if keys, _ := svr.Find("rev"); len(keys) != 0 {
+		t.Errorf("the revision record should not be visible, got %v", keys)
+	}
+
+	svr.Set("a", []byte("3"))
+	select {
+	case event := <-events:
+		if event.Revision != 3 {
+			t.Errorf("revisions should continue at 3, got %d", event.Revision)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the change")
+	}
+}
+
+// Test that revisions are saved once per batch and skip ahead after a crash
+func TestRevisionBatches(t *testing.T) {
+	defer quiet()()
+	// Arrange
+	svr := NewKVServer(map[string]string{"driver": "mock"})
+	svr.Set("a", []byte("1"))
+	svr.Set("a", []byte("2"))
+	saved, _ := svr.persistence.Read(revisionKey)
+	value, _ := saved.GetValue(-1)
+
+	// Act
+	crashed := NewKVServer(map[string]string{"driver": "none"})
+	_, counters := takeCounters([]KVRecord{saved})
+	crashed.resumeCounters(counters)
+	event := crashed.watches.publish(types.ChangeEvent{Key: "a"})
+
+	// Assert
+	if string(value) != "1024" {
+		t.Errorf("revisions reserved up to %s instead of 1024", value)
+	}
+	if event.Revision != revisionBatch+1 {
+		t.Errorf("revisions should continue after the reserved ones, got %d", event.Revision)
+	}
+}
@@ -0,0 +1,192 @@
+package kvserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aawadall/simple-kv/cdc"
+	"github.com/aawadall/simple-kv/health"
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Webhooks
+// `webhooks.urls` (comma separated) registers local HTTP endpoints as hooks, each
+// is POSTed a JSON body with the phase and either the operation or the change,
+// before a write a 2xx response accepts it, optionally replacing the value or the
+// metadata value with those of a JSON response body (values base64 encoded), and
+// a 4xx response rejects it with the response body as the reason,
+// `webhooks.timeout` bounds each attempt and `webhooks.retries`
+// retries unreachable endpoints and 5xx responses, a pre-write webhook that
+// cannot be reached rejects the write
+
+// delay between attempts of a webhook call
+const webhookRetryDelay = 100 * time.Millisecond
+
+// webhook - a hook calling a local HTTP endpoint
+type webhook struct {
+	endpoint string
+	// name - the endpoint without its credentials, as logged
+	name    string
+	client  *http.Client
+	retries int
+	logger  *health.Logger
+}
+
+// webhookRequest - the body POSTed to a webhook
+type webhookRequest struct {
+	Phase     string                `json:"phase"`
+	Operation *types.WriteOperation `json:"operation,omitempty"`
+	Change    *cdc.Event            `json:"change,omitempty"`
+}
+
+// webhookReply - the optional body of a pre-write webhook's 2xx response
+type webhookReply struct {
+	Value         []byte `json:"value"`
+	MetadataValue string `json:"metadataValue"`
+}
+
+// NewWebhook - A function that creates a hook calling a local HTTP endpoint,
+// endpoints off the local host are refused
+func NewWebhook(endpoint string, timeout time.Duration, retries int) (types.Hook, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("webhook is not a URL")
+	}
+	name := redactURL(parsed)
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("webhook %v is not an HTTP endpoint", name)
+	}
+	if host := parsed.Hostname(); host != "localhost" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return nil, fmt.Errorf("webhook %v is not on the local host", name)
+		}
+	}
+	if retries < 0 {
+		retries = 0
+	}
+
+	return &webhook{
+		endpoint: endpoint,
+		name:     name,
+		client:   &http.Client{Timeout: timeout},
+		retries:  retries,
+		logger:   health.Default().Component("webhooks").With("endpoint", name),
+	}, nil
+}
+
+// Name - the endpoint of the webhook, without its credentials
+func (w *webhook) Name() string {
+	return w.name
+}
+
+// BeforeWrite - asks the endpoint whether the write may go ahead
+func (w *webhook) BeforeWrite(op *types.WriteOperation) error {
+	status, body, err := w.post(webhookRequest{Phase: "before", Operation: op})
+	if err != nil {
+		return err
+	}
+	if status >= 400 {
+		reason := strings.TrimSpace(string(body))
+		if reason == "" {
+			reason = http.StatusText(status)
+		}
+		return fmt.Errorf("%v", reason)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	reply := webhookReply{}
+	if err := json.Unmarshal(body, &reply); err != nil {
+		return fmt.Errorf("invalid webhook response: %w", err)
+	}
+	if reply.Value != nil && op.Operation == types.OperationSet {
+		op.Value = reply.Value
+		op.Size = int64(len(reply.Value))
+	}
+	if reply.MetadataValue != "" && op.Operation == types.OperationSetMetadata {
+		op.MetadataValue = reply.MetadataValue
+	}
+	return nil
+}
+
+// AfterWrite - tells the endpoint about a committed change
+func (w *webhook) AfterWrite(event types.ChangeEvent) {
+	change := cdc.NewEvent(event)
+	status, _, err := w.post(webhookRequest{Phase: "after", Change: &change})
+	if err == nil && status >= 400 {
+		err = fmt.Errorf("%v", http.StatusText(status))
+	}
+	if err != nil {
+		w.logger.Warn("Error delivering change", "revision", event.Revision, "error", err)
+	}
+}
+
+// post - sends a request, retrying unreachable endpoints and 5xx responses
+func (w *webhook) post(request webhookRequest) (int, []byte, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		status, body, err := w.send(payload)
+		if err == nil && status < 500 {
+			return status, body, nil
+		}
+		if attempt == w.retries {
+			if err == nil {
+				err = fmt.Errorf("%v", http.StatusText(status))
+			}
+			return 0, nil, fmt.Errorf("webhook unavailable after %d attempts: %w", attempt+1, err)
+		}
+		time.Sleep(webhookRetryDelay * time.Duration(attempt+1))
+	}
+}
+
+// send - a single attempt
+func (w *webhook) send(payload []byte) (int, []byte, error) {
+	response, err := w.client.Post(w.endpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		// errors of the client name the endpoint they were sent to
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = w.name
+		}
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return response.StatusCode, body, nil
+}
+
+// redactURL - a URL without its user info, query and fragment, which may carry
+// credentials
+func redactURL(parsed *url.URL) string {
+	redacted := *parsed
+	redacted.User, redacted.RawQuery, redacted.Fragment = nil, "", ""
+	return redacted.String()
+}
+
+// loadWebhookConfig - reads the `webhooks` settings
+func (s *KVServer) loadWebhookConfig() {
+	settings := s.config.Settings().Webhooks
+	for _, endpoint := range settings.URLs {
+		hook, err := NewWebhook(endpoint, settings.Timeout, settings.Retries)
+		if err != nil {
+			s.logger.Warn("Error registering webhook, ignoring it", "error", err)
+			continue
+		}
+		hook.(*webhook).logger = s.logger.Component("webhooks").With("endpoint", hook.Name())
+		s.RegisterHook(hook)
+	}
+}
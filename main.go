@@ -1,71 +1,79 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-
-	kvserver "github.com/aawadall/simple-kv/kv_server"
-	"github.com/aawadall/simple-kv/persistence"
-	"github.com/aawadall/simple-kv/types"
+	"io"
+	"os"
 )
 
-func main() {
-	// Entry point code  here
-
-	// Define Features Manager
-	featureFlagManager := NewFeatureFlagManager()
+// simple-kv - the key value store server and the commands maintaining its data
 
-	featureFlagManager.Add("direct_sqlite", "Use the sqlite driver directly", true)
-	featureFlagManager.Add("kv_server", "Instantiate and use KV Server", false)
-
-	// Experiment: directly use sqlite driver
-	if featureFlagManager.IsEnabled("direct_sqlite") {
-		location := "local.sqlite"
-		driver := persistence.NewSQLiteDriver(location)
+// command - a subcommand of simple-kv
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
 
-		// prepare record
-		record := types.NewKVRecord("test", []byte("test"))
+// commands - every subcommand, in the order usage lists them
+var commands = []command{
+	{"serve", "run the server until interrupted", serve},
+	{"migrate", "copy every record to another driver or data directory", migrate},
+	{"backup", "write every record to a backup file", backup},
+	{"restore", "write the records of a backup file", restore},
+	{"check", "look for records that cannot be served", check},
+	{"config", "print the effective configuration and where each value comes from", showConfig},
+	{"version", "print build information", printVersion},
+	{"experiment", "run the direct sqlite driver experiment", experiment},
+}
 
-		// inspect record
-		fmt.Println("Inspecting Record: ")
-		fmt.Printf("Record ID: %s\n", record.Id)
-		fmt.Printf("Record Key: %s\n", record.Key)
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-		value, err := record.Value.Get(-1)
-		fmt.Printf("Record Value: %v\n", value)
+// run - runs the subcommand named by the first argument, returns the exit code
+func run(args []string) int {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return 2
+	}
 
-		// write a record
-		err = driver.Write(*record)
+	name := args[0]
+	switch name {
+	case "help", "-h", "-help", "--help":
+		usage(os.Stdout)
+		return 0
+	}
 
-		if err != nil {
-			fmt.Println(err)
+	for _, c := range commands {
+		if c.name != name {
+			continue
 		}
-
-		// read a record
-		readRecord, err := driver.Read("test")
-
-		if err != nil {
-			fmt.Println(err)
+		err := c.run(args[1:])
+		if err == flag.ErrHelp {
+			return 0
 		}
-
-		fmt.Println(readRecord)
-
-		// delete a record
-		err = driver.Delete("test")
-
 		if err != nil {
-			fmt.Println(err)
+			fmt.Fprintf(os.Stderr, "simple-kv %v: %v\n", name, err)
+			return 1
 		}
-
+		return 0
 	}
-	// define server
-	if featureFlagManager.IsEnabled("kv_server") {
-		config := map[string]string{
-			"driver":        "sqlite",
-			"file_location": "kv.sqlite",
-			"sync_interval": "120",
-		}
-		server := kvserver.NewKVServer(config)
 
-		server.Start()
+	fmt.Fprintf(os.Stderr, "simple-kv: unknown command %q\n\n", name)
+	usage(os.Stderr)
+	return 2
+}
+
+// usage - lists the subcommands
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: simple-kv <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-11s %v\n", c.name, c.description)
 	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'simple-kv <command> -h' for the flags of a command.")
 }
@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Backups
+// a backup is a JSON lines archive, every record is followed by the chunks of
+// its chunked values, so restoring it line by line never commits a manifest
+// whose chunks are missing for long
+
+// archiveEntry - a line of a backup, holding either a record or a chunk
+type archiveEntry struct {
+	Record *KvRecord     `json:"record,omitempty"`
+	Chunk  *archiveChunk `json:"chunk,omitempty"`
+}
+
+// archiveChunk - one chunk of a large value
+type archiveChunk struct {
+	Key   string `json:"key"`
+	Blob  string `json:"blob"`
+	Index int    `json:"index"`
+	Data  []byte `json:"data"`
+}
+
+// Backup - write every record and chunk to w, returns the number of records
+func (pm *PersistenceManager) Backup(w io.Writer) (int, error) {
+	encoder := json.NewEncoder(w)
+	return pm.each(func(entry archiveEntry) error {
+		return encoder.Encode(entry)
+	})
+}
+
+// Restore - write the records and chunks of a backup, returns the number of records
+func (pm *PersistenceManager) Restore(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	count := 0
+	for line := 1; scanner.Scan(); line++ {
+		entry := archiveEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count, fmt.Errorf("invalid backup line %d: %v", line, err)
+		}
+		if err := pm.restoreEntry(entry); err != nil {
+			return count, fmt.Errorf("error restoring backup line %d: %w", line, err)
+		}
+		if entry.Record != nil {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
+
+// CopyTo - copy every record and chunk to another persistence manager, returns
+// the number of records, targets that cannot read records back or keep chunks
+// only in memory are refused as the copy would be lost
+func (pm *PersistenceManager) CopyTo(target *PersistenceManager) (int, error) {
+	if !target.Readable() {
+		return 0, fmt.Errorf("driver %v cannot read records back", target.Driver())
+	}
+	if _, ok := target.chunks.(*memoryChunks); ok {
+		return 0, fmt.Errorf("driver %v does not store chunks", target.Driver())
+	}
+	return pm.each(target.restoreEntry)
+}
+
+// Check - look for records that cannot be served, returns a description of each
+// problem found
+func (pm *PersistenceManager) Check() ([]string, error) {
+	records, err := pm.driver.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+	for _, record := range records {
+		name := fmt.Sprintf("%q (tenant %q)", record.Key, record.TenantName())
+		if record.Value == nil || len(record.Value.Value) == 0 {
+			problems = append(problems, fmt.Sprintf("%v has no value", name))
+			continue
+		}
+		if len(record.Value.Timestamps) != len(record.Value.Value) {
+			problems = append(problems, fmt.Sprintf("%v has %d values but %d timestamps",
+				name, len(record.Value.Value), len(record.Value.Timestamps)))
+		}
+		if record.Metadata == nil {
+			problems = append(problems, fmt.Sprintf("%v has no metadata", name))
+			continue
+		}
+		for _, manifest := range chunkManifests(record) {
+			for index := 0; index < manifest.Chunks; index++ {
+				if _, err := pm.ReadChunk(record.StorageKey(), manifest.Blob, index); err != nil {
+					problems = append(problems, fmt.Sprintf("%v is missing chunk %d of blob %v", name, index, manifest.Blob))
+					break
+				}
+			}
+		}
+	}
+	return problems, nil
+}
+
+// each - visits every record followed by its chunks, returns the number of records
+func (pm *PersistenceManager) each(visit func(entry archiveEntry) error) (int, error) {
+	records, err := pm.driver.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range records {
+		record := records[i]
+		if err := visit(archiveEntry{Record: &record}); err != nil {
+			return i, err
+		}
+		for _, manifest := range chunkManifests(record) {
+			for index := 0; index < manifest.Chunks; index++ {
+				data, err := pm.ReadChunk(record.StorageKey(), manifest.Blob, index)
+				if err != nil {
+					return i, fmt.Errorf("error reading chunk %d of %v: %w", index, record.Key, err)
+				}
+				chunk := archiveChunk{Key: record.StorageKey(), Blob: manifest.Blob, Index: index, Data: data}
+				if err := visit(archiveEntry{Chunk: &chunk}); err != nil {
+					return i, err
+				}
+			}
+		}
+	}
+	return len(records), nil
+}
+
+// restoreEntry - writes a record or a chunk
+func (pm *PersistenceManager) restoreEntry(entry archiveEntry) error {
+	switch {
+	case entry.Record != nil:
+		return pm.Write(*entry.Record)
+	case entry.Chunk != nil:
+		return pm.WriteChunk(entry.Chunk.Key, entry.Chunk.Blob, entry.Chunk.Index, entry.Chunk.Data)
+	default:
+		return fmt.Errorf("entry holds neither a record nor a chunk")
+	}
+}
+
+// chunkManifests - the manifests of every chunked value in a record's history
+func chunkManifests(record KvRecord) []types.ChunkManifest {
+	if record.Metadata == nil || record.Value == nil {
+		return nil
+	}
+
+	chunked := false
+	for _, change := range record.Metadata.GetHistory() {
+		if change.Key == types.MetadataValueType && change.Value == types.ValueTypeChunked {
+			chunked = true
+			break
+		}
+	}
+	if !chunked {
+		return nil
+	}
+
+	manifests := []types.ChunkManifest{}
+	for _, value := range record.Value.Value {
+		if manifest, err := types.ParseChunkManifest(value); err == nil {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests
+}
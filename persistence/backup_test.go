@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/aawadall/simple-kv/types"
+)
+
+// Test that a backup restores records with their chunks, and that migrations
+// refuse targets that would lose them
+func TestBackupRestore(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	source := NewPersistenceManager(map[string]interface{}{"driver": "sqlite", "db_location": filepath.Join(dir, "source.db")})
+	manifest := types.ChunkManifest{Blob: "blob", Size: 6, ChunkSize: 4, Chunks: 2}
+	record := types.NewKVRecord("large", manifest.Encode())
+	record.SetMetadata(types.MetadataValueType, types.ValueTypeChunked)
+	source.Write(*record)
+	source.WriteChunk(record.StorageKey(), "blob", 0, []byte("chun"))
+	source.WriteChunk(record.StorageKey(), "blob", 1, []byte("ks"))
+	source.Write(*types.NewKVRecord("small", []byte("value")))
+	restored := NewPersistenceManager(map[string]interface{}{"driver": "sqlite", "db_location": filepath.Join(dir, "restored.db")})
+	migrated := NewPersistenceManager(map[string]interface{}{"driver": "sqlite", "db_location": filepath.Join(dir, "migrated.db")})
+
+	// Act
+	archive := &bytes.Buffer{}
+	backedUp, backupErr := source.Backup(archive)
+	count, restoreErr := restored.Restore(archive)
+	copied, copyErr := source.CopyTo(migrated)
+	_, logErr := source.CopyTo(NewPersistenceManager(map[string]interface{}{"driver": "log", "file_location": filepath.Join(dir, "kv.log")}))
+	_, mockErr := source.CopyTo(NewPersistenceManager(map[string]interface{}{"driver": "mock"}))
+
+	// Assert
+	if backupErr != nil || restoreErr != nil || copyErr != nil {
+		t.Fatalf("backup, restore or copy failed: %v, %v, %v", backupErr, restoreErr, copyErr)
+	}
+	if backedUp != 2 || count != 2 || copied != 2 {
+		t.Errorf("expected 2 records each way, got %d, %d and %d", backedUp, count, copied)
+	}
+	for _, target := range []*PersistenceManager{restored, migrated} {
+		if small, err := target.Read("small"); err != nil || string(small.Value.Value[0]) != "value" {
+			t.Errorf("record should be copied, got %v", err)
+		}
+		if data, err := target.ReadChunk("large", "blob", 1); err != nil || string(data) != "ks" {
+			t.Errorf("chunks should be copied, got %q, %v", data, err)
+		}
+		if problems, err := target.Check(); err != nil || len(problems) != 0 {
+			t.Errorf("copy should pass the check, got %v, %v", problems, err)
+		}
+	}
+	if logErr == nil || mockErr == nil {
+		t.Errorf("targets without reads or chunk storage should be refused, got %v, %v", logErr, mockErr)
+	}
+
+	// Arrange
+	mock := NewPersistenceManager(map[string]interface{}{"driver": "mock"})
+	mock.Write(KvRecord{Key: "bare", Value: &types.ValuesContainer{Value: [][]byte{[]byte("v")}}})
+
+	// Act
+	_, err := mock.Backup(&bytes.Buffer{})
+
+	// Assert
+	if err != nil {
+		t.Errorf("records without metadata should be backed up, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ChunkDriver - implemented by drivers that store the chunks of large values,
+// keys are storage keys, an empty blob names every blob of the key
+type ChunkDriver interface {
+	WriteChunk(key string, blob string, index int, data []byte) error
+	ReadChunk(key string, blob string, index int) ([]byte, error)
+	DeleteChunks(key string, blob string) error
+}
+
+// memoryChunks - keeps chunks in memory for drivers without chunk storage,
+// chunks of a key are named "<blob>/<index>"
+type memoryChunks struct {
+	mu     sync.Mutex
+	chunks map[string]map[string][]byte
+}
+
+func newMemoryChunks() *memoryChunks {
+	return &memoryChunks{chunks: make(map[string]map[string][]byte)}
+}
+
+func (mc *memoryChunks) WriteChunk(key string, blob string, index int, data []byte) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.chunks[key] == nil {
+		mc.chunks[key] = make(map[string][]byte)
+	}
+	mc.chunks[key][fmt.Sprintf("%s/%d", blob, index)] = append([]byte(nil), data...)
+	return nil
+}
+
+func (mc *memoryChunks) ReadChunk(key string, blob string, index int) ([]byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	data, ok := mc.chunks[key][fmt.Sprintf("%s/%d", blob, index)]
+	if !ok {
+		return nil, fmt.Errorf("chunk %d of %v not found", index, key)
+	}
+	return data, nil
+}
+
+func (mc *memoryChunks) DeleteChunks(key string, blob string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if blob == "" {
+		delete(mc.chunks, key)
+		return nil
+	}
+	for name := range mc.chunks[key] {
+		if strings.HasPrefix(name, blob+"/") {
+			delete(mc.chunks[key], name)
+		}
+	}
+	return nil
+}
@@ -14,6 +14,16 @@ type Driver interface {
 	Load() ([]KvRecord, error)
 }
 
+// KeyLister - drivers that list the keys of their records without reading them
+type KeyLister interface {
+	Keys() ([]string, error)
+}
+
+// Pinger - drivers that can check their storage is reachable
+type Pinger interface {
+	Ping() error
+}
+
 // Helper Functions
 func CompareRecords(a, b KvRecord) bool {
 	return a.Key == b.Key && a.Value == b.Value && CompareMetadata(a.Metadata.GetAll(), b.Metadata.GetAll())
@@ -33,8 +33,17 @@ func (ff *LogDriver) Write(record KvRecord) error {
 	}
 	defer f.Close()
 
-	// Write record to file in new line
-	if _, err = f.WriteString(fmt.Sprintf("WRITE record(%v)\r", record.Key)); err != nil {
+	// Write record to file in new line, along with the commit time of its latest version
+	committedAt, err := record.Value.GetTimestamp(-1)
+	if err != nil {
+		return err
+	}
+	operation := "WRITE"
+	if record.IsDeleted() {
+		operation = "TOMBSTONE"
+	}
+	if _, err = f.WriteString(fmt.Sprintf("%s tenant(%s) record(%v) version(%d) committed(%s)\r",
+		operation, record.TenantName(), record.Key, record.GetVersion(), formatTime(committedAt))); err != nil {
 		return err
 	}
 
@@ -48,7 +57,7 @@ func (ff *LogDriver) Read(key string) (KvRecord, error) {
 	valuesContainer := types.NewValuesContainer(blob)
 
 	record := types.KVRecord{}
-	record.Key = key
+	record.Tenant, record.Key = types.SplitStorageKey(key)
 	record.Value = valuesContainer
 	
 	return record, nil
@@ -64,7 +73,8 @@ func (ff *LogDriver) Delete(key string) error {
 	defer f.Close()
 
 	// Write record to file in new line
-	if _, err = f.WriteString(fmt.Sprintf("DELETE record(%v)\r", key)); err != nil {
+	tenant, recordKey := types.SplitStorageKey(key)
+	if _, err = f.WriteString(fmt.Sprintf("DELETE tenant(%s) record(%v)\r", tenant, recordKey)); err != nil {
 		return err
 	}
 
@@ -84,6 +94,15 @@ func (ff *LogDriver) Load() ([]KvRecord, error) {
 	return makeRecords(10), nil
 }
 
+// Ping - checks the log file can be appended to
+func (ff *LogDriver) Ping() error {
+	f, err := os.OpenFile(ff.logFileName, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
 // Helper functions
 func makeRecords(count int) []KvRecord {
 	records := make([]KvRecord, count)
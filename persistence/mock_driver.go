@@ -3,23 +3,21 @@ package persistence
 import (
 	"fmt"
 
-	"log"
-	"os"
-
+	"github.com/aawadall/simple-kv/health"
 	"github.com/aawadall/simple-kv/types"
 )
 
 // Mock Driver - Pretends to be a persistence driver
 type MockDriver struct {
 	records MockContainer
-	logger  *log.Logger
+	logger  *health.Logger
 }
 
 // NewMockDriver - Create a new mock driver
 func NewMockDriver() *MockDriver {
 	driver := &MockDriver{
 		records: *NewMockContainer(),
-		logger:  log.New(os.Stdout, "MockDriver: ", log.LstdFlags),
+		logger:  health.Default().Component("mock"),
 	}
 	driver.Populate(10)
 	return driver
@@ -28,14 +26,14 @@ func NewMockDriver() *MockDriver {
 // implement Driver interface
 // Write(KvRecord) error
 func (md *MockDriver) Write(record KvRecord) error {
-	md.logger.Printf("Write(%v)", record.Key)
-	md.records.Set(record.Key, &record)
+	md.logger.Debug("Write", "key", record.Key)
+	md.records.Set(record.StorageKey(), &record)
 	return nil
 }
 
 // Read(string) (KvRecord, error)
 func (md *MockDriver) Read(key string) (KvRecord, error) {
-	md.logger.Printf("Read(%v)", key)
+	md.logger.Debug("Read", "key", key)
 	record, ok := md.records.Get(key)
 	if !ok {
 		return record, fmt.Errorf("record not found")
@@ -45,15 +43,15 @@ func (md *MockDriver) Read(key string) (KvRecord, error) {
 
 // Delete(string) error
 func (md *MockDriver) Delete(key string) error {
-	md.logger.Printf("Delete(%v)", key)
+	md.logger.Debug("Delete", "key", key)
 	md.records.Delete(key)
 	return nil
 }
 
 // Compare(KvRecord) (bool, error)
 func (md *MockDriver) Compare(record KvRecord) (bool, error) {
-	md.logger.Printf("Compare(%v)", record.Key)
-	found, ok := md.records.Get(record.Key)
+	md.logger.Debug("Compare", "key", record.Key)
+	found, ok := md.records.Get(record.StorageKey())
 	if !ok {
 		return false, fmt.Errorf("record not found")
 	}
@@ -62,11 +60,26 @@ func (md *MockDriver) Compare(record KvRecord) (bool, error) {
 
 // Load() ([]KvRecord, error)
 func (md *MockDriver) Load() ([]KvRecord, error) {
-	md.logger.Printf("Load()")
+	md.logger.Debug("Load")
 	records := md.records.GetAll()
 	return MapToRecordList(records), nil
 }
 
+// Keys() ([]string, error)
+func (md *MockDriver) Keys() ([]string, error) {
+	md.logger.Debug("Keys")
+	keys := []string{}
+	for key := range md.records.GetAll() {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SetLogger - Set the logger of the driver
+func (md *MockDriver) SetLogger(logger *health.Logger) {
+	md.logger = logger
+}
+
 // Populate - Populate the mock driver with some records
 func (md *MockDriver) Populate(count int) {
 	for i := 0; i < count; i++ {
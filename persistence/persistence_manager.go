@@ -2,36 +2,76 @@ package persistence
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"sync"
+	"time"
+
+	"github.com/aawadall/simple-kv/health"
 )
 
 // PersistenceManager - persistence manager
 type PersistenceManager struct {
-	logger *log.Logger
+	logger *health.Logger
 	driver Driver
+	// driverName - the driver setting the manager was created with
+	driverName string
+	// drivers that read single records back, required to evict records from memory
+	readable bool
+	// chunks of large values, kept by the driver when it supports them
+	chunks ChunkDriver
+
+	// lastSync - outcome of the latest sync
+	mu       sync.Mutex
+	lastSync SyncResult
+	// observe - told of every write, read, delete, load and sync, none when nil
+	observe Observer
+}
+
+// Observer - told how long an operation of the driver took and how it ended
+type Observer func(operation string, duration time.Duration, err error)
+
+// Logged - a driver writing its own log, given a logger by the manager
+type Logged interface {
+	SetLogger(logger *health.Logger)
+}
+
+// SyncResult - outcome of a sync
+type SyncResult struct {
+	// At - when the sync ended, zero before the first
+	At       time.Time
+	Duration time.Duration
+	Err      error
 }
 
 // NewPersistenceManager - create a new persistence manager
 func NewPersistenceManager(config map[string]interface{}) *PersistenceManager {
 	pm := &PersistenceManager{
-		logger: log.New(os.Stdout, "persistence: ", log.LstdFlags),
+		logger: health.Default().Component("persistence"),
 	}
 
-	pm.logger.Printf("Creating Persistence Manager with driver: %v", config["driver"])
+	pm.logger.Debug("Creating Persistence Manager", "driver", config["driver"])
+	pm.driverName = fmt.Sprintf("%v", config["driver"])
 	switch config["driver"] {
 	case "flat_file":
 		pm.driver = NewFlatFileDriver()
 	case "sqlite":
 		pm.driver = NewSQLiteDriver(fmt.Sprintf("%v", config["db_location"]))
+		pm.readable = true
 	case "mock":
 		pm.driver = NewMockDriver()
+		pm.readable = true
 	case "none":
 		pm.driver = NewNoPersistence()
 	case "log":
 		pm.driver = NewLogDriver(fmt.Sprintf("%v", config["file_location"]))
 	default:
 		pm.driver = NewFlatFileDriver()
+		pm.driverName = "flat_file"
+	}
+
+	if chunks, ok := pm.driver.(ChunkDriver); ok {
+		pm.chunks = chunks
+	} else {
+		pm.chunks = newMemoryChunks()
 	}
 
 	return pm
@@ -40,83 +80,194 @@ func NewPersistenceManager(config map[string]interface{}) *PersistenceManager {
 // Start - start the persistence manager
 func (pm *PersistenceManager) Start() {
 	// TODO: implement
-	pm.logger.Println("Starting Persistence Manager")
+	pm.logger.Debug("Starting Persistence Manager")
 }
 
 // Stop - stop the persistence manager
 func (pm *PersistenceManager) Stop() {
 	// TODO: implement
-	pm.logger.Println("Stopping Persistence Manager")
+	pm.logger.Debug("Stopping Persistence Manager")
+}
+
+// Driver - the name of the driver in use
+func (pm *PersistenceManager) Driver() string {
+	return pm.driverName
+}
+
+// Ping - checks the storage of the driver is reachable, drivers that cannot
+// tell are taken as reachable
+func (pm *PersistenceManager) Ping() error {
+	if pinger, ok := pm.driver.(Pinger); ok {
+		return pinger.Ping()
+	}
+	return nil
+}
+
+// LastSync - outcome of the latest sync
+func (pm *PersistenceManager) LastSync() SyncResult {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	return pm.lastSync
+}
+
+// SetLogger - set the logger of the manager, drivers log as a component named
+// after them
+func (pm *PersistenceManager) SetLogger(logger *health.Logger) {
+	pm.logger = logger
+	if logged, ok := pm.driver.(Logged); ok {
+		logged.SetLogger(logger.Component(pm.driverName))
+	}
+}
+
+// SetObserver - set the function told of every operation, before the manager is used
+func (pm *PersistenceManager) SetObserver(observe Observer) {
+	pm.observe = observe
+}
+
+// Readable - whether records can be read back one at a time
+func (pm *PersistenceManager) Readable() bool {
+	return pm.readable
 }
 
 // Write - write a record to disk
-func (pm *PersistenceManager) Write(record KvRecord) error {
+func (pm *PersistenceManager) Write(record KvRecord) (err error) {
+	defer pm.observed("write", time.Now(), &err)
 	return pm.driver.Write(record)
 }
 
 // Read - read a record from disk
-func (pm *PersistenceManager) Read(key string) (KvRecord, error) {
+func (pm *PersistenceManager) Read(key string) (record KvRecord, err error) {
+	defer pm.observed("read", time.Now(), &err)
 	return pm.driver.Read(key)
 }
 
-// Delete - delete a record from disk
-func (pm *PersistenceManager) Delete(key string) error {
+// Delete - delete a record and the chunks of its values from disk
+func (pm *PersistenceManager) Delete(key string) (err error) {
+	defer pm.observed("delete", time.Now(), &err)
+	if err := pm.chunks.DeleteChunks(key, ""); err != nil {
+		return err
+	}
+	return pm.driver.Delete(key)
+}
+
+// DeleteRecord - delete a record from disk, keeping the chunks of its values
+func (pm *PersistenceManager) DeleteRecord(key string) (err error) {
+	defer pm.observed("delete", time.Now(), &err)
 	return pm.driver.Delete(key)
 }
 
+// WriteChunk - write a chunk of a large value
+func (pm *PersistenceManager) WriteChunk(key string, blob string, index int, data []byte) error {
+	return pm.chunks.WriteChunk(key, blob, index, data)
+}
+
+// ReadChunk - read a chunk of a large value
+func (pm *PersistenceManager) ReadChunk(key string, blob string, index int) ([]byte, error) {
+	return pm.chunks.ReadChunk(key, blob, index)
+}
+
+// DeleteChunks - delete the chunks of one value, or of every value of a key when blob is empty
+func (pm *PersistenceManager) DeleteChunks(key string, blob string) error {
+	return pm.chunks.DeleteChunks(key, blob)
+}
+
 // Compare - compare a record to disk
 func (pm *PersistenceManager) Compare(record KvRecord) (bool, error) {
 	return pm.driver.Compare(record)
 }
 
 // Load - load all records from disk
-func (pm *PersistenceManager) Load() ([]KvRecord, error) {
+func (pm *PersistenceManager) Load() (records []KvRecord, err error) {
+	defer pm.observed("load", time.Now(), &err)
 	return pm.driver.Load()
 }
 
+// Keys - the storage keys of every record on disk, listed without reading the
+// records when the driver can
+func (pm *PersistenceManager) Keys() (keys []string, err error) {
+	defer pm.observed("keys", time.Now(), &err)
+	if lister, ok := pm.driver.(KeyLister); ok {
+		return lister.Keys()
+	}
+
+	records, err := pm.driver.Load()
+	if err != nil {
+		return nil, err
+	}
+	keys = make([]string, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, record.StorageKey())
+	}
+	return keys, nil
+}
+
 // Save - save all records to disk
 func (pm *PersistenceManager) Save(records []KvRecord) error {
-	pm.logger.Printf("Saving %v records to disk", len(records))
 	for _, record := range records {
-		pm.logger.Print(".")
 		err := pm.driver.Write(record)
 		if err != nil {
 			return err
 		}
 	}
-	pm.logger.Printf("Done saving %v records to disk", len(records))
+	pm.logger.Debug("Saved records to disk", "records", len(records))
+	return nil
+}
+
+// Flush - final sync of all records before shutdown, stopping the manager
+func (pm *PersistenceManager) Flush(records []KvRecord, retain func(key string) bool) error {
+	err := pm.Sync(records, retain)
+	if err != nil {
+		return err
+	}
+	pm.Stop()
 	return nil
 }
 
-// Sync - sync all records to disk
-func (pm *PersistenceManager) Sync(records []KvRecord) error {
-	pm.logger.Println("Syncing records to disk")
+// Sync - sync all records to disk, records on disk only are kept when retain
+// reports their key, so records evicted from memory survive
+func (pm *PersistenceManager) Sync(records []KvRecord, retain func(key string) bool) error {
+	started := time.Now()
+	err := pm.sync(records, retain)
 
-	// 1. Load all records from disk
-	diskRecords, err := pm.driver.Load()
+	pm.mu.Lock()
+	pm.lastSync = SyncResult{At: time.Now(), Duration: time.Since(started), Err: err}
+	pm.mu.Unlock()
+	pm.observed("sync", started, &err)
+	return err
+}
+
+// Helper Functions
+// observed - tells the observer of an operation that started and ended with err
+func (pm *PersistenceManager) observed(operation string, started time.Time, err *error) {
+	if pm.observe != nil {
+		pm.observe(operation, time.Since(started), *err)
+	}
+}
+
+// sync - writes the records and deletes those only on disk
+func (pm *PersistenceManager) sync(records []KvRecord, retain func(key string) bool) error {
+	// 1. List the keys on disk
+	diskKeys, err := pm.Keys()
 	if err != nil {
 		return err
 	}
 
-	// 2. Compare With records, find any records in diskRecords and not in records
+	// 2. Compare With records, find any keys on disk and not in records
+	inMemory := make(map[string]bool, len(records))
+	for _, record := range records {
+		inMemory[record.StorageKey()] = true
+	}
 	delta := []string{}
-	for _, diskRecord := range diskRecords {
-		found := false
-		for _, record := range records {
-			if diskRecord.Key == record.Key {
-				found = true
-				break
-			}
-		}
-		if !found {
-			delta = append(delta, diskRecord.Key)
+	for _, key := range diskKeys {
+		if !inMemory[key] && (retain == nil || !retain(key)) {
+			delta = append(delta, key)
 		}
 	}
 
 	// 3. Delete any records that are not in With records
 	for _, key := range delta {
-		pm.logger.Printf("Deleting record: %v", key)
-		err := pm.driver.Delete(key)
+		pm.logger.Debug("Deleting record", "key", key)
+		err := pm.Delete(key)
 		if err != nil {
 			return err
 		}
@@ -124,28 +275,12 @@ func (pm *PersistenceManager) Sync(records []KvRecord) error {
 
 	// 4. Write All records from records
 	for _, record := range records {
-		pm.logger.Printf("Writing record: %v", record.Key)
 		err := pm.driver.Write(record)
 		if err != nil {
 			return err
 		}
 	}
 
-	// DEBUG - print all records on both sides
-
-	pm.logger.Println("Records on disk:")
-	diskRecord, err := pm.Load()
-	if err != nil {
-		return err
-	}
-	for _, record := range diskRecord {
-		pm.logger.Printf("Key: %v", record.Key)
-	}
-
-	pm.logger.Println("Records in memory:")
-	for _, record := range records {
-		pm.logger.Printf("Key: %v", record.Key)
-	}
-	fmt.Println("=====================================")
+	pm.logger.Debug("Synced records to disk", "written", len(records), "deleted", len(delta))
 	return nil
 }
@@ -2,9 +2,10 @@ package persistence
 
 import (
 	"database/sql"
-	"log"
+	"fmt"
 	"sync"
 
+	"github.com/aawadall/simple-kv/health"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -32,7 +33,7 @@ func (sc *SqliteContainer) ExecuteQuery(query string, args ...interface{}) (*sql
 
 	// inspect args
 	for _, arg := range args {
-		log.Printf("arg: %v (%T)", arg, arg)
+		health.Default().Component("sqlite").Debug("Query argument", "arg", arg, "type", fmt.Sprintf("%T", arg))
 	}
 	// open the database
 	db, err := sql.Open("sqlite3", sc.dbLocation)
@@ -2,11 +2,12 @@ package persistence
 
 import (
 	"database/sql"
-	"log"
-	"os"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/aawadall/simple-kv/health"
 	"github.com/aawadall/simple-kv/types"
-	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -15,7 +16,10 @@ var sqlInit = []string{
 	`CREATE TABLE IF NOT EXISTS records (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		key TEXT UNIQUE,
-		value BLOB
+		tenant TEXT DEFAULT 'default',
+		value BLOB,
+		committedAt TEXT,
+		tombstone INTEGER DEFAULT 0
 	);`,
 	`CREATE TABLE IF NOT EXISTS metadata (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -30,40 +34,76 @@ var sqlInit = []string{
 		key TEXT,
 		version INTEGER,
 		value BLOB,
+		committedAt TEXT,
+		tombstone INTEGER DEFAULT 0,
 		FOREIGN KEY(key) REFERENCES records(key),
 		UNIQUE (key, version)
 	);`,
+	`CREATE TABLE IF NOT EXISTS metadataHistory (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		key TEXT,
+		sequence INTEGER,
+		metadataKey TEXT,
+		metadataValue TEXT,
+		deleted INTEGER,
+		committedAt TEXT,
+		FOREIGN KEY(key) REFERENCES records(key),
+		UNIQUE (key, sequence)
+	);`,
+	`CREATE TABLE IF NOT EXISTS chunks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		key TEXT,
+		blob TEXT,
+		idx INTEGER,
+		data BLOB,
+		UNIQUE (key, blob, idx)
+	);`,
 }
 
-var sqlOperations = map[string]string{
-	"insertRecord":      `INSERT OR REPLACE INTO records (key, value) VALUES (?, ?);`,
-	"insertOldValue":    `INSERT INTO oldValues (key, version, value) VALUES (?, ?, ?);`,
-	"insertMetadata":    `INSERT OR REPLACE INTO metadata (key, metadataKey, metadataValue) VALUES (?, ?, ?);`,
-	"selectRecordByKey": `SELECT key FROM records WHERE key = ?;`,
-	"selectAllRecords":  `SELECT key FROM records;`,
+// migrations for databases created before a column existed,
+// a duplicate column error means the migration was already applied
+var sqlMigrations = []string{
+	`ALTER TABLE records ADD COLUMN committedAt TEXT;`,
+	`ALTER TABLE oldValues ADD COLUMN committedAt TEXT;`,
+	`ALTER TABLE records ADD COLUMN tombstone INTEGER DEFAULT 0;`,
+	`ALTER TABLE oldValues ADD COLUMN tombstone INTEGER DEFAULT 0;`,
+	`ALTER TABLE records ADD COLUMN tenant TEXT DEFAULT 'default';`,
 }
 
-// helper struct
-type Transaction struct {
-	TokenId string
-	Query   string
-	Args    []interface{}
+var sqlOperations = map[string]string{
+	"insertRecord":          `INSERT OR REPLACE INTO records (key, tenant, value, committedAt, tombstone) VALUES (?, ?, ?, ?, ?);`,
+	"insertOldValue":        `INSERT OR REPLACE INTO oldValues (key, version, value, committedAt, tombstone) VALUES (?, ?, ?, ?, ?);`,
+	"insertMetadata":        `INSERT OR REPLACE INTO metadata (key, metadataKey, metadataValue) VALUES (?, ?, ?);`,
+	"insertMetadataHistory": `INSERT OR REPLACE INTO metadataHistory (key, sequence, metadataKey, metadataValue, deleted, committedAt) VALUES (?, ?, ?, ?, ?, ?);`,
+	"selectRecord":          `SELECT value, committedAt, COALESCE(tombstone, 0) FROM records WHERE key = ?;`,
+	"selectOldValues":       `SELECT value, committedAt, COALESCE(tombstone, 0) FROM oldValues WHERE key = ? ORDER BY version;`,
+	"selectMetadata":        `SELECT metadataKey, metadataValue FROM metadata WHERE key = ?;`,
+	"selectMetadataHistory": `SELECT metadataKey, metadataValue, deleted, committedAt FROM metadataHistory WHERE key = ? ORDER BY sequence;`,
+	"selectAllRecords":      `SELECT key FROM records;`,
+	"deleteRecord":          `DELETE FROM records WHERE key = ?;`,
+	"deleteOldValues":       `DELETE FROM oldValues WHERE key = ?;`,
+	"deleteMetadata":        `DELETE FROM metadata WHERE key = ?;`,
+	"deleteMetadataHistory": `DELETE FROM metadataHistory WHERE key = ?;`,
+	"insertChunk":           `INSERT OR REPLACE INTO chunks (key, blob, idx, data) VALUES (?, ?, ?, ?);`,
+	"selectChunk":           `SELECT data FROM chunks WHERE key = ? AND blob = ? AND idx = ?;`,
+	"deleteChunks":          `DELETE FROM chunks WHERE key = ?;`,
+	"deleteBlobChunks":      `DELETE FROM chunks WHERE key = ? AND blob = ?;`,
 }
 
 // SQLite Driver
 type SQLiteDriver struct {
 	dbLocation string
-	logger     *log.Logger
+	logger     *health.Logger
 }
 
 // NewSQLiteDriver - create a new sqlite driver
 func NewSQLiteDriver(dbLocation string) *SQLiteDriver {
 	driver := &SQLiteDriver{
 		dbLocation: dbLocation,
-		logger:     log.New(os.Stdout, "sqlite: ", log.LstdFlags),
+		logger:     health.Default().Component("sqlite"),
 	}
 
-	driver.logger.Printf("Creating SQLite Driver with location: %v", dbLocation)
+	driver.logger.Debug("Creating SQLite Driver", "location", dbLocation)
 
 	// initialize the driver
 	driver.init()
@@ -71,13 +111,18 @@ func NewSQLiteDriver(dbLocation string) *SQLiteDriver {
 	return driver
 }
 
+// SetLogger - set the logger of the driver
+func (driver *SQLiteDriver) SetLogger(logger *health.Logger) {
+	driver.logger = logger
+}
+
 // init - initialize the driver
 func (driver *SQLiteDriver) init() {
 	// open the database
 	db, err := sql.Open("sqlite3", driver.dbLocation)
 
 	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
+		driver.logger.Error("Error opening database", "error", err)
 	}
 
 	defer db.Close()
@@ -87,7 +132,16 @@ func (driver *SQLiteDriver) init() {
 		_, err := db.Exec(query)
 
 		if err != nil {
-			driver.logger.Printf("Error initializing database: %v", err.Error())
+			driver.logger.Error("Error initializing database", "error", err)
+		}
+	}
+
+	// bring older databases up to date
+	for _, query := range sqlMigrations {
+		_, err := db.Exec(query)
+
+		if err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+			driver.logger.Error("Error migrating database", "error", err)
 		}
 	}
 }
@@ -95,69 +149,89 @@ func (driver *SQLiteDriver) init() {
 // Implement the Driver interface
 // Write - write a record to the database
 func (driver *SQLiteDriver) Write(record KvRecord) error {
-	// mementos
-	transactions := []Transaction{}
+	// open the database
+	db, err := sql.Open("sqlite3", driver.dbLocation)
 
-	// insert record
-	transaction, err := driver.insertRecord(&record)
+	if err != nil {
+		driver.logger.Error("Error opening database", "error", err)
+		return err
+	}
 
-	// append the token to the transaction
+	defer db.Close()
 
-	transactions = append(transactions, transaction)
+	// all tables are written in one transaction
+	tx, err := db.Begin()
 	if err != nil {
-		driver.logger.Printf("Error inserting record: %v", err.Error())
-		driver.rollback(transactions)
+		driver.logger.Error("Error starting transaction", "error", err)
 		return err
 	}
 
-	// insert old values
-	old_value_transactions, err := driver.insertOldValues(&record)
-
-	// append old value transactions to the transactions
-	transactions = append(transactions, old_value_transactions...)
+	// insert record
+	err = driver.insertRecord(tx, &record)
+	if err != nil {
+		driver.logger.Error("Error inserting record", "error", err)
+		tx.Rollback()
+		return err
+	}
 
+	// insert old values
+	err = driver.insertOldValues(tx, &record)
 	if err != nil {
-		driver.logger.Printf("Error inserting old values: %v", err.Error())
-		driver.rollback(transactions)
+		driver.logger.Error("Error inserting old values", "error", err)
+		tx.Rollback()
 		return err
 	}
 
 	// insert metadata
-	metadata_transaction, err := driver.insertMetadata(&record)
-	transactions = append(transactions, metadata_transaction...)
+	err = driver.insertMetadata(tx, &record)
 	if err != nil {
-		driver.logger.Printf("Error inserting metadata: %v", err.Error())
-		driver.rollback(transactions)
+		driver.logger.Error("Error inserting metadata", "error", err)
+		tx.Rollback()
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 // Read - read a record from the database
 func (driver *SQLiteDriver) Read(key string) (KvRecord, error) {
+	// open the database
+	db, err := sql.Open("sqlite3", driver.dbLocation)
+
+	if err != nil {
+		driver.logger.Error("Error opening database", "error", err)
+		return KvRecord{}, err
+	}
+
+	defer db.Close()
+
+	// the key column holds storage keys, unique across tenants
+	tenant, recordKey := types.SplitStorageKey(key)
 	record := &KvRecord{
-		Key: key,
+		Tenant:   tenant,
+		Key:      recordKey,
+		Value:    &types.ValuesContainer{},
+		Metadata: types.NewMetadataContainer(),
 	}
 
-	// get the record
-	err := driver.getRecord(record)
+	// get the old values, oldest first
+	err = driver.getOldValues(db, record)
 	if err != nil {
-		driver.logger.Printf("Error getting record: %v", err.Error())
+		driver.logger.Error("Error getting old values", "error", err)
 		return KvRecord{}, err
 	}
 
-	// get the old values
-	err = driver.getOldValues(record)
+	// get the record, its value is the latest version
+	err = driver.getRecord(db, record)
 	if err != nil {
-		driver.logger.Printf("Error getting old values: %v", err.Error())
+		driver.logger.Error("Error getting record", "error", err)
 		return KvRecord{}, err
 	}
 
 	// get the metadata
-	err = driver.getMetadata(record)
+	err = driver.getMetadata(db, record)
 	if err != nil {
-		driver.logger.Printf("Error getting metadata: %v", err.Error())
+		driver.logger.Error("Error getting metadata", "error", err)
 		return KvRecord{}, err
 	}
 
@@ -170,28 +244,101 @@ func (driver *SQLiteDriver) Delete(key string) error {
 	db, err := sql.Open("sqlite3", driver.dbLocation)
 
 	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
+		driver.logger.Error("Error opening database", "error", err)
 		return err
 	}
 
 	defer db.Close()
 
-	// delete the record
-	_, err = db.Exec(sqlOperations["deleteRecord"], key)
+	tx, err := db.Begin()
 	if err != nil {
-		driver.logger.Printf("Error deleting record: %v", err.Error())
+		driver.logger.Error("Error starting transaction", "error", err)
 		return err
 	}
 
-	return nil
+	// delete the record and everything hanging off it
+	for _, operation := range []string{"deleteMetadataHistory", "deleteMetadata", "deleteOldValues", "deleteRecord"} {
+		_, err = tx.Exec(sqlOperations[operation], key)
+		if err != nil {
+			driver.logger.Error("Error deleting record", "error", err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Implement the ChunkDriver interface
+// WriteChunk - write a chunk of a large value to the database
+func (driver *SQLiteDriver) WriteChunk(key string, blob string, index int, data []byte) error {
+	// open the database
+	db, err := sql.Open("sqlite3", driver.dbLocation)
+
+	if err != nil {
+		driver.logger.Error("Error opening database", "error", err)
+		return err
+	}
+
+	defer db.Close()
+
+	_, err = db.Exec(sqlOperations["insertChunk"], key, blob, index, data)
+	if err != nil {
+		driver.logger.Error("Error inserting chunk", "error", err)
+	}
+	return err
+}
+
+// ReadChunk - read a chunk of a large value from the database
+func (driver *SQLiteDriver) ReadChunk(key string, blob string, index int) ([]byte, error) {
+	// open the database
+	db, err := sql.Open("sqlite3", driver.dbLocation)
+
+	if err != nil {
+		driver.logger.Error("Error opening database", "error", err)
+		return nil, err
+	}
+
+	defer db.Close()
+
+	var data []byte
+	err = db.QueryRow(sqlOperations["selectChunk"], key, blob, index).Scan(&data)
+	if err != nil {
+		driver.logger.Error("Error getting chunk", "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// DeleteChunks - delete the chunks of one value, or of every value of a key when blob is empty
+func (driver *SQLiteDriver) DeleteChunks(key string, blob string) error {
+	// open the database
+	db, err := sql.Open("sqlite3", driver.dbLocation)
+
+	if err != nil {
+		driver.logger.Error("Error opening database", "error", err)
+		return err
+	}
+
+	defer db.Close()
+
+	if blob == "" {
+		_, err = db.Exec(sqlOperations["deleteChunks"], key)
+	} else {
+		_, err = db.Exec(sqlOperations["deleteBlobChunks"], key, blob)
+	}
+	if err != nil {
+		driver.logger.Error("Error deleting chunks", "error", err)
+	}
+	return err
 }
 
 // Compare - compare a record to the database
 func (driver *SQLiteDriver) Compare(record KvRecord) (bool, error) {
 	// get the record
-	dbRecord, err := driver.Read(record.Key)
+	dbRecord, err := driver.Read(record.StorageKey())
 	if err != nil {
-		driver.logger.Printf("Error getting record: %v", err.Error())
+		driver.logger.Error("Error getting record", "error", err)
 		return false, err
 	}
 
@@ -199,13 +346,13 @@ func (driver *SQLiteDriver) Compare(record KvRecord) (bool, error) {
 	return matchRecords(record, &dbRecord), nil
 }
 
-// Load - load all records from the database
-func (driver *SQLiteDriver) Load() ([]KvRecord, error) {
+// Keys - list the storage keys of all records in the database
+func (driver *SQLiteDriver) Keys() ([]string, error) {
 	// open the database
 	db, err := sql.Open("sqlite3", driver.dbLocation)
 
 	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
+		driver.logger.Error("Error opening database", "error", err)
 		return nil, err
 	}
 
@@ -214,25 +361,39 @@ func (driver *SQLiteDriver) Load() ([]KvRecord, error) {
 	// get all records
 	rows, err := db.Query(sqlOperations["selectAllRecords"])
 	if err != nil {
-		driver.logger.Printf("Error selecting records: %v", err.Error())
+		driver.logger.Error("Error selecting records", "error", err)
 		return nil, err
 	}
 
 	defer rows.Close()
 
-	// load the records
-	records := []KvRecord{}
+	keys := []string{}
 	for rows.Next() {
 		var key string
 		err := rows.Scan(&key)
 		if err != nil {
-			driver.logger.Printf("Error scanning record: %v", err.Error())
+			driver.logger.Error("Error scanning record", "error", err)
 			return nil, err
 		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
 
+// Load - load all records from the database
+func (driver *SQLiteDriver) Load() ([]KvRecord, error) {
+	// collect the keys first, Read opens its own connection
+	keys, err := driver.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	// load the records
+	records := []KvRecord{}
+	for _, key := range keys {
 		record, err := driver.Read(key)
 		if err != nil {
-			driver.logger.Printf("Error reading record: %v", err.Error())
+			driver.logger.Error("Error reading record", "error", err)
 			return nil, err
 		}
 
@@ -242,265 +403,210 @@ func (driver *SQLiteDriver) Load() ([]KvRecord, error) {
 	return records, nil
 }
 
-// helper functions
-// insertRecord - insert a record into the database
-func (driver *SQLiteDriver) insertRecord(record *KvRecord) (Transaction, error) {
-	value, err := record.Value.Get(-1)
+// Ping - checks the database can be opened
+func (driver *SQLiteDriver) Ping() error {
+	db, err := sql.Open("sqlite3", driver.dbLocation)
 	if err != nil {
-		driver.logger.Printf("Error getting value: %v", err.Error())
-		return Transaction{}, err
+		return err
 	}
+	defer db.Close()
 
-	return driver.insert(sqlOperations["insertRecord"], record.Key, value)
+	return db.Ping()
 }
 
-// insertOldValues - insert old values into the database
-func (driver *SQLiteDriver) insertOldValues(record *KvRecord) ([]Transaction, error) {
-	// get version
-	version := record.Value.GetVersion()
-	transactions := []Transaction{}
-	// insert old values
-	for i := 0; i < version; i++ {
-		value, err := record.Value.Get(i)
-		if err != nil {
-			driver.logger.Printf("Error getting value: %v", err.Error())
-			return []Transaction{}, err
-		}
-		token, err := driver.insert(sqlOperations["insertOldValue"], record.Key, value)
-		if err != nil {
-			driver.logger.Printf("Error inserting old value: %v", err.Error())
-			return []Transaction{}, err
-		}
-		transactions = append(transactions, token)
+// helper functions
+// insertRecord - insert the latest version of a record into the database
+func (driver *SQLiteDriver) insertRecord(tx *sql.Tx, record *KvRecord) error {
+	values, timestamps, tombstones := record.Value.History()
+	if len(values) == 0 {
+		return fmt.Errorf("record %v has no value", record.Key)
 	}
 
-	return transactions, nil
+	last := len(values) - 1
+	_, err := tx.Exec(sqlOperations["insertRecord"], record.StorageKey(), record.TenantName(), values[last], formatTime(timestamps[last]), tombstones[last])
+	return err
 }
 
-// insertMetadata - insert metadata into the database
-func (driver *SQLiteDriver) insertMetadata(record *KvRecord) ([]Transaction, error) {
-	transactions := []Transaction{}
+// insertOldValues - insert all versions but the latest into the database
+func (driver *SQLiteDriver) insertOldValues(tx *sql.Tx, record *KvRecord) error {
+	values, timestamps, tombstones := record.Value.History()
 
-	// insert metadata
-	for key, value := range record.Metadata.GetAll() {
-		byte_value := []byte(value)
-		transaction, err := driver.insert(sqlOperations["insertMetadata"], key, byte_value)
+	// insert old values
+	for version := 0; version < len(values)-1; version++ {
+		_, err := tx.Exec(sqlOperations["insertOldValue"],
+			record.StorageKey(), version, values[version], formatTime(timestamps[version]), tombstones[version])
 		if err != nil {
-			driver.logger.Printf("Error inserting metadata: %v", err.Error())
-			return []Transaction{}, err
+			driver.logger.Error("Error inserting old value", "error", err)
+			return err
 		}
-		transactions = append(transactions, transaction)
 	}
-	return transactions, nil
-}
 
-// insert - insert a record into the database
-func (driver *SQLiteDriver) insert(query string, key string, value []byte) (Transaction, error) {
-	// open the database
-	db, err := sql.Open("sqlite3", driver.dbLocation)
-
-	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
-		return Transaction{}, err
-	}
-
-	defer db.Close()
+	return nil
+}
 
-	// insert the record
-	result, err := db.Exec(query, key, value)
+// insertMetadata - replace the metadata and its history in the database
+func (driver *SQLiteDriver) insertMetadata(tx *sql.Tx, record *KvRecord) error {
+	// deleted metadata keys must not survive the write
+	_, err := tx.Exec(sqlOperations["deleteMetadata"], record.StorageKey())
 	if err != nil {
-		driver.logger.Printf("Error inserting record: %v", err.Error())
-		return Transaction{}, err
+		return err
 	}
 
-	// get the token
-	_, err = result.LastInsertId()
-	if err != nil {
-		driver.logger.Printf("Error getting token: %v", err.Error())
-		return Transaction{}, err
+	// insert metadata
+	for key, value := range record.Metadata.GetAll() {
+		_, err := tx.Exec(sqlOperations["insertMetadata"], record.StorageKey(), key, value)
+		if err != nil {
+			driver.logger.Error("Error inserting metadata", "error", err)
+			return err
+		}
 	}
 
-	token := makeToken()
-
-	transaction := Transaction{
-		TokenId: token,
-		Query:   query,
-		Args:    []interface{}{key, value},
+	// insert metadata history
+	for sequence, change := range record.Metadata.GetHistory() {
+		_, err := tx.Exec(sqlOperations["insertMetadataHistory"],
+			record.StorageKey(), sequence, change.Key, change.Value, change.Deleted, formatTime(change.CommittedAt))
+		if err != nil {
+			driver.logger.Error("Error inserting metadata history", "error", err)
+			return err
+		}
 	}
-	return transaction, nil
-}
-
-// rollback - rollback a transaction
-func (driver *SQLiteDriver) rollback(transactions []Transaction) {
-	// treat transaction as a stack
-	for len(transactions) > 0 {
-		// pop the transaction
-		transaction := transactions[len(transactions)-1]
-
-		// reverse the token
-		driver.reverseTransaction(transaction)
 
-		// remove the token
-		transactions = transactions[:len(transactions)-1]
-	}
+	return nil
 }
 
 // get record
-func (driver *SQLiteDriver) getRecord(key *KvRecord) error {
-	// open the database
-	db, err := sql.Open("sqlite3", driver.dbLocation)
-
+func (driver *SQLiteDriver) getRecord(db *sql.DB, record *KvRecord) error {
+	var value []byte
+	var committedAt sql.NullString
+	var tombstone bool
+	err := db.QueryRow(sqlOperations["selectRecord"], record.StorageKey()).Scan(&value, &committedAt, &tombstone)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("record not found")
+	}
 	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
 		return err
 	}
 
-	defer db.Close()
+	loadVersion(record, value, committedAt, tombstone)
+	return nil
+}
 
-	// get the record
-	rows, err := db.Query(sqlOperations["selectRecord"], key)
+// get old values
+func (driver *SQLiteDriver) getOldValues(db *sql.DB, record *KvRecord) error {
+	rows, err := db.Query(sqlOperations["selectOldValues"], record.StorageKey())
 	if err != nil {
-		driver.logger.Printf("Error selecting record: %v", err.Error())
+		driver.logger.Error("Error selecting old values", "error", err)
 		return err
 	}
 
 	defer rows.Close()
 
-	// load the record
-	record := KvRecord{}
+	// load the versions in order
 	for rows.Next() {
-		var key string
 		var value []byte
-		err := rows.Scan(&key, &value)
+		var committedAt sql.NullString
+		var tombstone bool
+		err := rows.Scan(&value, &committedAt, &tombstone)
 		if err != nil {
-			driver.logger.Printf("Error scanning record: %v", err.Error())
+			driver.logger.Error("Error scanning old values", "error", err)
 			return err
 		}
 
-		record.Key = key
-		record.Value = &types.ValuesContainer{}
-		record.Value.Set(value)
+		loadVersion(record, value, committedAt, tombstone)
 	}
 
-	return nil
+	return rows.Err()
 }
 
-// get old values
-func (driver *SQLiteDriver) getOldValues(key *KvRecord) error {
-	// open the database
-	db, err := sql.Open("sqlite3", driver.dbLocation)
-
-	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
-		return err
-	}
-
-	defer db.Close()
-
-	// get the record
-	args := []string{key.Key, string(key.Value.GetVersion())}
-	rows, err := db.Query(sqlOperations["selectOldValues"], args)
+// get metadata
+func (driver *SQLiteDriver) getMetadata(db *sql.DB, record *KvRecord) error {
+	rows, err := db.Query(sqlOperations["selectMetadata"], record.StorageKey())
 	if err != nil {
-		driver.logger.Printf("Error selecting old values: %v", err.Error())
+		driver.logger.Error("Error selecting metadata", "error", err)
 		return err
 	}
 
-	defer rows.Close()
-
-	// load the record
+	// loading must not append to the change history
 	for rows.Next() {
 		var key string
-		var value []byte
+		var value string
 		err := rows.Scan(&key, &value)
 		if err != nil {
-			driver.logger.Printf("Error scanning old values: %v", err.Error())
+			driver.logger.Error("Error scanning metadata", "error", err)
+			rows.Close()
 			return err
 		}
 
-		record := KvRecord{}
-		record.Key = key
-		record.Value = &types.ValuesContainer{}
-		record.Value.Set(value)
+		record.Metadata.Metadata[key] = value
 	}
+	rows.Close()
 
-	return nil
-}
-
-// get metadata
-func (driver *SQLiteDriver) getMetadata(key *KvRecord) error {
-	// open database
-	db, err := sql.Open("sqlite3", driver.dbLocation)
-
-	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
-		return err
-	}
-
-	defer db.Close()
-
-	// get the record
-	args := []string{key.Key, string(key.Value.GetVersion())}
-	rows, err := db.Query(sqlOperations["selectMetadata"], args)
-
+	rows, err = db.Query(sqlOperations["selectMetadataHistory"], record.StorageKey())
 	if err != nil {
-		driver.logger.Printf("Error selecting metadata: %v", err.Error())
+		driver.logger.Error("Error selecting metadata history", "error", err)
 		return err
 	}
 
 	defer rows.Close()
 
-	// load the record
 	for rows.Next() {
-		var key string
-		var value []byte
-		err := rows.Scan(&key, &value)
+		var change types.MetadataChange
+		var committedAt sql.NullString
+		err := rows.Scan(&change.Key, &change.Value, &change.Deleted, &committedAt)
 		if err != nil {
-			driver.logger.Printf("Error scanning metadata: %v", err.Error())
+			driver.logger.Error("Error scanning metadata history", "error", err)
 			return err
 		}
 
-		record := KvRecord{}
-		record.Key = key
-		record.Value = &types.ValuesContainer{}
-		record.Value.Set(value)
+		change.CommittedAt = parseTime(committedAt)
+		record.Metadata.History = append(record.Metadata.History, change)
 	}
-	return nil
-}
-
 
-// reverse transaction 
-func (driver *SQLiteDriver) reverseTransaction(transaction Transaction) error {
-	// open the database
-	db, err := sql.Open("sqlite3", driver.dbLocation)
-
-	if err != nil {
-		driver.logger.Printf("Error opening database: %v", err.Error())
-		return err
+	// databases written before history was kept, current metadata is all we know
+	if len(record.Metadata.History) == 0 {
+		for key, value := range record.Metadata.Metadata {
+			record.Metadata.History = append(record.Metadata.History, types.MetadataChange{
+				Key:         key,
+				Value:       value,
+				CommittedAt: record.Value.FirstTimestamp(),
+			})
+		}
 	}
 
-	defer db.Close()
+	return rows.Err()
+}
 
-	// reverse the transaction
-	_, err = db.Exec(transaction.Query, transaction.Args...)
-	if err != nil {
-		driver.logger.Printf("Error reversing transaction: %v", err.Error())
-		return err
+// helper functions
+// loadVersion - appends a stored version to the record
+func loadVersion(record *KvRecord, value []byte, committedAt sql.NullString, tombstone bool) {
+	if tombstone {
+		record.Value.SetTombstoneAt(parseTime(committedAt))
+		return
 	}
+	record.Value.SetAt(value, parseTime(committedAt))
+}
 
-	return nil
+// formatTime - timestamps are stored as RFC3339 text
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
 }
 
-// helper functions
-// make token
-func makeToken() string {
-	// UUID
-	uuid := uuid.New()
-	return uuid.String()
+// parseTime - missing or malformed timestamps load as the zero time
+func parseTime(s sql.NullString) time.Time {
+	if !s.Valid {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s.String)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
 }
 
-// match records 
+// match records
 func matchRecords(record1 KvRecord, record2 *KvRecord) bool {
-	if record1.Key != record2.Key {
+	if record1.StorageKey() != record2.StorageKey() {
 		return false
 	}
 
@@ -509,4 +615,4 @@ func matchRecords(record1 KvRecord, record2 *KvRecord) bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
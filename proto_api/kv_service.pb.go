@@ -76,7 +76,9 @@ func (m *KeyValueRecord) GetMetadata() map[string]string {
 }
 
 type GetRequest struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// optional RFC3339 instant for point in time reads
+	AsOf                 string   `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -114,6 +116,13 @@ func (m *GetRequest) GetKey() string {
 	return ""
 }
 
+func (m *GetRequest) GetAsOf() string {
+	if m != nil {
+		return m.AsOf
+	}
+	return ""
+}
+
 type SetRequest struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -459,7 +468,9 @@ func (m *DeleteMetadataResponse) GetResponse() *UniversalResponse {
 }
 
 type GetAllMetadataRequest struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// optional RFC3339 instant for point in time reads
+	AsOf                 string   `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -497,6 +508,13 @@ func (m *GetAllMetadataRequest) GetKey() string {
 	return ""
 }
 
+func (m *GetAllMetadataRequest) GetAsOf() string {
+	if m != nil {
+		return m.AsOf
+	}
+	return ""
+}
+
 type GetAllMetadataResponse struct {
 	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	Metadata             map[string]string  `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -545,7 +563,9 @@ func (m *GetAllMetadataResponse) GetMetadata() map[string]string {
 }
 
 type FindRequest struct {
-	PartialKey           string   `protobuf:"bytes,1,opt,name=partial_key,json=partialKey,proto3" json:"partial_key,omitempty"`
+	PartialKey string `protobuf:"bytes,1,opt,name=partial_key,json=partialKey,proto3" json:"partial_key,omitempty"`
+	// optional RFC3339 instant for point in time reads
+	AsOf                 string   `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -583,6 +603,13 @@ func (m *FindRequest) GetPartialKey() string {
 	return ""
 }
 
+func (m *FindRequest) GetAsOf() string {
+	if m != nil {
+		return m.AsOf
+	}
+	return ""
+}
+
 type FindResponse struct {
 	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
 	Records              []string           `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
@@ -716,6 +743,3055 @@ func (m *FindByMetadataResponse) GetRecords() []string {
 	return nil
 }
 
+type WatchRequest struct {
+	// empty filters match every key
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Prefix        string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MetadataQuery string `protobuf:"bytes,3,opt,name=metadata_query,json=metadataQuery,proto3" json:"metadata_query,omitempty"`
+	// resume from this revision, 0 for live events only
+	FromRevision         uint64   `protobuf:"varint,4,opt,name=from_revision,json=fromRevision,proto3" json:"from_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{16}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetMetadataQuery() string {
+	if m != nil {
+		return m.MetadataQuery
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetFromRevision() uint64 {
+	if m != nil {
+		return m.FromRevision
+	}
+	return 0
+}
+
+type WatchEvent struct {
+	Revision             uint64            `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Key                  string            `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Operation            string            `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Version              int64             `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Value                []byte            `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata             map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timestamp            string            `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *WatchEvent) Reset()         { *m = WatchEvent{} }
+func (m *WatchEvent) String() string { return proto.CompactTextString(m) }
+func (*WatchEvent) ProtoMessage()    {}
+func (*WatchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{17}
+}
+
+func (m *WatchEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEvent.Unmarshal(m, b)
+}
+func (m *WatchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEvent.Marshal(b, m, deterministic)
+}
+func (m *WatchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEvent.Merge(m, src)
+}
+func (m *WatchEvent) XXX_Size() int {
+	return xxx_messageInfo_WatchEvent.Size(m)
+}
+func (m *WatchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEvent proto.InternalMessageInfo
+
+func (m *WatchEvent) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *WatchEvent) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *WatchEvent) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *WatchEvent) GetTimestamp() string {
+	if m != nil {
+		return m.Timestamp
+	}
+	return ""
+}
+
+type IncrementRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// negative to decrement
+	Delta int64 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	// value assumed when the key does not exist yet
+	Initial int64 `protobuf:"varint,3,opt,name=initial,proto3" json:"initial,omitempty"`
+	// inclusive bounds, only applied when the has_ flag is set
+	HasMin               bool     `protobuf:"varint,4,opt,name=has_min,json=hasMin,proto3" json:"has_min,omitempty"`
+	Min                  int64    `protobuf:"varint,5,opt,name=min,proto3" json:"min,omitempty"`
+	HasMax               bool     `protobuf:"varint,6,opt,name=has_max,json=hasMax,proto3" json:"has_max,omitempty"`
+	Max                  int64    `protobuf:"varint,7,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IncrementRequest) Reset()         { *m = IncrementRequest{} }
+func (m *IncrementRequest) String() string { return proto.CompactTextString(m) }
+func (*IncrementRequest) ProtoMessage()    {}
+func (*IncrementRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{18}
+}
+
+func (m *IncrementRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncrementRequest.Unmarshal(m, b)
+}
+func (m *IncrementRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncrementRequest.Marshal(b, m, deterministic)
+}
+func (m *IncrementRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncrementRequest.Merge(m, src)
+}
+func (m *IncrementRequest) XXX_Size() int {
+	return xxx_messageInfo_IncrementRequest.Size(m)
+}
+func (m *IncrementRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncrementRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncrementRequest proto.InternalMessageInfo
+
+func (m *IncrementRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *IncrementRequest) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+func (m *IncrementRequest) GetInitial() int64 {
+	if m != nil {
+		return m.Initial
+	}
+	return 0
+}
+
+func (m *IncrementRequest) GetHasMin() bool {
+	if m != nil {
+		return m.HasMin
+	}
+	return false
+}
+
+func (m *IncrementRequest) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *IncrementRequest) GetHasMax() bool {
+	if m != nil {
+		return m.HasMax
+	}
+	return false
+}
+
+func (m *IncrementRequest) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type IncrementResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Value                int64              `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *IncrementResponse) Reset()         { *m = IncrementResponse{} }
+func (m *IncrementResponse) String() string { return proto.CompactTextString(m) }
+func (*IncrementResponse) ProtoMessage()    {}
+func (*IncrementResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{19}
+}
+
+func (m *IncrementResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncrementResponse.Unmarshal(m, b)
+}
+func (m *IncrementResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncrementResponse.Marshal(b, m, deterministic)
+}
+func (m *IncrementResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncrementResponse.Merge(m, src)
+}
+func (m *IncrementResponse) XXX_Size() int {
+	return xxx_messageInfo_IncrementResponse.Size(m)
+}
+func (m *IncrementResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncrementResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncrementResponse proto.InternalMessageInfo
+
+func (m *IncrementResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *IncrementResponse) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type NextSequenceRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextSequenceRequest) Reset()         { *m = NextSequenceRequest{} }
+func (m *NextSequenceRequest) String() string { return proto.CompactTextString(m) }
+func (*NextSequenceRequest) ProtoMessage()    {}
+func (*NextSequenceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{20}
+}
+
+func (m *NextSequenceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextSequenceRequest.Unmarshal(m, b)
+}
+func (m *NextSequenceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextSequenceRequest.Marshal(b, m, deterministic)
+}
+func (m *NextSequenceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextSequenceRequest.Merge(m, src)
+}
+func (m *NextSequenceRequest) XXX_Size() int {
+	return xxx_messageInfo_NextSequenceRequest.Size(m)
+}
+func (m *NextSequenceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextSequenceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextSequenceRequest proto.InternalMessageInfo
+
+func (m *NextSequenceRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type NextSequenceResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Value                int64              `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *NextSequenceResponse) Reset()         { *m = NextSequenceResponse{} }
+func (m *NextSequenceResponse) String() string { return proto.CompactTextString(m) }
+func (*NextSequenceResponse) ProtoMessage()    {}
+func (*NextSequenceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{21}
+}
+
+func (m *NextSequenceResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextSequenceResponse.Unmarshal(m, b)
+}
+func (m *NextSequenceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextSequenceResponse.Marshal(b, m, deterministic)
+}
+func (m *NextSequenceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextSequenceResponse.Merge(m, src)
+}
+func (m *NextSequenceResponse) XXX_Size() int {
+	return xxx_messageInfo_NextSequenceResponse.Size(m)
+}
+func (m *NextSequenceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextSequenceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextSequenceResponse proto.InternalMessageInfo
+
+func (m *NextSequenceResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *NextSequenceResponse) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ListPushRequest struct {
+	Key    string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// push onto the head instead of the tail
+	Front                bool     `protobuf:"varint,3,opt,name=front,proto3" json:"front,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPushRequest) Reset()         { *m = ListPushRequest{} }
+func (m *ListPushRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPushRequest) ProtoMessage()    {}
+func (*ListPushRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{22}
+}
+
+func (m *ListPushRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPushRequest.Unmarshal(m, b)
+}
+func (m *ListPushRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPushRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPushRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPushRequest.Merge(m, src)
+}
+func (m *ListPushRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPushRequest.Size(m)
+}
+func (m *ListPushRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPushRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPushRequest proto.InternalMessageInfo
+
+func (m *ListPushRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ListPushRequest) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *ListPushRequest) GetFront() bool {
+	if m != nil {
+		return m.Front
+	}
+	return false
+}
+
+type ListPushResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Length               int64              `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListPushResponse) Reset()         { *m = ListPushResponse{} }
+func (m *ListPushResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPushResponse) ProtoMessage()    {}
+func (*ListPushResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{23}
+}
+
+func (m *ListPushResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPushResponse.Unmarshal(m, b)
+}
+func (m *ListPushResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPushResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPushResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPushResponse.Merge(m, src)
+}
+func (m *ListPushResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPushResponse.Size(m)
+}
+func (m *ListPushResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPushResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPushResponse proto.InternalMessageInfo
+
+func (m *ListPushResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ListPushResponse) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type ListPopRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// pop the head instead of the tail
+	Front                bool     `protobuf:"varint,2,opt,name=front,proto3" json:"front,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPopRequest) Reset()         { *m = ListPopRequest{} }
+func (m *ListPopRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPopRequest) ProtoMessage()    {}
+func (*ListPopRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{24}
+}
+
+func (m *ListPopRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPopRequest.Unmarshal(m, b)
+}
+func (m *ListPopRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPopRequest.Marshal(b, m, deterministic)
+}
+func (m *ListPopRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPopRequest.Merge(m, src)
+}
+func (m *ListPopRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPopRequest.Size(m)
+}
+func (m *ListPopRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPopRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPopRequest proto.InternalMessageInfo
+
+func (m *ListPopRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ListPopRequest) GetFront() bool {
+	if m != nil {
+		return m.Front
+	}
+	return false
+}
+
+type ListPopResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Value                string             `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListPopResponse) Reset()         { *m = ListPopResponse{} }
+func (m *ListPopResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPopResponse) ProtoMessage()    {}
+func (*ListPopResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{25}
+}
+
+func (m *ListPopResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPopResponse.Unmarshal(m, b)
+}
+func (m *ListPopResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPopResponse.Marshal(b, m, deterministic)
+}
+func (m *ListPopResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPopResponse.Merge(m, src)
+}
+func (m *ListPopResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPopResponse.Size(m)
+}
+func (m *ListPopResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPopResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPopResponse proto.InternalMessageInfo
+
+func (m *ListPopResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ListPopResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ListRangeRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// inclusive, negative indexes count from the end
+	Start                int64    `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Stop                 int64    `protobuf:"varint,3,opt,name=stop,proto3" json:"stop,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRangeRequest) Reset()         { *m = ListRangeRequest{} }
+func (m *ListRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRangeRequest) ProtoMessage()    {}
+func (*ListRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{26}
+}
+
+func (m *ListRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRangeRequest.Unmarshal(m, b)
+}
+func (m *ListRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRangeRequest.Merge(m, src)
+}
+func (m *ListRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRangeRequest.Size(m)
+}
+func (m *ListRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRangeRequest proto.InternalMessageInfo
+
+func (m *ListRangeRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ListRangeRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ListRangeRequest) GetStop() int64 {
+	if m != nil {
+		return m.Stop
+	}
+	return 0
+}
+
+type ListRangeResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Values               []string           `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListRangeResponse) Reset()         { *m = ListRangeResponse{} }
+func (m *ListRangeResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRangeResponse) ProtoMessage()    {}
+func (*ListRangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{27}
+}
+
+func (m *ListRangeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRangeResponse.Unmarshal(m, b)
+}
+func (m *ListRangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRangeResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRangeResponse.Merge(m, src)
+}
+func (m *ListRangeResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRangeResponse.Size(m)
+}
+func (m *ListRangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRangeResponse proto.InternalMessageInfo
+
+func (m *ListRangeResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ListRangeResponse) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SetAddRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members              []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAddRequest) Reset()         { *m = SetAddRequest{} }
+func (m *SetAddRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAddRequest) ProtoMessage()    {}
+func (*SetAddRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{28}
+}
+
+func (m *SetAddRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddRequest.Unmarshal(m, b)
+}
+func (m *SetAddRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddRequest.Marshal(b, m, deterministic)
+}
+func (m *SetAddRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddRequest.Merge(m, src)
+}
+func (m *SetAddRequest) XXX_Size() int {
+	return xxx_messageInfo_SetAddRequest.Size(m)
+}
+func (m *SetAddRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddRequest proto.InternalMessageInfo
+
+func (m *SetAddRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetAddRequest) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type SetAddResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Added                int64              `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetAddResponse) Reset()         { *m = SetAddResponse{} }
+func (m *SetAddResponse) String() string { return proto.CompactTextString(m) }
+func (*SetAddResponse) ProtoMessage()    {}
+func (*SetAddResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{29}
+}
+
+func (m *SetAddResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAddResponse.Unmarshal(m, b)
+}
+func (m *SetAddResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAddResponse.Marshal(b, m, deterministic)
+}
+func (m *SetAddResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAddResponse.Merge(m, src)
+}
+func (m *SetAddResponse) XXX_Size() int {
+	return xxx_messageInfo_SetAddResponse.Size(m)
+}
+func (m *SetAddResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAddResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAddResponse proto.InternalMessageInfo
+
+func (m *SetAddResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SetAddResponse) GetAdded() int64 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+type SetRemoveRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Members              []string `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetRemoveRequest) Reset()         { *m = SetRemoveRequest{} }
+func (m *SetRemoveRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRemoveRequest) ProtoMessage()    {}
+func (*SetRemoveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{30}
+}
+
+func (m *SetRemoveRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRemoveRequest.Unmarshal(m, b)
+}
+func (m *SetRemoveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRemoveRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRemoveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRemoveRequest.Merge(m, src)
+}
+func (m *SetRemoveRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRemoveRequest.Size(m)
+}
+func (m *SetRemoveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRemoveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRemoveRequest proto.InternalMessageInfo
+
+func (m *SetRemoveRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetRemoveRequest) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type SetRemoveResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Removed              int64              `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetRemoveResponse) Reset()         { *m = SetRemoveResponse{} }
+func (m *SetRemoveResponse) String() string { return proto.CompactTextString(m) }
+func (*SetRemoveResponse) ProtoMessage()    {}
+func (*SetRemoveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{31}
+}
+
+func (m *SetRemoveResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRemoveResponse.Unmarshal(m, b)
+}
+func (m *SetRemoveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRemoveResponse.Marshal(b, m, deterministic)
+}
+func (m *SetRemoveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRemoveResponse.Merge(m, src)
+}
+func (m *SetRemoveResponse) XXX_Size() int {
+	return xxx_messageInfo_SetRemoveResponse.Size(m)
+}
+func (m *SetRemoveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRemoveResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRemoveResponse proto.InternalMessageInfo
+
+func (m *SetRemoveResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SetRemoveResponse) GetRemoved() int64 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+type SetMembersRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMembersRequest) Reset()         { *m = SetMembersRequest{} }
+func (m *SetMembersRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMembersRequest) ProtoMessage()    {}
+func (*SetMembersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{32}
+}
+
+func (m *SetMembersRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMembersRequest.Unmarshal(m, b)
+}
+func (m *SetMembersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMembersRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMembersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMembersRequest.Merge(m, src)
+}
+func (m *SetMembersRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMembersRequest.Size(m)
+}
+func (m *SetMembersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMembersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMembersRequest proto.InternalMessageInfo
+
+func (m *SetMembersRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type SetMembersResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Members              []string           `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetMembersResponse) Reset()         { *m = SetMembersResponse{} }
+func (m *SetMembersResponse) String() string { return proto.CompactTextString(m) }
+func (*SetMembersResponse) ProtoMessage()    {}
+func (*SetMembersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{33}
+}
+
+func (m *SetMembersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMembersResponse.Unmarshal(m, b)
+}
+func (m *SetMembersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMembersResponse.Marshal(b, m, deterministic)
+}
+func (m *SetMembersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMembersResponse.Merge(m, src)
+}
+func (m *SetMembersResponse) XXX_Size() int {
+	return xxx_messageInfo_SetMembersResponse.Size(m)
+}
+func (m *SetMembersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMembersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMembersResponse proto.InternalMessageInfo
+
+func (m *SetMembersResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *SetMembersResponse) GetMembers() []string {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
+
+type HashSetRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Value                string   `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashSetRequest) Reset()         { *m = HashSetRequest{} }
+func (m *HashSetRequest) String() string { return proto.CompactTextString(m) }
+func (*HashSetRequest) ProtoMessage()    {}
+func (*HashSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{34}
+}
+
+func (m *HashSetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashSetRequest.Unmarshal(m, b)
+}
+func (m *HashSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashSetRequest.Marshal(b, m, deterministic)
+}
+func (m *HashSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashSetRequest.Merge(m, src)
+}
+func (m *HashSetRequest) XXX_Size() int {
+	return xxx_messageInfo_HashSetRequest.Size(m)
+}
+func (m *HashSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashSetRequest proto.InternalMessageInfo
+
+func (m *HashSetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *HashSetRequest) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *HashSetRequest) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type HashSetResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HashSetResponse) Reset()         { *m = HashSetResponse{} }
+func (m *HashSetResponse) String() string { return proto.CompactTextString(m) }
+func (*HashSetResponse) ProtoMessage()    {}
+func (*HashSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{35}
+}
+
+func (m *HashSetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashSetResponse.Unmarshal(m, b)
+}
+func (m *HashSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashSetResponse.Marshal(b, m, deterministic)
+}
+func (m *HashSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashSetResponse.Merge(m, src)
+}
+func (m *HashSetResponse) XXX_Size() int {
+	return xxx_messageInfo_HashSetResponse.Size(m)
+}
+func (m *HashSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashSetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashSetResponse proto.InternalMessageInfo
+
+func (m *HashSetResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type HashGetRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashGetRequest) Reset()         { *m = HashGetRequest{} }
+func (m *HashGetRequest) String() string { return proto.CompactTextString(m) }
+func (*HashGetRequest) ProtoMessage()    {}
+func (*HashGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{36}
+}
+
+func (m *HashGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashGetRequest.Unmarshal(m, b)
+}
+func (m *HashGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashGetRequest.Marshal(b, m, deterministic)
+}
+func (m *HashGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashGetRequest.Merge(m, src)
+}
+func (m *HashGetRequest) XXX_Size() int {
+	return xxx_messageInfo_HashGetRequest.Size(m)
+}
+func (m *HashGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashGetRequest proto.InternalMessageInfo
+
+func (m *HashGetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *HashGetRequest) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+type HashGetResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Value                string             `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HashGetResponse) Reset()         { *m = HashGetResponse{} }
+func (m *HashGetResponse) String() string { return proto.CompactTextString(m) }
+func (*HashGetResponse) ProtoMessage()    {}
+func (*HashGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{37}
+}
+
+func (m *HashGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashGetResponse.Unmarshal(m, b)
+}
+func (m *HashGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashGetResponse.Marshal(b, m, deterministic)
+}
+func (m *HashGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashGetResponse.Merge(m, src)
+}
+func (m *HashGetResponse) XXX_Size() int {
+	return xxx_messageInfo_HashGetResponse.Size(m)
+}
+func (m *HashGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashGetResponse proto.InternalMessageInfo
+
+func (m *HashGetResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *HashGetResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type HashDeleteRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Field                string   `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashDeleteRequest) Reset()         { *m = HashDeleteRequest{} }
+func (m *HashDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*HashDeleteRequest) ProtoMessage()    {}
+func (*HashDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{38}
+}
+
+func (m *HashDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashDeleteRequest.Unmarshal(m, b)
+}
+func (m *HashDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *HashDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashDeleteRequest.Merge(m, src)
+}
+func (m *HashDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_HashDeleteRequest.Size(m)
+}
+func (m *HashDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashDeleteRequest proto.InternalMessageInfo
+
+func (m *HashDeleteRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *HashDeleteRequest) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+type HashDeleteResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HashDeleteResponse) Reset()         { *m = HashDeleteResponse{} }
+func (m *HashDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*HashDeleteResponse) ProtoMessage()    {}
+func (*HashDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{39}
+}
+
+func (m *HashDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashDeleteResponse.Unmarshal(m, b)
+}
+func (m *HashDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *HashDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashDeleteResponse.Merge(m, src)
+}
+func (m *HashDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_HashDeleteResponse.Size(m)
+}
+func (m *HashDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashDeleteResponse proto.InternalMessageInfo
+
+func (m *HashDeleteResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type HashGetAllRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashGetAllRequest) Reset()         { *m = HashGetAllRequest{} }
+func (m *HashGetAllRequest) String() string { return proto.CompactTextString(m) }
+func (*HashGetAllRequest) ProtoMessage()    {}
+func (*HashGetAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{40}
+}
+
+func (m *HashGetAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashGetAllRequest.Unmarshal(m, b)
+}
+func (m *HashGetAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashGetAllRequest.Marshal(b, m, deterministic)
+}
+func (m *HashGetAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashGetAllRequest.Merge(m, src)
+}
+func (m *HashGetAllRequest) XXX_Size() int {
+	return xxx_messageInfo_HashGetAllRequest.Size(m)
+}
+func (m *HashGetAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashGetAllRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashGetAllRequest proto.InternalMessageInfo
+
+func (m *HashGetAllRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type HashGetAllResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Fields               map[string]string  `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HashGetAllResponse) Reset()         { *m = HashGetAllResponse{} }
+func (m *HashGetAllResponse) String() string { return proto.CompactTextString(m) }
+func (*HashGetAllResponse) ProtoMessage()    {}
+func (*HashGetAllResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{41}
+}
+
+func (m *HashGetAllResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashGetAllResponse.Unmarshal(m, b)
+}
+func (m *HashGetAllResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashGetAllResponse.Marshal(b, m, deterministic)
+}
+func (m *HashGetAllResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashGetAllResponse.Merge(m, src)
+}
+func (m *HashGetAllResponse) XXX_Size() int {
+	return xxx_messageInfo_HashGetAllResponse.Size(m)
+}
+func (m *HashGetAllResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashGetAllResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashGetAllResponse proto.InternalMessageInfo
+
+func (m *HashGetAllResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *HashGetAllResponse) GetFields() map[string]string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+type SetDocumentRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Document             []byte   `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetDocumentRequest) Reset()         { *m = SetDocumentRequest{} }
+func (m *SetDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDocumentRequest) ProtoMessage()    {}
+func (*SetDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{42}
+}
+
+func (m *SetDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDocumentRequest.Unmarshal(m, b)
+}
+func (m *SetDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *SetDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDocumentRequest.Merge(m, src)
+}
+func (m *SetDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_SetDocumentRequest.Size(m)
+}
+func (m *SetDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDocumentRequest proto.InternalMessageInfo
+
+func (m *SetDocumentRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetDocumentRequest) GetDocument() []byte {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+type SetDocumentResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *SetDocumentResponse) Reset()         { *m = SetDocumentResponse{} }
+func (m *SetDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*SetDocumentResponse) ProtoMessage()    {}
+func (*SetDocumentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{43}
+}
+
+func (m *SetDocumentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetDocumentResponse.Unmarshal(m, b)
+}
+func (m *SetDocumentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetDocumentResponse.Marshal(b, m, deterministic)
+}
+func (m *SetDocumentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDocumentResponse.Merge(m, src)
+}
+func (m *SetDocumentResponse) XXX_Size() int {
+	return xxx_messageInfo_SetDocumentResponse.Size(m)
+}
+func (m *SetDocumentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDocumentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetDocumentResponse proto.InternalMessageInfo
+
+func (m *SetDocumentResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type GetDocumentRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// JSON Pointer or dotted path, empty for the whole document
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetDocumentRequest) Reset()         { *m = GetDocumentRequest{} }
+func (m *GetDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetDocumentRequest) ProtoMessage()    {}
+func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{44}
+}
+
+func (m *GetDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDocumentRequest.Unmarshal(m, b)
+}
+func (m *GetDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *GetDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDocumentRequest.Merge(m, src)
+}
+func (m *GetDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_GetDocumentRequest.Size(m)
+}
+func (m *GetDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDocumentRequest proto.InternalMessageInfo
+
+func (m *GetDocumentRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetDocumentRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type GetDocumentResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Document             []byte             `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *GetDocumentResponse) Reset()         { *m = GetDocumentResponse{} }
+func (m *GetDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*GetDocumentResponse) ProtoMessage()    {}
+func (*GetDocumentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{45}
+}
+
+func (m *GetDocumentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetDocumentResponse.Unmarshal(m, b)
+}
+func (m *GetDocumentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetDocumentResponse.Marshal(b, m, deterministic)
+}
+func (m *GetDocumentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetDocumentResponse.Merge(m, src)
+}
+func (m *GetDocumentResponse) XXX_Size() int {
+	return xxx_messageInfo_GetDocumentResponse.Size(m)
+}
+func (m *GetDocumentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetDocumentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetDocumentResponse proto.InternalMessageInfo
+
+func (m *GetDocumentResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetDocumentResponse) GetDocument() []byte {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+type PatchDocumentRequest struct {
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Patch []byte `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	// RFC 7386 merge patch instead of RFC 6902 JSON Patch
+	Merge                bool     `protobuf:"varint,3,opt,name=merge,proto3" json:"merge,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PatchDocumentRequest) Reset()         { *m = PatchDocumentRequest{} }
+func (m *PatchDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*PatchDocumentRequest) ProtoMessage()    {}
+func (*PatchDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{46}
+}
+
+func (m *PatchDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PatchDocumentRequest.Unmarshal(m, b)
+}
+func (m *PatchDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PatchDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *PatchDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PatchDocumentRequest.Merge(m, src)
+}
+func (m *PatchDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_PatchDocumentRequest.Size(m)
+}
+func (m *PatchDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PatchDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PatchDocumentRequest proto.InternalMessageInfo
+
+func (m *PatchDocumentRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *PatchDocumentRequest) GetPatch() []byte {
+	if m != nil {
+		return m.Patch
+	}
+	return nil
+}
+
+func (m *PatchDocumentRequest) GetMerge() bool {
+	if m != nil {
+		return m.Merge
+	}
+	return false
+}
+
+type PatchDocumentResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Document             []byte             `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PatchDocumentResponse) Reset()         { *m = PatchDocumentResponse{} }
+func (m *PatchDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*PatchDocumentResponse) ProtoMessage()    {}
+func (*PatchDocumentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{47}
+}
+
+func (m *PatchDocumentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PatchDocumentResponse.Unmarshal(m, b)
+}
+func (m *PatchDocumentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PatchDocumentResponse.Marshal(b, m, deterministic)
+}
+func (m *PatchDocumentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PatchDocumentResponse.Merge(m, src)
+}
+func (m *PatchDocumentResponse) XXX_Size() int {
+	return xxx_messageInfo_PatchDocumentResponse.Size(m)
+}
+func (m *PatchDocumentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PatchDocumentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PatchDocumentResponse proto.InternalMessageInfo
+
+func (m *PatchDocumentResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *PatchDocumentResponse) GetDocument() []byte {
+	if m != nil {
+		return m.Document
+	}
+	return nil
+}
+
+type FindByDocumentRequest struct {
+	Query                string   `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindByDocumentRequest) Reset()         { *m = FindByDocumentRequest{} }
+func (m *FindByDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*FindByDocumentRequest) ProtoMessage()    {}
+func (*FindByDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{48}
+}
+
+func (m *FindByDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindByDocumentRequest.Unmarshal(m, b)
+}
+func (m *FindByDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindByDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *FindByDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindByDocumentRequest.Merge(m, src)
+}
+func (m *FindByDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_FindByDocumentRequest.Size(m)
+}
+func (m *FindByDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindByDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindByDocumentRequest proto.InternalMessageInfo
+
+func (m *FindByDocumentRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+type FindByDocumentResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Records              []string           `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FindByDocumentResponse) Reset()         { *m = FindByDocumentResponse{} }
+func (m *FindByDocumentResponse) String() string { return proto.CompactTextString(m) }
+func (*FindByDocumentResponse) ProtoMessage()    {}
+func (*FindByDocumentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{49}
+}
+
+func (m *FindByDocumentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindByDocumentResponse.Unmarshal(m, b)
+}
+func (m *FindByDocumentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindByDocumentResponse.Marshal(b, m, deterministic)
+}
+func (m *FindByDocumentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindByDocumentResponse.Merge(m, src)
+}
+func (m *FindByDocumentResponse) XXX_Size() int {
+	return xxx_messageInfo_FindByDocumentResponse.Size(m)
+}
+func (m *FindByDocumentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindByDocumentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindByDocumentResponse proto.InternalMessageInfo
+
+func (m *FindByDocumentResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *FindByDocumentResponse) GetRecords() []string {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
+type UploadRequest struct {
+	// key is read from the first message, later messages may leave it empty
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UploadRequest) Reset()         { *m = UploadRequest{} }
+func (m *UploadRequest) String() string { return proto.CompactTextString(m) }
+func (*UploadRequest) ProtoMessage()    {}
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{50}
+}
+
+func (m *UploadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadRequest.Unmarshal(m, b)
+}
+func (m *UploadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadRequest.Marshal(b, m, deterministic)
+}
+func (m *UploadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadRequest.Merge(m, src)
+}
+func (m *UploadRequest) XXX_Size() int {
+	return xxx_messageInfo_UploadRequest.Size(m)
+}
+func (m *UploadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadRequest proto.InternalMessageInfo
+
+func (m *UploadRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *UploadRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type UploadResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Size                 int64              `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UploadResponse) Reset()         { *m = UploadResponse{} }
+func (m *UploadResponse) String() string { return proto.CompactTextString(m) }
+func (*UploadResponse) ProtoMessage()    {}
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{51}
+}
+
+func (m *UploadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UploadResponse.Unmarshal(m, b)
+}
+func (m *UploadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UploadResponse.Marshal(b, m, deterministic)
+}
+func (m *UploadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadResponse.Merge(m, src)
+}
+func (m *UploadResponse) XXX_Size() int {
+	return xxx_messageInfo_UploadResponse.Size(m)
+}
+func (m *UploadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadResponse proto.InternalMessageInfo
+
+func (m *UploadResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *UploadResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type DownloadRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadRequest) Reset()         { *m = DownloadRequest{} }
+func (m *DownloadRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadRequest) ProtoMessage()    {}
+func (*DownloadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{52}
+}
+
+func (m *DownloadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadRequest.Unmarshal(m, b)
+}
+func (m *DownloadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadRequest.Merge(m, src)
+}
+func (m *DownloadRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadRequest.Size(m)
+}
+func (m *DownloadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadRequest proto.InternalMessageInfo
+
+func (m *DownloadRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type DownloadResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadResponse) Reset()         { *m = DownloadResponse{} }
+func (m *DownloadResponse) String() string { return proto.CompactTextString(m) }
+func (*DownloadResponse) ProtoMessage()    {}
+func (*DownloadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{53}
+}
+
+func (m *DownloadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadResponse.Unmarshal(m, b)
+}
+func (m *DownloadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadResponse.Marshal(b, m, deterministic)
+}
+func (m *DownloadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadResponse.Merge(m, src)
+}
+func (m *DownloadResponse) XXX_Size() int {
+	return xxx_messageInfo_DownloadResponse.Size(m)
+}
+func (m *DownloadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadResponse proto.InternalMessageInfo
+
+func (m *DownloadResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type LeaseGrantRequest struct {
+	// seconds
+	Ttl                  int64    `protobuf:"varint,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseGrantRequest) Reset()         { *m = LeaseGrantRequest{} }
+func (m *LeaseGrantRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseGrantRequest) ProtoMessage()    {}
+func (*LeaseGrantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{54}
+}
+
+func (m *LeaseGrantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseGrantRequest.Unmarshal(m, b)
+}
+func (m *LeaseGrantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseGrantRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaseGrantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseGrantRequest.Merge(m, src)
+}
+func (m *LeaseGrantRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaseGrantRequest.Size(m)
+}
+func (m *LeaseGrantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseGrantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseGrantRequest proto.InternalMessageInfo
+
+func (m *LeaseGrantRequest) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+type LeaseIdRequest struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseIdRequest) Reset()         { *m = LeaseIdRequest{} }
+func (m *LeaseIdRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseIdRequest) ProtoMessage()    {}
+func (*LeaseIdRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{55}
+}
+
+func (m *LeaseIdRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseIdRequest.Unmarshal(m, b)
+}
+func (m *LeaseIdRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseIdRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaseIdRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseIdRequest.Merge(m, src)
+}
+func (m *LeaseIdRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaseIdRequest.Size(m)
+}
+func (m *LeaseIdRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseIdRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseIdRequest proto.InternalMessageInfo
+
+func (m *LeaseIdRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type LeaseResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Id                   int64              `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Ttl                  int64              `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	ExpiresAt            string             `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Keys                 []string           `protobuf:"bytes,5,rep,name=keys,proto3" json:"keys,omitempty"`
+	Locks                []string           `protobuf:"bytes,6,rep,name=locks,proto3" json:"locks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *LeaseResponse) Reset()         { *m = LeaseResponse{} }
+func (m *LeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseResponse) ProtoMessage()    {}
+func (*LeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{56}
+}
+
+func (m *LeaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseResponse.Unmarshal(m, b)
+}
+func (m *LeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseResponse.Merge(m, src)
+}
+func (m *LeaseResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaseResponse.Size(m)
+}
+func (m *LeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseResponse proto.InternalMessageInfo
+
+func (m *LeaseResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LeaseResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *LeaseResponse) GetTtl() int64 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *LeaseResponse) GetExpiresAt() string {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return ""
+}
+
+func (m *LeaseResponse) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *LeaseResponse) GetLocks() []string {
+	if m != nil {
+		return m.Locks
+	}
+	return nil
+}
+
+type LeaseRevokeResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *LeaseRevokeResponse) Reset()         { *m = LeaseRevokeResponse{} }
+func (m *LeaseRevokeResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseRevokeResponse) ProtoMessage()    {}
+func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{57}
+}
+
+func (m *LeaseRevokeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseRevokeResponse.Unmarshal(m, b)
+}
+func (m *LeaseRevokeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseRevokeResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaseRevokeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseRevokeResponse.Merge(m, src)
+}
+func (m *LeaseRevokeResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaseRevokeResponse.Size(m)
+}
+func (m *LeaseRevokeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseRevokeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseRevokeResponse proto.InternalMessageInfo
+
+func (m *LeaseRevokeResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type LeaseAttachRequest struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Id                   int64    `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseAttachRequest) Reset()         { *m = LeaseAttachRequest{} }
+func (m *LeaseAttachRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaseAttachRequest) ProtoMessage()    {}
+func (*LeaseAttachRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{58}
+}
+
+func (m *LeaseAttachRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseAttachRequest.Unmarshal(m, b)
+}
+func (m *LeaseAttachRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseAttachRequest.Marshal(b, m, deterministic)
+}
+func (m *LeaseAttachRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseAttachRequest.Merge(m, src)
+}
+func (m *LeaseAttachRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaseAttachRequest.Size(m)
+}
+func (m *LeaseAttachRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseAttachRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseAttachRequest proto.InternalMessageInfo
+
+func (m *LeaseAttachRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LeaseAttachRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type LeaseAttachResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *LeaseAttachResponse) Reset()         { *m = LeaseAttachResponse{} }
+func (m *LeaseAttachResponse) String() string { return proto.CompactTextString(m) }
+func (*LeaseAttachResponse) ProtoMessage()    {}
+func (*LeaseAttachResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{59}
+}
+
+func (m *LeaseAttachResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaseAttachResponse.Unmarshal(m, b)
+}
+func (m *LeaseAttachResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaseAttachResponse.Marshal(b, m, deterministic)
+}
+func (m *LeaseAttachResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseAttachResponse.Merge(m, src)
+}
+func (m *LeaseAttachResponse) XXX_Size() int {
+	return xxx_messageInfo_LeaseAttachResponse.Size(m)
+}
+func (m *LeaseAttachResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseAttachResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseAttachResponse proto.InternalMessageInfo
+
+func (m *LeaseAttachResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type LockRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LeaseId              int64    `protobuf:"varint,2,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockRequest) Reset()         { *m = LockRequest{} }
+func (m *LockRequest) String() string { return proto.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()    {}
+func (*LockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{60}
+}
+
+func (m *LockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockRequest.Unmarshal(m, b)
+}
+func (m *LockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockRequest.Marshal(b, m, deterministic)
+}
+func (m *LockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockRequest.Merge(m, src)
+}
+func (m *LockRequest) XXX_Size() int {
+	return xxx_messageInfo_LockRequest.Size(m)
+}
+func (m *LockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockRequest proto.InternalMessageInfo
+
+func (m *LockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LockRequest) GetLeaseId() int64 {
+	if m != nil {
+		return m.LeaseId
+	}
+	return 0
+}
+
+type LockResponse struct {
+	Response *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	// fencing token, grows with every acquisition
+	Token                int64    `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockResponse) Reset()         { *m = LockResponse{} }
+func (m *LockResponse) String() string { return proto.CompactTextString(m) }
+func (*LockResponse) ProtoMessage()    {}
+func (*LockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{61}
+}
+
+func (m *LockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockResponse.Unmarshal(m, b)
+}
+func (m *LockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockResponse.Marshal(b, m, deterministic)
+}
+func (m *LockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockResponse.Merge(m, src)
+}
+func (m *LockResponse) XXX_Size() int {
+	return xxx_messageInfo_LockResponse.Size(m)
+}
+func (m *LockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockResponse proto.InternalMessageInfo
+
+func (m *LockResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *LockResponse) GetToken() int64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+type UnlockRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Token                int64    `protobuf:"varint,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockRequest) Reset()         { *m = UnlockRequest{} }
+func (m *UnlockRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockRequest) ProtoMessage()    {}
+func (*UnlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{62}
+}
+
+func (m *UnlockRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockRequest.Unmarshal(m, b)
+}
+func (m *UnlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockRequest.Merge(m, src)
+}
+func (m *UnlockRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockRequest.Size(m)
+}
+func (m *UnlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockRequest proto.InternalMessageInfo
+
+func (m *UnlockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UnlockRequest) GetToken() int64 {
+	if m != nil {
+		return m.Token
+	}
+	return 0
+}
+
+type UnlockResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *UnlockResponse) Reset()         { *m = UnlockResponse{} }
+func (m *UnlockResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlockResponse) ProtoMessage()    {}
+func (*UnlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{63}
+}
+
+func (m *UnlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockResponse.Unmarshal(m, b)
+}
+func (m *UnlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockResponse.Marshal(b, m, deterministic)
+}
+func (m *UnlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockResponse.Merge(m, src)
+}
+func (m *UnlockResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlockResponse.Size(m)
+}
+func (m *UnlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockResponse proto.InternalMessageInfo
+
+func (m *UnlockResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type BulkDeleteRequest struct {
+	// one of prefix and metadata_query selects the keys
+	Prefix               string   `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MetadataQuery        string   `protobuf:"bytes,2,opt,name=metadata_query,json=metadataQuery,proto3" json:"metadata_query,omitempty"`
+	DryRun               bool     `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkDeleteRequest) Reset()         { *m = BulkDeleteRequest{} }
+func (m *BulkDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkDeleteRequest) ProtoMessage()    {}
+func (*BulkDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{64}
+}
+
+func (m *BulkDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkDeleteRequest.Unmarshal(m, b)
+}
+func (m *BulkDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkDeleteRequest.Merge(m, src)
+}
+func (m *BulkDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkDeleteRequest.Size(m)
+}
+func (m *BulkDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkDeleteRequest proto.InternalMessageInfo
+
+func (m *BulkDeleteRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *BulkDeleteRequest) GetMetadataQuery() string {
+	if m != nil {
+		return m.MetadataQuery
+	}
+	return ""
+}
+
+func (m *BulkDeleteRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type CopyRequest struct {
+	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// source and destination are key prefixes
+	Prefix bool `protobuf:"varint,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// copies every version, renames always do
+	History              bool     `protobuf:"varint,4,opt,name=history,proto3" json:"history,omitempty"`
+	Overwrite            bool     `protobuf:"varint,5,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	DryRun               bool     `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CopyRequest) Reset()         { *m = CopyRequest{} }
+func (m *CopyRequest) String() string { return proto.CompactTextString(m) }
+func (*CopyRequest) ProtoMessage()    {}
+func (*CopyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{65}
+}
+
+func (m *CopyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CopyRequest.Unmarshal(m, b)
+}
+func (m *CopyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CopyRequest.Marshal(b, m, deterministic)
+}
+func (m *CopyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CopyRequest.Merge(m, src)
+}
+func (m *CopyRequest) XXX_Size() int {
+	return xxx_messageInfo_CopyRequest.Size(m)
+}
+func (m *CopyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CopyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CopyRequest proto.InternalMessageInfo
+
+func (m *CopyRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *CopyRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *CopyRequest) GetPrefix() bool {
+	if m != nil {
+		return m.Prefix
+	}
+	return false
+}
+
+func (m *CopyRequest) GetHistory() bool {
+	if m != nil {
+		return m.History
+	}
+	return false
+}
+
+func (m *CopyRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+func (m *CopyRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type BulkResponse struct {
+	Response *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Count    int64              `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Keys     []string           `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	// index aligned with keys for copies and renames
+	Destinations         []string `protobuf:"bytes,4,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	DryRun               bool     `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkResponse) Reset()         { *m = BulkResponse{} }
+func (m *BulkResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkResponse) ProtoMessage()    {}
+func (*BulkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{66}
+}
+
+func (m *BulkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkResponse.Unmarshal(m, b)
+}
+func (m *BulkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkResponse.Merge(m, src)
+}
+func (m *BulkResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkResponse.Size(m)
+}
+func (m *BulkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkResponse proto.InternalMessageInfo
+
+func (m *BulkResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *BulkResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *BulkResponse) GetKeys() []string {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *BulkResponse) GetDestinations() []string {
+	if m != nil {
+		return m.Destinations
+	}
+	return nil
+}
+
+func (m *BulkResponse) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type FeatureFlag struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled     bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// percentage of tenants and subjects, 1 to 99, zero or 100 for everyone
+	Rollout int32 `protobuf:"varint,4,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	// tenants the flag is turned on or off for, ahead of the rollout
+	Tenants              map[string]bool `protobuf:"bytes,5,rep,name=tenants,proto3" json:"tenants,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FeatureFlag) Reset()         { *m = FeatureFlag{} }
+func (m *FeatureFlag) String() string { return proto.CompactTextString(m) }
+func (*FeatureFlag) ProtoMessage()    {}
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{67}
+}
+
+func (m *FeatureFlag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeatureFlag.Unmarshal(m, b)
+}
+func (m *FeatureFlag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeatureFlag.Marshal(b, m, deterministic)
+}
+func (m *FeatureFlag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeatureFlag.Merge(m, src)
+}
+func (m *FeatureFlag) XXX_Size() int {
+	return xxx_messageInfo_FeatureFlag.Size(m)
+}
+func (m *FeatureFlag) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeatureFlag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeatureFlag proto.InternalMessageInfo
+
+func (m *FeatureFlag) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FeatureFlag) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FeatureFlag) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *FeatureFlag) GetRollout() int32 {
+	if m != nil {
+		return m.Rollout
+	}
+	return 0
+}
+
+func (m *FeatureFlag) GetTenants() map[string]bool {
+	if m != nil {
+		return m.Tenants
+	}
+	return nil
+}
+
+type ListFlagsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFlagsRequest) Reset()         { *m = ListFlagsRequest{} }
+func (m *ListFlagsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFlagsRequest) ProtoMessage()    {}
+func (*ListFlagsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{68}
+}
+
+func (m *ListFlagsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFlagsRequest.Unmarshal(m, b)
+}
+func (m *ListFlagsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFlagsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFlagsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFlagsRequest.Merge(m, src)
+}
+func (m *ListFlagsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFlagsRequest.Size(m)
+}
+func (m *ListFlagsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFlagsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFlagsRequest proto.InternalMessageInfo
+
+type ListFlagsResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Flags                []*FeatureFlag     `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListFlagsResponse) Reset()         { *m = ListFlagsResponse{} }
+func (m *ListFlagsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFlagsResponse) ProtoMessage()    {}
+func (*ListFlagsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{69}
+}
+
+func (m *ListFlagsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFlagsResponse.Unmarshal(m, b)
+}
+func (m *ListFlagsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFlagsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFlagsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFlagsResponse.Merge(m, src)
+}
+func (m *ListFlagsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFlagsResponse.Size(m)
+}
+func (m *ListFlagsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFlagsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFlagsResponse proto.InternalMessageInfo
+
+func (m *ListFlagsResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ListFlagsResponse) GetFlags() []*FeatureFlag {
+	if m != nil {
+		return m.Flags
+	}
+	return nil
+}
+
+type FlagNameRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlagNameRequest) Reset()         { *m = FlagNameRequest{} }
+func (m *FlagNameRequest) String() string { return proto.CompactTextString(m) }
+func (*FlagNameRequest) ProtoMessage()    {}
+func (*FlagNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{70}
+}
+
+func (m *FlagNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlagNameRequest.Unmarshal(m, b)
+}
+func (m *FlagNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlagNameRequest.Marshal(b, m, deterministic)
+}
+func (m *FlagNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlagNameRequest.Merge(m, src)
+}
+func (m *FlagNameRequest) XXX_Size() int {
+	return xxx_messageInfo_FlagNameRequest.Size(m)
+}
+func (m *FlagNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlagNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlagNameRequest proto.InternalMessageInfo
+
+func (m *FlagNameRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type FlagResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Flag                 *FeatureFlag       `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *FlagResponse) Reset()         { *m = FlagResponse{} }
+func (m *FlagResponse) String() string { return proto.CompactTextString(m) }
+func (*FlagResponse) ProtoMessage()    {}
+func (*FlagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{71}
+}
+
+func (m *FlagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlagResponse.Unmarshal(m, b)
+}
+func (m *FlagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlagResponse.Marshal(b, m, deterministic)
+}
+func (m *FlagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlagResponse.Merge(m, src)
+}
+func (m *FlagResponse) XXX_Size() int {
+	return xxx_messageInfo_FlagResponse.Size(m)
+}
+func (m *FlagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlagResponse proto.InternalMessageInfo
+
+func (m *FlagResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *FlagResponse) GetFlag() *FeatureFlag {
+	if m != nil {
+		return m.Flag
+	}
+	return nil
+}
+
+type DeleteFlagResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DeleteFlagResponse) Reset()         { *m = DeleteFlagResponse{} }
+func (m *DeleteFlagResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFlagResponse) ProtoMessage()    {}
+func (*DeleteFlagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{72}
+}
+
+func (m *DeleteFlagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFlagResponse.Unmarshal(m, b)
+}
+func (m *DeleteFlagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFlagResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteFlagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFlagResponse.Merge(m, src)
+}
+func (m *DeleteFlagResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFlagResponse.Size(m)
+}
+func (m *DeleteFlagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFlagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFlagResponse proto.InternalMessageInfo
+
+func (m *DeleteFlagResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type EvaluateFlagRequest struct {
+	// evaluated for the tenant of the request
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Subject              string   `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateFlagRequest) Reset()         { *m = EvaluateFlagRequest{} }
+func (m *EvaluateFlagRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFlagRequest) ProtoMessage()    {}
+func (*EvaluateFlagRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{73}
+}
+
+func (m *EvaluateFlagRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateFlagRequest.Unmarshal(m, b)
+}
+func (m *EvaluateFlagRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateFlagRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateFlagRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFlagRequest.Merge(m, src)
+}
+func (m *EvaluateFlagRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateFlagRequest.Size(m)
+}
+func (m *EvaluateFlagRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFlagRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateFlagRequest proto.InternalMessageInfo
+
+func (m *EvaluateFlagRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EvaluateFlagRequest) GetSubject() string {
+	if m != nil {
+		return m.Subject
+	}
+	return ""
+}
+
+type EvaluateFlagResponse struct {
+	Response             *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Enabled              bool               `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EvaluateFlagResponse) Reset()         { *m = EvaluateFlagResponse{} }
+func (m *EvaluateFlagResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateFlagResponse) ProtoMessage()    {}
+func (*EvaluateFlagResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{74}
+}
+
+func (m *EvaluateFlagResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateFlagResponse.Unmarshal(m, b)
+}
+func (m *EvaluateFlagResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateFlagResponse.Marshal(b, m, deterministic)
+}
+func (m *EvaluateFlagResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateFlagResponse.Merge(m, src)
+}
+func (m *EvaluateFlagResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateFlagResponse.Size(m)
+}
+func (m *EvaluateFlagResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateFlagResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateFlagResponse proto.InternalMessageInfo
+
+func (m *EvaluateFlagResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *EvaluateFlagResponse) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type ConfigSetting struct {
+	// dotted name of the setting
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// redacted when secret
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Source               string   `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Secret               bool     `protobuf:"varint,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigSetting) Reset()         { *m = ConfigSetting{} }
+func (m *ConfigSetting) String() string { return proto.CompactTextString(m) }
+func (*ConfigSetting) ProtoMessage()    {}
+func (*ConfigSetting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{75}
+}
+
+func (m *ConfigSetting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfigSetting.Unmarshal(m, b)
+}
+func (m *ConfigSetting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfigSetting.Marshal(b, m, deterministic)
+}
+func (m *ConfigSetting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigSetting.Merge(m, src)
+}
+func (m *ConfigSetting) XXX_Size() int {
+	return xxx_messageInfo_ConfigSetting.Size(m)
+}
+func (m *ConfigSetting) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigSetting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigSetting proto.InternalMessageInfo
+
+func (m *ConfigSetting) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConfigSetting) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *ConfigSetting) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *ConfigSetting) GetSecret() bool {
+	if m != nil {
+		return m.Secret
+	}
+	return false
+}
+
+type GetConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()    {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{76}
+}
+
+func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
+}
+func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(m, src)
+}
+func (m *GetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRequest.Size(m)
+}
+func (m *GetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRequest proto.InternalMessageInfo
+
+type GetConfigResponse struct {
+	Response *UniversalResponse `protobuf:"bytes,1,opt,name=response,proto3" json:"response,omitempty"`
+	Settings []*ConfigSetting   `protobuf:"bytes,2,rep,name=settings,proto3" json:"settings,omitempty"`
+	// settings changed since the server started that wait for a restart
+	Pending              []string `protobuf:"bytes,3,rep,name=pending,proto3" json:"pending,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigResponse) Reset()         { *m = GetConfigResponse{} }
+func (m *GetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*GetConfigResponse) ProtoMessage()    {}
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2489677d3d3be1b1, []int{77}
+}
+
+func (m *GetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigResponse.Unmarshal(m, b)
+}
+func (m *GetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *GetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigResponse.Merge(m, src)
+}
+func (m *GetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_GetConfigResponse.Size(m)
+}
+func (m *GetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigResponse proto.InternalMessageInfo
+
+func (m *GetConfigResponse) GetResponse() *UniversalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetSettings() []*ConfigSetting {
+	if m != nil {
+		return m.Settings
+	}
+	return nil
+}
+
+func (m *GetConfigResponse) GetPending() []string {
+	if m != nil {
+		return m.Pending
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*KeyValueRecord)(nil), "proto_api.KeyValueRecord")
 	proto.RegisterMapType((map[string]string)(nil), "proto_api.KeyValueRecord.MetadataEntry")
@@ -735,46 +3811,225 @@ func init() {
 	proto.RegisterType((*FindResponse)(nil), "proto_api.FindResponse")
 	proto.RegisterType((*FindByMetadataRequest)(nil), "proto_api.FindByMetadataRequest")
 	proto.RegisterType((*FindByMetadataResponse)(nil), "proto_api.FindByMetadataResponse")
+	proto.RegisterType((*WatchRequest)(nil), "proto_api.WatchRequest")
+	proto.RegisterType((*WatchEvent)(nil), "proto_api.WatchEvent")
+	proto.RegisterMapType((map[string]string)(nil), "proto_api.WatchEvent.MetadataEntry")
+	proto.RegisterType((*IncrementRequest)(nil), "proto_api.IncrementRequest")
+	proto.RegisterType((*IncrementResponse)(nil), "proto_api.IncrementResponse")
+	proto.RegisterType((*NextSequenceRequest)(nil), "proto_api.NextSequenceRequest")
+	proto.RegisterType((*NextSequenceResponse)(nil), "proto_api.NextSequenceResponse")
+	proto.RegisterType((*ListPushRequest)(nil), "proto_api.ListPushRequest")
+	proto.RegisterType((*ListPushResponse)(nil), "proto_api.ListPushResponse")
+	proto.RegisterType((*ListPopRequest)(nil), "proto_api.ListPopRequest")
+	proto.RegisterType((*ListPopResponse)(nil), "proto_api.ListPopResponse")
+	proto.RegisterType((*ListRangeRequest)(nil), "proto_api.ListRangeRequest")
+	proto.RegisterType((*ListRangeResponse)(nil), "proto_api.ListRangeResponse")
+	proto.RegisterType((*SetAddRequest)(nil), "proto_api.SetAddRequest")
+	proto.RegisterType((*SetAddResponse)(nil), "proto_api.SetAddResponse")
+	proto.RegisterType((*SetRemoveRequest)(nil), "proto_api.SetRemoveRequest")
+	proto.RegisterType((*SetRemoveResponse)(nil), "proto_api.SetRemoveResponse")
+	proto.RegisterType((*SetMembersRequest)(nil), "proto_api.SetMembersRequest")
+	proto.RegisterType((*SetMembersResponse)(nil), "proto_api.SetMembersResponse")
+	proto.RegisterType((*HashSetRequest)(nil), "proto_api.HashSetRequest")
+	proto.RegisterType((*HashSetResponse)(nil), "proto_api.HashSetResponse")
+	proto.RegisterType((*HashGetRequest)(nil), "proto_api.HashGetRequest")
+	proto.RegisterType((*HashGetResponse)(nil), "proto_api.HashGetResponse")
+	proto.RegisterType((*HashDeleteRequest)(nil), "proto_api.HashDeleteRequest")
+	proto.RegisterType((*HashDeleteResponse)(nil), "proto_api.HashDeleteResponse")
+	proto.RegisterType((*HashGetAllRequest)(nil), "proto_api.HashGetAllRequest")
+	proto.RegisterType((*HashGetAllResponse)(nil), "proto_api.HashGetAllResponse")
+	proto.RegisterMapType((map[string]string)(nil), "proto_api.HashGetAllResponse.FieldsEntry")
+	proto.RegisterType((*SetDocumentRequest)(nil), "proto_api.SetDocumentRequest")
+	proto.RegisterType((*SetDocumentResponse)(nil), "proto_api.SetDocumentResponse")
+	proto.RegisterType((*GetDocumentRequest)(nil), "proto_api.GetDocumentRequest")
+	proto.RegisterType((*GetDocumentResponse)(nil), "proto_api.GetDocumentResponse")
+	proto.RegisterType((*PatchDocumentRequest)(nil), "proto_api.PatchDocumentRequest")
+	proto.RegisterType((*PatchDocumentResponse)(nil), "proto_api.PatchDocumentResponse")
+	proto.RegisterType((*FindByDocumentRequest)(nil), "proto_api.FindByDocumentRequest")
+	proto.RegisterType((*FindByDocumentResponse)(nil), "proto_api.FindByDocumentResponse")
+	proto.RegisterType((*UploadRequest)(nil), "proto_api.UploadRequest")
+	proto.RegisterType((*UploadResponse)(nil), "proto_api.UploadResponse")
+	proto.RegisterType((*DownloadRequest)(nil), "proto_api.DownloadRequest")
+	proto.RegisterType((*DownloadResponse)(nil), "proto_api.DownloadResponse")
+	proto.RegisterType((*LeaseGrantRequest)(nil), "proto_api.LeaseGrantRequest")
+	proto.RegisterType((*LeaseIdRequest)(nil), "proto_api.LeaseIdRequest")
+	proto.RegisterType((*LeaseResponse)(nil), "proto_api.LeaseResponse")
+	proto.RegisterType((*LeaseRevokeResponse)(nil), "proto_api.LeaseRevokeResponse")
+	proto.RegisterType((*LeaseAttachRequest)(nil), "proto_api.LeaseAttachRequest")
+	proto.RegisterType((*LeaseAttachResponse)(nil), "proto_api.LeaseAttachResponse")
+	proto.RegisterType((*LockRequest)(nil), "proto_api.LockRequest")
+	proto.RegisterType((*LockResponse)(nil), "proto_api.LockResponse")
+	proto.RegisterType((*UnlockRequest)(nil), "proto_api.UnlockRequest")
+	proto.RegisterType((*UnlockResponse)(nil), "proto_api.UnlockResponse")
+	proto.RegisterType((*BulkDeleteRequest)(nil), "proto_api.BulkDeleteRequest")
+	proto.RegisterType((*CopyRequest)(nil), "proto_api.CopyRequest")
+	proto.RegisterType((*BulkResponse)(nil), "proto_api.BulkResponse")
+	proto.RegisterType((*FeatureFlag)(nil), "proto_api.FeatureFlag")
+	proto.RegisterMapType((map[string]bool)(nil), "proto_api.FeatureFlag.TenantsEntry")
+	proto.RegisterType((*ListFlagsRequest)(nil), "proto_api.ListFlagsRequest")
+	proto.RegisterType((*ListFlagsResponse)(nil), "proto_api.ListFlagsResponse")
+	proto.RegisterType((*FlagNameRequest)(nil), "proto_api.FlagNameRequest")
+	proto.RegisterType((*FlagResponse)(nil), "proto_api.FlagResponse")
+	proto.RegisterType((*DeleteFlagResponse)(nil), "proto_api.DeleteFlagResponse")
+	proto.RegisterType((*EvaluateFlagRequest)(nil), "proto_api.EvaluateFlagRequest")
+	proto.RegisterType((*EvaluateFlagResponse)(nil), "proto_api.EvaluateFlagResponse")
+	proto.RegisterType((*ConfigSetting)(nil), "proto_api.ConfigSetting")
+	proto.RegisterType((*GetConfigRequest)(nil), "proto_api.GetConfigRequest")
+	proto.RegisterType((*GetConfigResponse)(nil), "proto_api.GetConfigResponse")
 }
 
 func init() { proto.RegisterFile("kv_service.proto", fileDescriptor_2489677d3d3be1b1) }
 
 var fileDescriptor_2489677d3d3be1b1 = []byte{
-	// 575 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
-	0x14, 0x8d, 0xe3, 0xb4, 0x34, 0xd7, 0x49, 0xa8, 0x86, 0x26, 0x18, 0x0b, 0x4a, 0x32, 0x12, 0x22,
-	0x2c, 0x70, 0xa5, 0x80, 0x50, 0x0b, 0x0b, 0x44, 0x79, 0x44, 0x22, 0x3c, 0x24, 0x47, 0x80, 0xc4,
-	0x26, 0x72, 0x93, 0xbb, 0xb0, 0xea, 0xd8, 0xae, 0x3d, 0x89, 0xe4, 0x5f, 0xe3, 0x57, 0xe0, 0x63,
-	0x90, 0x1f, 0xe3, 0x78, 0x1c, 0xa7, 0x2c, 0x4c, 0x57, 0xf6, 0xcc, 0x9c, 0x7b, 0xcf, 0xb9, 0x4f,
-	0x38, 0xbc, 0x5c, 0xcf, 0x02, 0xf4, 0xd7, 0xd6, 0x1c, 0x75, 0xcf, 0x77, 0x99, 0x4b, 0x9a, 0xf1,
-	0x67, 0x66, 0x7a, 0x96, 0xd6, 0x9a, 0xbb, 0xcb, 0xa5, 0xeb, 0x24, 0x0f, 0xf4, 0x97, 0x04, 0x9d,
-	0x09, 0x86, 0xdf, 0x4d, 0x7b, 0x85, 0x06, 0xce, 0x5d, 0x7f, 0x41, 0x0e, 0x41, 0xbe, 0xc4, 0x50,
-	0xad, 0xf7, 0xa5, 0x61, 0xd3, 0x88, 0x7e, 0xc9, 0x11, 0xec, 0xad, 0x23, 0x80, 0x2a, 0xf7, 0xa5,
-	0x61, 0xcb, 0x48, 0x0e, 0xe4, 0x2d, 0x1c, 0x2c, 0x91, 0x99, 0x0b, 0x93, 0x99, 0x6a, 0xa3, 0x2f,
-	0x0f, 0x95, 0xd1, 0x63, 0x3d, 0xa3, 0xd1, 0x45, 0xa7, 0xfa, 0xe7, 0x14, 0xf9, 0xde, 0x61, 0x7e,
-	0x68, 0x64, 0x86, 0xda, 0x2b, 0x68, 0x0b, 0x4f, 0x9c, 0x5d, 0x2a, 0x61, 0x4f, 0x14, 0x25, 0x87,
-	0x97, 0xf5, 0x53, 0x89, 0x1e, 0x03, 0x8c, 0x91, 0x19, 0x78, 0xb5, 0xc2, 0x80, 0x6d, 0x5b, 0xd2,
-	0xe7, 0x00, 0xd3, 0x6b, 0xde, 0x45, 0xcf, 0x3c, 0x2e, 0x3a, 0x06, 0x25, 0xb6, 0x0a, 0x3c, 0xd7,
-	0x09, 0x90, 0x9c, 0xc2, 0x81, 0x9f, 0xfe, 0xc7, 0xb6, 0xca, 0xe8, 0x7e, 0x2e, 0xcc, 0x6f, 0x8e,
-	0xb5, 0x46, 0x3f, 0x30, 0x6d, 0x8e, 0x37, 0x32, 0x34, 0x1d, 0x40, 0xfb, 0x1d, 0xda, 0xc8, 0x70,
-	0xb7, 0xc2, 0x8f, 0xd0, 0xe1, 0x90, 0xca, 0x74, 0x1e, 0x90, 0x29, 0x32, 0x9e, 0xcd, 0xdd, 0x51,
-	0x0f, 0xa0, 0xc5, 0xd3, 0x3f, 0xdb, 0x14, 0x5a, 0xe1, 0x77, 0x13, 0x0c, 0xc9, 0x23, 0xe8, 0x64,
-	0x90, 0x4d, 0xe5, 0x9b, 0x46, 0x9b, 0xdf, 0xc6, 0xa5, 0xa5, 0x5f, 0xe1, 0x8e, 0xc0, 0x58, 0x39,
-	0x84, 0x4f, 0xd0, 0x4d, 0xd2, 0xf1, 0x3f, 0xa2, 0xa0, 0x06, 0xf4, 0x8a, 0xde, 0x2a, 0x2b, 0x7c,
-	0x02, 0xdd, 0x31, 0xb2, 0x37, 0xb6, 0xfd, 0x4f, 0x85, 0xf4, 0x8f, 0x04, 0xbd, 0x22, 0xb6, 0x2a,
-	0x3f, 0x99, 0xe4, 0x86, 0xae, 0x1e, 0x0f, 0xdd, 0x49, 0xce, 0xb2, 0x9c, 0xee, 0x66, 0x86, 0x4f,
-	0x07, 0xe5, 0x83, 0xe5, 0x2c, 0x78, 0xfc, 0x0f, 0x41, 0xf1, 0x4c, 0x9f, 0x59, 0xa6, 0x3d, 0xdb,
-	0xb8, 0x80, 0xf4, 0x2a, 0xaa, 0xc6, 0x05, 0xb4, 0x12, 0x7c, 0xe5, 0x1c, 0xa8, 0x70, 0xcb, 0x8f,
-	0xb7, 0x4a, 0x10, 0xa7, 0xa0, 0x69, 0xf0, 0x23, 0x7d, 0x0a, 0xdd, 0x88, 0xe3, 0x3c, 0x2c, 0x56,
-	0xe7, 0x08, 0xf6, 0xae, 0x56, 0xe8, 0x73, 0x5d, 0xc9, 0x81, 0xda, 0xd0, 0x2b, 0xc2, 0x6f, 0x4e,
-	0xdc, 0xe8, 0x77, 0x03, 0x6e, 0xf3, 0xad, 0x38, 0x4d, 0xb6, 0x33, 0x39, 0x03, 0x79, 0x8c, 0x8c,
-	0x74, 0xc5, 0x1a, 0xa6, 0xaa, 0xb5, 0x7b, 0x3b, 0xf7, 0x29, 0xad, 0x91, 0x17, 0x20, 0x4f, 0x0b,
-	0xa6, 0x9b, 0x65, 0xa7, 0xf5, 0x8a, 0xd7, 0x69, 0xff, 0xd6, 0xc8, 0x6b, 0xd8, 0x4f, 0xa6, 0x82,
-	0xa8, 0x39, 0x8c, 0xb0, 0xa8, 0x04, 0x62, 0x71, 0x3f, 0xd1, 0x1a, 0xf9, 0x12, 0xef, 0x47, 0x9e,
-	0x32, 0xf2, 0x40, 0x64, 0x2a, 0x64, 0x5e, 0x3b, 0xde, 0xf5, 0x9c, 0xf9, 0xfb, 0xc1, 0x77, 0x60,
-	0xe6, 0xb2, 0xbf, 0x45, 0x5f, 0xf4, 0x3a, 0xb8, 0x06, 0x91, 0x77, 0x2c, 0x0e, 0x84, 0xe0, 0xb8,
-	0x74, 0x8c, 0x05, 0xc7, 0xe5, 0xd3, 0x44, 0x6b, 0xe4, 0x0c, 0x1a, 0x51, 0xdf, 0x90, 0x7c, 0x92,
-	0x73, 0xb3, 0xa0, 0xdd, 0xdd, 0xba, 0xcf, 0x6b, 0x12, 0x5b, 0x4e, 0xd0, 0x54, 0xda, 0xbc, 0x82,
-	0xa6, 0xf2, 0x7e, 0xa5, 0xb5, 0xf3, 0xf6, 0x4f, 0x45, 0x3f, 0xc9, 0x70, 0x17, 0xfb, 0xf1, 0xef,
-	0xb3, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xeb, 0x68, 0x12, 0x5b, 0x0b, 0x08, 0x00, 0x00,
+	// 2393 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xdb, 0x76, 0xdb, 0xc6,
+	0xd5, 0x36, 0x44, 0xf1, 0xb4, 0x49, 0xea, 0x30, 0xd6, 0x81, 0x82, 0x25, 0x5b, 0x82, 0x97, 0xff,
+	0xf8, 0xef, 0x6a, 0x95, 0x2c, 0x27, 0xcd, 0xf2, 0x21, 0x4d, 0x2a, 0x9f, 0x68, 0x5b, 0xb6, 0x12,
+	0x43, 0x76, 0xb3, 0x56, 0x2f, 0xa2, 0xc2, 0xe4, 0x48, 0x42, 0x04, 0x02, 0x0c, 0x30, 0x54, 0xc4,
+	0xf6, 0xb6, 0xaf, 0xd1, 0xbb, 0x5e, 0xf5, 0xa6, 0xbd, 0xe8, 0x55, 0x57, 0x1f, 0xa1, 0x8f, 0xd0,
+	0xa7, 0xe8, 0x13, 0x74, 0xcd, 0x91, 0x33, 0x20, 0x00, 0xd5, 0x21, 0x7d, 0x45, 0xee, 0x99, 0x3d,
+	0xdf, 0x7c, 0x7b, 0xcf, 0x9e, 0xc3, 0xde, 0x80, 0xa5, 0xb3, 0xf3, 0xa3, 0x04, 0xc7, 0xe7, 0x7e,
+	0x17, 0xef, 0x0e, 0xe2, 0x88, 0x44, 0xa8, 0xce, 0x7e, 0x8e, 0xbc, 0x81, 0x6f, 0x37, 0xbb, 0x51,
+	0xbf, 0x1f, 0x85, 0xbc, 0xc3, 0xf9, 0x87, 0x05, 0x0b, 0xfb, 0x78, 0xf4, 0x1b, 0x2f, 0x18, 0x62,
+	0x17, 0x77, 0xa3, 0xb8, 0x87, 0x96, 0xa0, 0x74, 0x86, 0x47, 0xed, 0xb9, 0x6d, 0xeb, 0x76, 0xdd,
+	0xa5, 0x7f, 0xd1, 0x0a, 0x94, 0xcf, 0xa9, 0x42, 0xbb, 0xb4, 0x6d, 0xdd, 0x6e, 0xba, 0x5c, 0x40,
+	0x8f, 0xa0, 0xd6, 0xc7, 0xc4, 0xeb, 0x79, 0xc4, 0x6b, 0xcf, 0x6f, 0x97, 0x6e, 0x37, 0xee, 0x7c,
+	0xb4, 0xab, 0xa6, 0xd9, 0x35, 0x41, 0x77, 0x5f, 0x09, 0xcd, 0x27, 0x21, 0x89, 0x47, 0xae, 0x1a,
+	0x68, 0x3f, 0x80, 0x96, 0xd1, 0x25, 0x67, 0xb7, 0x32, 0x66, 0xe7, 0x8c, 0xb8, 0x70, 0x7f, 0xee,
+	0xae, 0xe5, 0x7c, 0x0a, 0xd0, 0xc1, 0xc4, 0xc5, 0x3f, 0x0c, 0x71, 0x42, 0x32, 0x46, 0x5e, 0x85,
+	0xb2, 0x97, 0x1c, 0x45, 0xc7, 0x62, 0xe4, 0xbc, 0x97, 0x7c, 0x7d, 0xec, 0x7c, 0x06, 0x70, 0x58,
+	0x34, 0xc8, 0x98, 0x4e, 0x1a, 0xeb, 0x74, 0xa0, 0xc1, 0x46, 0x25, 0x83, 0x28, 0x4c, 0x30, 0xba,
+	0x0b, 0xb5, 0x58, 0xfc, 0x67, 0x63, 0x1b, 0x77, 0x36, 0x35, 0xdb, 0xdf, 0x86, 0xfe, 0x39, 0x8e,
+	0x13, 0x2f, 0x90, 0xfa, 0xae, 0xd2, 0x76, 0x76, 0xa0, 0xf5, 0x18, 0x07, 0x98, 0xe0, 0x5c, 0x06,
+	0xce, 0x0b, 0x58, 0x90, 0x2a, 0x53, 0x4f, 0x37, 0x00, 0x74, 0x88, 0x89, 0x74, 0x71, 0xbe, 0xd5,
+	0x3b, 0xd0, 0x94, 0x6b, 0x72, 0x34, 0x5e, 0xfd, 0x86, 0x6c, 0xdb, 0xc7, 0x23, 0x74, 0x0b, 0x16,
+	0x94, 0xca, 0x38, 0x1c, 0xea, 0x6e, 0x4b, 0xb6, 0xb2, 0xf5, 0x76, 0xbe, 0x86, 0xab, 0xc6, 0x8c,
+	0x53, 0x9b, 0xf0, 0x12, 0x56, 0xb9, 0x3b, 0x66, 0x61, 0x85, 0xe3, 0xc2, 0x5a, 0x1a, 0x6d, 0x6a,
+	0x86, 0x5f, 0xc2, 0x6a, 0x07, 0x93, 0xbd, 0x20, 0xb8, 0x9c, 0x61, 0x66, 0x48, 0xfe, 0xdb, 0x82,
+	0xb5, 0x34, 0xc0, 0xb4, 0xa4, 0xd0, 0xbe, 0xb6, 0x3d, 0xe7, 0xd8, 0xf6, 0xfc, 0x58, 0x1b, 0x99,
+	0x3d, 0xdd, 0x87, 0xd9, 0xa6, 0x8f, 0xa0, 0xf1, 0xd4, 0x0f, 0x7b, 0xd2, 0x29, 0x37, 0xa0, 0x31,
+	0xf0, 0x62, 0xe2, 0x7b, 0xc1, 0xd1, 0x18, 0x02, 0x44, 0xd3, 0x7e, 0x9e, 0x8f, 0xde, 0x41, 0x93,
+	0x83, 0x4c, 0xed, 0x98, 0x36, 0x54, 0x63, 0x76, 0x28, 0x25, 0xcc, 0x2f, 0x75, 0x57, 0x8a, 0xce,
+	0x2f, 0x60, 0x95, 0xce, 0xf1, 0x70, 0x94, 0x5e, 0xc7, 0x15, 0x28, 0xff, 0x30, 0xc4, 0xb1, 0x24,
+	0xcb, 0x05, 0x27, 0x80, 0xb5, 0xb4, 0xfa, 0x07, 0x24, 0xf7, 0x47, 0x0b, 0x9a, 0xdf, 0x7a, 0xa4,
+	0x7b, 0x9a, 0x1f, 0x5c, 0x6b, 0x50, 0x19, 0xc4, 0xf8, 0xd8, 0xbf, 0x10, 0x9e, 0x13, 0x92, 0xb1,
+	0x73, 0xb9, 0x1d, 0xa9, 0x9d, 0xfb, 0x9a, 0x36, 0xa2, 0x9b, 0xd0, 0x3a, 0x8e, 0xa3, 0xfe, 0x51,
+	0x8c, 0xcf, 0xfd, 0xc4, 0x8f, 0xc2, 0xf6, 0xfc, 0xb6, 0x75, 0x7b, 0xde, 0x6d, 0xd2, 0x46, 0x57,
+	0xb4, 0x39, 0x7f, 0x9e, 0x03, 0x60, 0x34, 0x9e, 0x9c, 0xe3, 0x90, 0x20, 0x9b, 0x5a, 0x2a, 0xd4,
+	0x2d, 0xa6, 0xae, 0xe4, 0x8c, 0x8b, 0x64, 0x13, 0xea, 0xd1, 0x00, 0xc7, 0x1e, 0xa1, 0xea, 0x9c,
+	0xc3, 0xb8, 0x81, 0xda, 0x4e, 0xfd, 0x22, 0x67, 0x2e, 0xb9, 0x52, 0x1c, 0xc7, 0x56, 0x59, 0xbf,
+	0x80, 0xbe, 0xd2, 0x22, 0xbc, 0xc2, 0x22, 0xfc, 0xa6, 0xe6, 0xe5, 0x31, 0xc9, 0xbc, 0xa8, 0xa6,
+	0x74, 0x88, 0xdf, 0xc7, 0x09, 0xf1, 0xfa, 0x83, 0x76, 0x95, 0xd3, 0x51, 0x0d, 0xd3, 0xc5, 0xfc,
+	0x5f, 0x2c, 0x58, 0x7a, 0x1e, 0x76, 0x63, 0xdc, 0xc7, 0x61, 0xf1, 0x65, 0xd3, 0xc3, 0x01, 0xdb,
+	0xa1, 0xd4, 0x60, 0x2e, 0x50, 0x47, 0xf8, 0xa1, 0x4f, 0xb7, 0x03, 0x73, 0x52, 0xc9, 0x95, 0x22,
+	0x5a, 0x87, 0xea, 0xa9, 0x97, 0x1c, 0xf5, 0x7d, 0xee, 0xa2, 0x9a, 0x5b, 0x39, 0xf5, 0x92, 0x57,
+	0x3e, 0xf3, 0x35, 0x6d, 0x2c, 0x33, 0x75, 0xfa, 0x57, 0xa9, 0x7a, 0x17, 0xed, 0xca, 0x58, 0xd5,
+	0xbb, 0x60, 0xaa, 0xde, 0x45, 0xbb, 0x2a, 0x54, 0xbd, 0x0b, 0xa7, 0x0b, 0xcb, 0x1a, 0xd7, 0xa9,
+	0x63, 0xd8, 0xf0, 0x4a, 0x49, 0xde, 0xa0, 0x1f, 0xc1, 0xd5, 0x03, 0x7c, 0x41, 0x0e, 0xa9, 0x2f,
+	0xc2, 0x6e, 0xc1, 0xf5, 0x77, 0x0c, 0x2b, 0xa6, 0xe2, 0x07, 0x22, 0xf4, 0x1a, 0x16, 0x5f, 0xfa,
+	0x09, 0xf9, 0x66, 0x98, 0x14, 0x6f, 0x29, 0xa6, 0x2d, 0xb7, 0xa3, 0x90, 0x28, 0xe4, 0x71, 0x1c,
+	0x85, 0x84, 0x2d, 0x50, 0xcd, 0xe5, 0x82, 0xd3, 0x83, 0xa5, 0x31, 0xe4, 0xd4, 0xb4, 0xd7, 0xa0,
+	0x12, 0xe0, 0xf0, 0x84, 0x9c, 0x0a, 0xde, 0x42, 0x72, 0xee, 0xc2, 0x02, 0x9b, 0x25, 0x1a, 0x14,
+	0x06, 0x16, 0xe7, 0x37, 0xa7, 0xf3, 0xf3, 0x60, 0x51, 0x8d, 0x9c, 0xad, 0x57, 0x65, 0xf0, 0x3b,
+	0x07, 0xdc, 0x05, 0xae, 0x17, 0x9e, 0xe0, 0x42, 0x7a, 0x09, 0xf1, 0x62, 0x22, 0x57, 0x84, 0x09,
+	0x08, 0xc1, 0x7c, 0x42, 0xa2, 0x81, 0x08, 0x7a, 0xf6, 0xdf, 0xc1, 0xb0, 0xac, 0xe1, 0xcd, 0xc2,
+	0xa7, 0x59, 0xeb, 0xe9, 0x3c, 0x80, 0xd6, 0x21, 0x26, 0x7b, 0xbd, 0x5e, 0x3e, 0xe7, 0x36, 0x54,
+	0xfb, 0xb8, 0xff, 0x0e, 0xc7, 0xea, 0x68, 0x16, 0xa2, 0xf3, 0x3b, 0x58, 0x90, 0x83, 0x67, 0xe1,
+	0x55, 0xaf, 0xd7, 0xc3, 0x3d, 0xe9, 0x19, 0x26, 0x38, 0x5f, 0xc2, 0x12, 0x7b, 0x7e, 0xf6, 0xa3,
+	0x73, 0xfc, 0x53, 0x18, 0x9e, 0xc0, 0xb2, 0x36, 0x7e, 0x36, 0xb7, 0x14, 0xc5, 0x92, 0x34, 0xa5,
+	0xe8, 0xdc, 0x62, 0x13, 0xbd, 0xe2, 0xd3, 0xe6, 0xef, 0xf1, 0x53, 0x40, 0xba, 0xda, 0x2c, 0x08,
+	0xe5, 0x58, 0x7e, 0x00, 0x0b, 0xcf, 0xbc, 0xe4, 0xf4, 0xb2, 0x27, 0xff, 0xb1, 0x8f, 0x83, 0x9e,
+	0x8c, 0x64, 0x26, 0x98, 0x59, 0x8f, 0x8a, 0xef, 0x7d, 0x58, 0x54, 0x78, 0x53, 0x3f, 0x1c, 0xef,
+	0x72, 0x72, 0x9d, 0xf7, 0x26, 0x47, 0x77, 0xb2, 0x1a, 0xf9, 0x81, 0x76, 0xf2, 0x03, 0x58, 0xa6,
+	0x53, 0x5c, 0x92, 0xad, 0xe4, 0xf0, 0x3b, 0x00, 0xa4, 0x0f, 0x9e, 0xda, 0x53, 0xb7, 0x38, 0x19,
+	0xfe, 0x6c, 0xcd, 0x8f, 0xab, 0x7f, 0x59, 0x80, 0x74, 0xbd, 0xa9, 0x5d, 0xb3, 0x07, 0x15, 0x66,
+	0x50, 0x22, 0xde, 0xd0, 0xff, 0xaf, 0x8d, 0x9b, 0x9c, 0x68, 0xf7, 0x29, 0xd3, 0xe5, 0xef, 0x0c,
+	0x31, 0xd0, 0xbe, 0x47, 0x9f, 0xbf, 0xaa, 0xf9, 0xbd, 0x5e, 0x11, 0x0f, 0xd9, 0x36, 0x79, 0x1c,
+	0x75, 0x87, 0xc5, 0xcf, 0x08, 0x1b, 0x6a, 0x3d, 0xa1, 0x24, 0xd2, 0x56, 0x25, 0x8b, 0x7c, 0x6c,
+	0x8c, 0x31, 0xf5, 0x52, 0xdc, 0x07, 0xd4, 0xf9, 0x5f, 0x48, 0x21, 0x98, 0x1f, 0x78, 0xe2, 0xf2,
+	0xaa, 0xbb, 0xec, 0xbf, 0x73, 0x06, 0x57, 0x3b, 0xb3, 0x24, 0x53, 0x68, 0xf9, 0x1b, 0x58, 0xf9,
+	0x86, 0x3e, 0x02, 0x2f, 0xa7, 0xba, 0x02, 0xe5, 0x01, 0xd5, 0x94, 0x39, 0x3f, 0x13, 0x68, 0x6b,
+	0x1f, 0xc7, 0x27, 0x58, 0xde, 0xf1, 0x4c, 0x70, 0xfa, 0xb0, 0x9a, 0x42, 0xfd, 0xa0, 0x46, 0xa8,
+	0x9c, 0x24, 0x6d, 0xc5, 0x25, 0x39, 0xc9, 0x0c, 0xe9, 0xe5, 0xe7, 0x24, 0xbf, 0x84, 0xd6, 0xdb,
+	0x41, 0x10, 0x79, 0xbd, 0xc2, 0x28, 0x10, 0x29, 0x28, 0xb5, 0x8b, 0xfd, 0x77, 0xbe, 0x83, 0x05,
+	0x39, 0x6c, 0x6a, 0x72, 0xf4, 0xcd, 0xe0, 0xff, 0x5e, 0x3e, 0xed, 0xd8, 0x7f, 0xe7, 0x26, 0x2c,
+	0x3e, 0x8e, 0x7e, 0x0c, 0x0b, 0x89, 0x39, 0xff, 0x07, 0x4b, 0x63, 0xa5, 0x31, 0x18, 0x23, 0x6b,
+	0x69, 0x64, 0x6f, 0xc1, 0xf2, 0x4b, 0xec, 0x25, 0xb8, 0x13, 0x7b, 0x46, 0x08, 0x11, 0x12, 0x30,
+	0xbd, 0x92, 0x4b, 0xff, 0x3a, 0xdb, 0xb0, 0xc0, 0xd4, 0x9e, 0xab, 0x29, 0x17, 0x60, 0xce, 0xef,
+	0x09, 0x95, 0x39, 0xbf, 0xe7, 0xfc, 0xdd, 0x82, 0x16, 0x53, 0x99, 0x81, 0xd5, 0x1c, 0x7b, 0x4e,
+	0x62, 0x4b, 0x3e, 0x25, 0xc5, 0x07, 0x6d, 0x01, 0xe0, 0x8b, 0x81, 0x1f, 0xe3, 0xe4, 0xc8, 0x23,
+	0x2c, 0x59, 0xa8, 0xbb, 0x75, 0xd1, 0xb2, 0xc7, 0x9e, 0x5a, 0x67, 0x78, 0x94, 0xb4, 0xcb, 0x6c,
+	0x41, 0xd9, 0x7f, 0x1a, 0x51, 0x41, 0xd4, 0x3d, 0x4b, 0x58, 0x32, 0x55, 0x77, 0xb9, 0x40, 0xcf,
+	0x0f, 0xc1, 0xfa, 0x3c, 0x3a, 0x9b, 0xc5, 0x51, 0xfe, 0x39, 0x20, 0x06, 0xb8, 0x47, 0x88, 0x57,
+	0x94, 0xcd, 0xa6, 0x6c, 0x54, 0x44, 0xe4, 0xb8, 0xa9, 0x89, 0x7c, 0x01, 0x8d, 0x97, 0x51, 0xf7,
+	0x4c, 0x32, 0x40, 0x30, 0x1f, 0x7a, 0x7d, 0x2c, 0x28, 0xb0, 0xff, 0x68, 0x03, 0x6a, 0x01, 0x9d,
+	0xf3, 0x48, 0x31, 0xa9, 0x06, 0x7c, 0x95, 0x9d, 0xef, 0xa0, 0xc9, 0x47, 0xcf, 0xe2, 0xfa, 0x25,
+	0xd1, 0x19, 0x0e, 0xe5, 0x93, 0x8f, 0x09, 0xce, 0x3d, 0x68, 0xbd, 0x0d, 0x83, 0x4b, 0xf8, 0x65,
+	0x0f, 0x7d, 0x01, 0x0b, 0x72, 0xe8, 0xd4, 0x4e, 0x3a, 0x83, 0xe5, 0x87, 0xc3, 0xe0, 0xcc, 0x7c,
+	0x05, 0x8c, 0x0b, 0x0d, 0xd6, 0x25, 0x85, 0x86, 0xb9, 0xac, 0x42, 0xc3, 0x3a, 0x54, 0x7b, 0xf1,
+	0xe8, 0x28, 0x1e, 0x86, 0xe2, 0x68, 0xad, 0xf4, 0xe2, 0x91, 0x3b, 0x0c, 0x9d, 0xbf, 0x59, 0xd0,
+	0x78, 0x14, 0x0d, 0x46, 0xda, 0x3c, 0x49, 0x34, 0x8c, 0xbb, 0xd2, 0x68, 0x21, 0xa1, 0x6d, 0x68,
+	0xf4, 0x70, 0x42, 0xfc, 0x90, 0x57, 0x12, 0x44, 0x99, 0x4f, 0x6b, 0xd2, 0x18, 0x8a, 0x19, 0x04,
+	0xc3, 0x36, 0x54, 0x4f, 0xfd, 0x84, 0x44, 0xf1, 0x48, 0x24, 0xd0, 0x52, 0x64, 0xb5, 0x89, 0x73,
+	0x1c, 0xff, 0x18, 0xfb, 0x84, 0xd7, 0x19, 0x6a, 0xee, 0xb8, 0x41, 0xa7, 0x5c, 0x31, 0x28, 0xff,
+	0xd5, 0x82, 0x26, 0x75, 0xd0, 0x6c, 0xe2, 0xa0, 0x1b, 0x0d, 0x43, 0x95, 0x14, 0x31, 0x41, 0xed,
+	0xd4, 0x92, 0xb6, 0x53, 0x1d, 0x68, 0x6a, 0xc6, 0x26, 0xac, 0xfc, 0x5e, 0x77, 0x8d, 0x36, 0x9d,
+	0x71, 0xd9, 0x60, 0xfc, 0x1f, 0x0b, 0x1a, 0x4f, 0xb1, 0x47, 0x86, 0x31, 0x7e, 0x1a, 0x78, 0x27,
+	0x99, 0x71, 0xc5, 0x1d, 0xdc, 0x8d, 0xfd, 0x41, 0xca, 0xc1, 0xb2, 0x89, 0x3a, 0x12, 0x87, 0xde,
+	0xbb, 0x00, 0xf7, 0x84, 0x87, 0xa5, 0x48, 0x7b, 0xe2, 0x28, 0x08, 0xa2, 0x21, 0x3f, 0x76, 0xca,
+	0xae, 0x14, 0xd1, 0xaf, 0xa0, 0x4a, 0x70, 0xe8, 0x85, 0x84, 0x9f, 0x3b, 0x66, 0xbd, 0x46, 0xa3,
+	0xb4, 0xfb, 0x86, 0x6b, 0xf1, 0x77, 0x94, 0x1c, 0x63, 0xdf, 0x87, 0xa6, 0xde, 0x71, 0xd9, 0x4b,
+	0xaa, 0xa6, 0xbf, 0xa4, 0x10, 0x4f, 0x4b, 0x29, 0xba, 0x4c, 0x4b, 0x9c, 0x3f, 0xc0, 0xb2, 0xd6,
+	0x36, 0xf5, 0xf2, 0xfd, 0x1c, 0xca, 0xc7, 0x14, 0x4a, 0xbc, 0x14, 0xd7, 0xb2, 0x6d, 0x73, 0xb9,
+	0x92, 0x73, 0x0b, 0x16, 0xa9, 0x78, 0xe0, 0xf5, 0x71, 0xc1, 0x06, 0x77, 0x08, 0x34, 0xd9, 0xa8,
+	0xe9, 0xe9, 0xfd, 0x0c, 0xe6, 0xe9, 0xcc, 0xcc, 0x35, 0xf9, 0xec, 0x98, 0x0e, 0x7d, 0xbd, 0xf3,
+	0x0d, 0x3f, 0x9b, 0xb9, 0x9d, 0x47, 0x70, 0xf5, 0x09, 0x5d, 0x0b, 0x4f, 0x22, 0xe6, 0x9f, 0x68,
+	0x6d, 0xa8, 0x26, 0xc3, 0x77, 0xdf, 0xe3, 0x2e, 0x11, 0x51, 0x27, 0x45, 0xe7, 0x7b, 0x58, 0x31,
+	0x41, 0x66, 0xf1, 0xb0, 0x91, 0x31, 0x3c, 0x67, 0xc4, 0xb0, 0xe3, 0x43, 0xeb, 0x51, 0x14, 0x1e,
+	0xfb, 0x27, 0x87, 0x98, 0x10, 0x3f, 0x3c, 0xc9, 0x3b, 0x7c, 0x27, 0xdf, 0xed, 0xda, 0x99, 0x55,
+	0x32, 0xce, 0x2c, 0xda, 0x8e, 0xbb, 0x31, 0x26, 0xb2, 0x72, 0xc7, 0x25, 0x1a, 0x99, 0x1d, 0x4c,
+	0xf8, 0x6c, 0x32, 0x32, 0xff, 0x64, 0xc1, 0xb2, 0xd6, 0x38, 0xb5, 0xa1, 0x9f, 0x41, 0x2d, 0xe1,
+	0x86, 0xc8, 0xe8, 0x6c, 0x6b, 0x23, 0x0d, 0x4b, 0x5d, 0xa5, 0x49, 0xdd, 0x33, 0xc0, 0x61, 0xcf,
+	0x0f, 0x4f, 0xc4, 0xe1, 0x23, 0xc5, 0x3b, 0xff, 0x6c, 0xc3, 0xa2, 0xfc, 0xc0, 0x77, 0xc8, 0x3f,
+	0x34, 0xa2, 0x7b, 0x50, 0xea, 0x60, 0x82, 0x56, 0xcd, 0x8f, 0x0c, 0xc2, 0x22, 0x7b, 0x23, 0xf7,
+	0xd3, 0xa0, 0x73, 0x05, 0x7d, 0x0e, 0xa5, 0xc3, 0xd4, 0xd0, 0x71, 0xbe, 0x6e, 0xaf, 0xa5, 0x9b,
+	0x45, 0x50, 0x5d, 0x41, 0x5f, 0x41, 0x85, 0x87, 0x29, 0xd2, 0xcd, 0x31, 0xae, 0x2a, 0x7b, 0x23,
+	0xa3, 0x47, 0x01, 0x1c, 0xb0, 0xaf, 0x7a, 0xb2, 0xca, 0x8b, 0xb6, 0xcc, 0x99, 0x52, 0x5f, 0x01,
+	0xec, 0xeb, 0x79, 0xdd, 0x0a, 0xef, 0x5b, 0xf9, 0xe5, 0x4e, 0x41, 0x6e, 0x4f, 0x4c, 0x9f, 0x46,
+	0xdd, 0x29, 0xd0, 0xd0, 0x81, 0xcd, 0x2f, 0x36, 0x06, 0x70, 0xe6, 0xc7, 0x27, 0x7b, 0xa7, 0x40,
+	0x43, 0x01, 0xdf, 0x83, 0x79, 0x9a, 0x2f, 0x20, 0xe3, 0x3c, 0x18, 0x7f, 0xac, 0xb1, 0xd7, 0x27,
+	0xda, 0x75, 0x4e, 0xe6, 0xe7, 0x0f, 0x83, 0x53, 0xe6, 0x87, 0x14, 0x7b, 0xa7, 0x40, 0x43, 0x01,
+	0x3f, 0x80, 0x32, 0x2b, 0xde, 0xa3, 0xf5, 0x74, 0x39, 0x5f, 0xc2, 0xac, 0x66, 0xd6, 0xf9, 0x9d,
+	0x2b, 0x9f, 0x58, 0xe8, 0x19, 0xd4, 0x55, 0x2d, 0x1b, 0x5d, 0xd3, 0xf4, 0xd2, 0xd5, 0x78, 0x7b,
+	0x33, 0xbb, 0x53, 0xd1, 0x78, 0x0d, 0x4d, 0xbd, 0x0e, 0x8d, 0xf4, 0xe5, 0xcf, 0xa8, 0x64, 0xdb,
+	0x37, 0x72, 0xfb, 0x15, 0xe4, 0x13, 0xa8, 0xc9, 0xfa, 0x30, 0xb2, 0x35, 0xf5, 0x54, 0x1d, 0xda,
+	0xbe, 0x96, 0xd9, 0xa7, 0x60, 0x1e, 0x42, 0x55, 0x94, 0x71, 0xd1, 0x46, 0x5a, 0x53, 0x15, 0x85,
+	0x6d, 0x3b, 0xab, 0x4b, 0x61, 0x3c, 0x83, 0xba, 0xaa, 0xab, 0xa2, 0xf4, 0x7c, 0x7a, 0xf5, 0xd6,
+	0xde, 0xcc, 0xee, 0xd4, 0x77, 0x21, 0xaf, 0x7e, 0x1a, 0xbb, 0xd0, 0xa8, 0xa6, 0xda, 0x1b, 0x19,
+	0x3d, 0x3a, 0x15, 0x55, 0x9c, 0x34, 0xa8, 0xa4, 0x4b, 0x9e, 0xf6, 0x66, 0x76, 0xa7, 0x42, 0xda,
+	0x07, 0x18, 0x97, 0x15, 0xd1, 0x66, 0x7a, 0xbf, 0xea, 0x45, 0x49, 0x7b, 0x2b, 0xa7, 0x57, 0xf7,
+	0xb2, 0xa8, 0xf4, 0x19, 0x5e, 0x36, 0xab, 0x89, 0xb6, 0x9d, 0xd5, 0x95, 0xc6, 0xe8, 0x64, 0x60,
+	0x74, 0xf2, 0x31, 0x3a, 0x06, 0xc6, 0x3e, 0xc0, 0xb8, 0x94, 0x66, 0x18, 0x35, 0x51, 0x9e, 0xb3,
+	0xb7, 0x72, 0x7a, 0xd3, 0x60, 0xfc, 0x3c, 0x98, 0x00, 0x33, 0xca, 0x6b, 0xf6, 0x56, 0x4e, 0x6f,
+	0xea, 0xf8, 0x94, 0x95, 0x86, 0xf4, 0xf1, 0x99, 0x2a, 0x58, 0xd8, 0xd7, 0xf3, 0xba, 0x75, 0xbc,
+	0x4e, 0x0e, 0x5e, 0xa7, 0x18, 0xaf, 0x93, 0x89, 0xf7, 0x06, 0x5a, 0x46, 0xa9, 0x06, 0xe9, 0x5b,
+	0x34, 0xab, 0x34, 0x64, 0x6f, 0xe7, 0x2b, 0x4c, 0x9e, 0x7b, 0x0a, 0x76, 0xf2, 0xdc, 0x4b, 0xe3,
+	0xee, 0x14, 0x68, 0x28, 0xe0, 0x3d, 0xa8, 0xf0, 0xb2, 0x88, 0xb1, 0x91, 0x8c, 0x02, 0x8b, 0xbd,
+	0x91, 0xd1, 0x23, 0x01, 0x6e, 0x5b, 0xa8, 0x03, 0x35, 0x59, 0xd4, 0x30, 0x0e, 0x98, 0x54, 0x39,
+	0xc4, 0xbe, 0x96, 0xd9, 0x27, 0x81, 0x3e, 0xb1, 0xd0, 0x53, 0x80, 0x71, 0xd5, 0xc3, 0x88, 0x93,
+	0x89, 0x62, 0x88, 0xdd, 0x4e, 0xf7, 0x6a, 0x36, 0x75, 0x44, 0x59, 0x64, 0x1f, 0xe3, 0xc1, 0x5e,
+	0xe0, 0x9f, 0x63, 0xf3, 0xc4, 0x32, 0x2a, 0x26, 0x85, 0x40, 0x7b, 0x50, 0xe3, 0x33, 0xa7, 0xb6,
+	0xd2, 0x7b, 0x40, 0xbc, 0x80, 0x86, 0x56, 0xc9, 0x28, 0x42, 0xb9, 0x3e, 0x89, 0xa2, 0x17, 0x3f,
+	0x78, 0xa8, 0x6a, 0xc5, 0x08, 0x23, 0x54, 0x27, 0x8b, 0x1b, 0xf6, 0xf5, 0xbc, 0x6e, 0xfd, 0x1e,
+	0xa6, 0xd5, 0x04, 0xe3, 0x1e, 0xd6, 0x8a, 0x13, 0xf6, 0xfa, 0x44, 0xbb, 0x7e, 0xfe, 0xf2, 0x6c,
+	0xdf, 0x0c, 0x1b, 0xbd, 0x76, 0x60, 0x6f, 0x64, 0xf4, 0x68, 0xb7, 0x12, 0x8c, 0x53, 0x7c, 0x63,
+	0xad, 0x27, 0x32, 0x7f, 0x7b, 0x3d, 0xd5, 0x6b, 0x9a, 0x40, 0x73, 0x77, 0xc3, 0x04, 0x2d, 0x99,
+	0x2f, 0x1a, 0xfa, 0x00, 0x2a, 0x2e, 0x66, 0x6f, 0xea, 0x9f, 0x30, 0x58, 0xdc, 0x64, 0x2c, 0x8d,
+	0x9b, 0xb8, 0xc9, 0xf4, 0x84, 0xcf, 0xde, 0xcc, 0xee, 0x54, 0x48, 0xbf, 0x86, 0x6a, 0x07, 0xb3,
+	0x56, 0x63, 0xf3, 0xa4, 0xf2, 0x34, 0xf3, 0x4d, 0xa4, 0x65, 0x22, 0xce, 0x15, 0xf4, 0x05, 0x54,
+	0x0f, 0x05, 0x42, 0x4e, 0x86, 0x55, 0x34, 0xfa, 0x39, 0xc0, 0x38, 0xed, 0x2a, 0xa4, 0xb0, 0x35,
+	0xf1, 0x68, 0x4c, 0x41, 0xbd, 0x86, 0xa6, 0x9e, 0x2c, 0x19, 0x8f, 0x97, 0x8c, 0x54, 0xcc, 0xbe,
+	0x91, 0xdb, 0xaf, 0xfb, 0x59, 0xe5, 0x24, 0x86, 0x9f, 0xd3, 0xe9, 0x8b, 0xbd, 0x99, 0xdd, 0x29,
+	0x91, 0x1e, 0xb6, 0x7e, 0xdb, 0xd8, 0xfd, 0x58, 0xa9, 0xbc, 0xab, 0xb0, 0xbf, 0x9f, 0xfe, 0x77,
+	0x00, 0x58, 0x82, 0x13, 0x06, 0xb7, 0x28, 0x00, 0x00,
 }
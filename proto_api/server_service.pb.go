@@ -99,11 +99,20 @@ func (m *GetStatusResponse) GetStatus() *ServerStatus {
 }
 
 type ServerStatus struct {
-	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// time since the server started running, 0 before
+	UptimeSeconds int64 `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	// records held, evicted ones included
+	Records     int64              `protobuf:"varint,4,opt,name=records,proto3" json:"records,omitempty"`
+	Memory      *MemoryStatus      `protobuf:"bytes,5,opt,name=memory,proto3" json:"memory,omitempty"`
+	Persistence *PersistenceStatus `protobuf:"bytes,6,opt,name=persistence,proto3" json:"persistence,omitempty"`
+	// addresses of the APIs by protocol
+	Listeners            map[string]string `protobuf:"bytes,7,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ClusterRole          string            `protobuf:"bytes,8,opt,name=cluster_role,json=clusterRole,proto3" json:"cluster_role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
@@ -145,6 +154,175 @@ func (m *ServerStatus) GetStatus() string {
 	return ""
 }
 
+func (m *ServerStatus) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *ServerStatus) GetRecords() int64 {
+	if m != nil {
+		return m.Records
+	}
+	return 0
+}
+
+func (m *ServerStatus) GetMemory() *MemoryStatus {
+	if m != nil {
+		return m.Memory
+	}
+	return nil
+}
+
+func (m *ServerStatus) GetPersistence() *PersistenceStatus {
+	if m != nil {
+		return m.Persistence
+	}
+	return nil
+}
+
+func (m *ServerStatus) GetListeners() map[string]string {
+	if m != nil {
+		return m.Listeners
+	}
+	return nil
+}
+
+func (m *ServerStatus) GetClusterRole() string {
+	if m != nil {
+		return m.ClusterRole
+	}
+	return ""
+}
+
+type MemoryStatus struct {
+	AllocBytes           uint64   `protobuf:"varint,1,opt,name=alloc_bytes,json=allocBytes,proto3" json:"alloc_bytes,omitempty"`
+	SysBytes             uint64   `protobuf:"varint,2,opt,name=sys_bytes,json=sysBytes,proto3" json:"sys_bytes,omitempty"`
+	GcCycles             uint32   `protobuf:"varint,3,opt,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	Goroutines           int64    `protobuf:"varint,4,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MemoryStatus) Reset()         { *m = MemoryStatus{} }
+func (m *MemoryStatus) String() string { return proto.CompactTextString(m) }
+func (*MemoryStatus) ProtoMessage()    {}
+func (*MemoryStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c2f965b42940ea8, []int{3}
+}
+
+func (m *MemoryStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MemoryStatus.Unmarshal(m, b)
+}
+func (m *MemoryStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MemoryStatus.Marshal(b, m, deterministic)
+}
+func (m *MemoryStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemoryStatus.Merge(m, src)
+}
+func (m *MemoryStatus) XXX_Size() int {
+	return xxx_messageInfo_MemoryStatus.Size(m)
+}
+func (m *MemoryStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemoryStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemoryStatus proto.InternalMessageInfo
+
+func (m *MemoryStatus) GetAllocBytes() uint64 {
+	if m != nil {
+		return m.AllocBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatus) GetSysBytes() uint64 {
+	if m != nil {
+		return m.SysBytes
+	}
+	return 0
+}
+
+func (m *MemoryStatus) GetGcCycles() uint32 {
+	if m != nil {
+		return m.GcCycles
+	}
+	return 0
+}
+
+func (m *MemoryStatus) GetGoroutines() int64 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+type PersistenceStatus struct {
+	Driver string `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	// RFC 3339, empty before the first sync
+	LastSync             string   `protobuf:"bytes,2,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`
+	LastSyncMillis       int64    `protobuf:"varint,3,opt,name=last_sync_millis,json=lastSyncMillis,proto3" json:"last_sync_millis,omitempty"`
+	LastSyncError        string   `protobuf:"bytes,4,opt,name=last_sync_error,json=lastSyncError,proto3" json:"last_sync_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PersistenceStatus) Reset()         { *m = PersistenceStatus{} }
+func (m *PersistenceStatus) String() string { return proto.CompactTextString(m) }
+func (*PersistenceStatus) ProtoMessage()    {}
+func (*PersistenceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8c2f965b42940ea8, []int{4}
+}
+
+func (m *PersistenceStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PersistenceStatus.Unmarshal(m, b)
+}
+func (m *PersistenceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PersistenceStatus.Marshal(b, m, deterministic)
+}
+func (m *PersistenceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PersistenceStatus.Merge(m, src)
+}
+func (m *PersistenceStatus) XXX_Size() int {
+	return xxx_messageInfo_PersistenceStatus.Size(m)
+}
+func (m *PersistenceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_PersistenceStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PersistenceStatus proto.InternalMessageInfo
+
+func (m *PersistenceStatus) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *PersistenceStatus) GetLastSync() string {
+	if m != nil {
+		return m.LastSync
+	}
+	return ""
+}
+
+func (m *PersistenceStatus) GetLastSyncMillis() int64 {
+	if m != nil {
+		return m.LastSyncMillis
+	}
+	return 0
+}
+
+func (m *PersistenceStatus) GetLastSyncError() string {
+	if m != nil {
+		return m.LastSyncError
+	}
+	return ""
+}
+
 type StartRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -155,7 +333,7 @@ func (m *StartRequest) Reset()         { *m = StartRequest{} }
 func (m *StartRequest) String() string { return proto.CompactTextString(m) }
 func (*StartRequest) ProtoMessage()    {}
 func (*StartRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8c2f965b42940ea8, []int{3}
+	return fileDescriptor_8c2f965b42940ea8, []int{5}
 }
 
 func (m *StartRequest) XXX_Unmarshal(b []byte) error {
@@ -187,7 +365,7 @@ func (m *StartResponse) Reset()         { *m = StartResponse{} }
 func (m *StartResponse) String() string { return proto.CompactTextString(m) }
 func (*StartResponse) ProtoMessage()    {}
 func (*StartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8c2f965b42940ea8, []int{4}
+	return fileDescriptor_8c2f965b42940ea8, []int{6}
 }
 
 func (m *StartResponse) XXX_Unmarshal(b []byte) error {
@@ -225,7 +403,7 @@ func (m *StopRequest) Reset()         { *m = StopRequest{} }
 func (m *StopRequest) String() string { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()    {}
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8c2f965b42940ea8, []int{5}
+	return fileDescriptor_8c2f965b42940ea8, []int{7}
 }
 
 func (m *StopRequest) XXX_Unmarshal(b []byte) error {
@@ -257,7 +435,7 @@ func (m *StopResponse) Reset()         { *m = StopResponse{} }
 func (m *StopResponse) String() string { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()    {}
 func (*StopResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8c2f965b42940ea8, []int{6}
+	return fileDescriptor_8c2f965b42940ea8, []int{8}
 }
 
 func (m *StopResponse) XXX_Unmarshal(b []byte) error {
@@ -289,6 +467,9 @@ func init() {
 	proto.RegisterType((*GetStatusRequest)(nil), "proto_api.GetStatusRequest")
 	proto.RegisterType((*GetStatusResponse)(nil), "proto_api.GetStatusResponse")
 	proto.RegisterType((*ServerStatus)(nil), "proto_api.ServerStatus")
+	proto.RegisterMapType((map[string]string)(nil), "proto_api.ServerStatus.ListenersEntry")
+	proto.RegisterType((*MemoryStatus)(nil), "proto_api.MemoryStatus")
+	proto.RegisterType((*PersistenceStatus)(nil), "proto_api.PersistenceStatus")
 	proto.RegisterType((*StartRequest)(nil), "proto_api.StartRequest")
 	proto.RegisterType((*StartResponse)(nil), "proto_api.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "proto_api.StopRequest")
@@ -298,24 +479,42 @@ func init() {
 func init() { proto.RegisterFile("server_service.proto", fileDescriptor_8c2f965b42940ea8) }
 
 var fileDescriptor_8c2f965b42940ea8 = []byte{
-	// 293 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x51, 0x3d, 0x4f, 0xc3, 0x30,
-	0x10, 0x25, 0x08, 0x8a, 0x72, 0x49, 0x0a, 0x9c, 0x50, 0x1b, 0x85, 0x0e, 0x28, 0x13, 0x53, 0x2a,
-	0x95, 0x09, 0x89, 0x01, 0x21, 0x21, 0x98, 0x13, 0x95, 0x81, 0x25, 0x0a, 0x95, 0x87, 0x48, 0x34,
-	0x0e, 0xb6, 0xdb, 0x3f, 0xc1, 0xbf, 0xe3, 0x17, 0x21, 0x7f, 0xca, 0xa1, 0x8c, 0x99, 0xec, 0xbb,
-	0xf3, 0x7b, 0xcf, 0xf7, 0x1e, 0x5c, 0x71, 0xc2, 0xf6, 0x84, 0xd5, 0xf2, 0x68, 0x37, 0xa4, 0xe8,
-	0x19, 0x15, 0x14, 0x43, 0x75, 0xd4, 0x4d, 0xdf, 0x66, 0xf1, 0x86, 0x6e, 0xb7, 0xb4, 0xd3, 0x83,
-	0x1c, 0xe1, 0xe2, 0x85, 0x88, 0x4a, 0x34, 0x62, 0xc7, 0x4b, 0xf2, 0xb5, 0x23, 0x5c, 0xe4, 0xdf,
-	0x01, 0x5c, 0x7a, 0x4d, 0xde, 0xd3, 0x8e, 0x13, 0x7c, 0x86, 0x73, 0x8d, 0xac, 0x99, 0x69, 0xa5,
-	0xc1, 0x4d, 0x70, 0x1b, 0xad, 0x16, 0x85, 0x23, 0x2f, 0xd6, 0x5d, 0xbb, 0x27, 0x8c, 0x37, 0x9f,
-	0x16, 0x56, 0x4e, 0x35, 0xc8, 0xd1, 0x2c, 0x61, 0xc2, 0x15, 0x71, 0x7a, 0xac, 0xd0, 0x73, 0x0f,
-	0x5d, 0xa9, 0xaf, 0x1b, 0x5d, 0xf3, 0x2c, 0x7f, 0x84, 0xd8, 0xef, 0x63, 0x0a, 0x67, 0x52, 0xa2,
-	0xa5, 0x9d, 0xd2, 0x0f, 0x4b, 0x5b, 0xe2, 0x6c, 0x40, 0x1d, 0x3a, 0x86, 0x29, 0xc4, 0x95, 0x68,
-	0x98, 0xb0, 0xfb, 0xbd, 0x41, 0x62, 0xea, 0x51, 0x57, 0xcb, 0x13, 0x88, 0x2a, 0x41, 0x7b, 0x2b,
-	0xb3, 0x96, 0xb2, 0xb2, 0x1c, 0x55, 0x65, 0xf5, 0x13, 0x40, 0x62, 0x0c, 0xd1, 0x11, 0xe3, 0x2b,
-	0x84, 0x2e, 0x2e, 0xbc, 0xf6, 0xc8, 0xfe, 0x26, 0x9b, 0x2d, 0xfe, 0x1f, 0x9a, 0xff, 0x1f, 0xe1,
-	0x03, 0x9c, 0x2a, 0x67, 0x70, 0x90, 0x8a, 0xe7, 0x5d, 0x96, 0x1e, 0x0e, 0x1c, 0xfa, 0x1e, 0x4e,
-	0xe4, 0xc2, 0x38, 0x1b, 0xbc, 0x71, 0x86, 0x64, 0xf3, 0x83, 0xbe, 0x85, 0x3e, 0x25, 0xef, 0x51,
-	0xb1, 0x74, 0xd3, 0x8f, 0x89, 0xba, 0xde, 0xfd, 0x06, 0x00, 0x00, 0xff, 0xff, 0xe6, 0xd1, 0x3e,
-	0x38, 0xcd, 0x02, 0x00, 0x00,
+	// 587 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcf, 0x6e, 0xd4, 0x3e,
+	0x10, 0xfe, 0xa5, 0xdb, 0x3f, 0x9b, 0xc9, 0x66, 0xdb, 0x5a, 0x55, 0x1b, 0x6d, 0xab, 0x1f, 0x25,
+	0x12, 0xd5, 0x9e, 0xb6, 0x52, 0xb9, 0x00, 0xaa, 0x38, 0x14, 0x2a, 0x38, 0x50, 0x09, 0x25, 0x2a,
+	0x07, 0x2e, 0x51, 0xea, 0x8e, 0xaa, 0x08, 0x27, 0x0e, 0xb6, 0x53, 0x29, 0x8f, 0x80, 0x78, 0x02,
+	0x78, 0x2c, 0x9e, 0x08, 0xd9, 0xb1, 0xd3, 0x2c, 0x0b, 0xb7, 0x9e, 0xe2, 0xf9, 0xbe, 0xf9, 0x66,
+	0xc6, 0x5f, 0x6c, 0xc3, 0x9e, 0x44, 0x71, 0x8f, 0x22, 0xd3, 0x9f, 0x82, 0xe2, 0xa2, 0x16, 0x5c,
+	0x71, 0xe2, 0x9b, 0x4f, 0x96, 0xd7, 0xc5, 0x6c, 0x42, 0x79, 0x59, 0xf2, 0xaa, 0x23, 0x62, 0x02,
+	0x3b, 0xef, 0x50, 0xa5, 0x2a, 0x57, 0x8d, 0x4c, 0xf0, 0x6b, 0x83, 0x52, 0xc5, 0xdf, 0x3d, 0xd8,
+	0x1d, 0x80, 0xb2, 0xe6, 0x95, 0x44, 0x72, 0x09, 0xdb, 0x9d, 0x32, 0x13, 0x16, 0x8a, 0xbc, 0x63,
+	0x6f, 0x1e, 0x9c, 0x1d, 0x2d, 0xfa, 0xe2, 0x8b, 0xeb, 0xaa, 0xb8, 0x47, 0x21, 0x73, 0xe6, 0x64,
+	0xc9, 0xb4, 0x13, 0xf5, 0x65, 0x4e, 0x61, 0x53, 0x9a, 0xc2, 0xd1, 0x9a, 0x51, 0x1f, 0x0c, 0xd4,
+	0xa9, 0x19, 0xdd, 0xf6, 0xb5, 0x69, 0xf1, 0x8f, 0x11, 0x4c, 0x86, 0x04, 0x89, 0x60, 0x4b, 0xf7,
+	0x28, 0x78, 0x65, 0x06, 0xf0, 0x13, 0x17, 0x92, 0xfd, 0xa5, 0xda, 0xbe, 0x2b, 0x41, 0x9e, 0xc1,
+	0xb4, 0xa9, 0x55, 0x51, 0x62, 0x26, 0x91, 0xf2, 0xea, 0x56, 0x46, 0xa3, 0x63, 0x6f, 0x3e, 0x4a,
+	0xc2, 0x0e, 0x4d, 0x3b, 0x50, 0x17, 0x16, 0x48, 0xb9, 0xb8, 0x95, 0xd1, 0xba, 0xe1, 0x5d, 0xa8,
+	0x87, 0x2e, 0xb1, 0xe4, 0xa2, 0x8d, 0x36, 0x56, 0x86, 0xbe, 0x32, 0x84, 0x1b, 0xba, 0x4b, 0x23,
+	0xaf, 0x21, 0xa8, 0xf5, 0x50, 0x52, 0x61, 0x45, 0x31, 0xda, 0x5c, 0x31, 0xea, 0xe3, 0x03, 0x6b,
+	0xa5, 0x43, 0x01, 0x79, 0x0b, 0x3e, 0x33, 0x6b, 0x14, 0x32, 0xda, 0x3a, 0x1e, 0xcd, 0x83, 0xb3,
+	0x93, 0x7f, 0x18, 0xb5, 0xf8, 0xe0, 0x12, 0x2f, 0x2b, 0x25, 0xda, 0xe4, 0x41, 0x48, 0x9e, 0xc2,
+	0x84, 0xb2, 0x46, 0x2a, 0x14, 0x99, 0xe0, 0x0c, 0xa3, 0xb1, 0x71, 0x25, 0xb0, 0x58, 0xc2, 0x19,
+	0xce, 0xce, 0x61, 0xba, 0xac, 0x27, 0x3b, 0x30, 0xfa, 0x82, 0xad, 0xb5, 0x56, 0x2f, 0xc9, 0x1e,
+	0x6c, 0xdc, 0xe7, 0xac, 0x41, 0xeb, 0x6a, 0x17, 0xbc, 0x5a, 0x7b, 0xe1, 0xc5, 0xdf, 0x3c, 0x98,
+	0x0c, 0xf7, 0x4f, 0x9e, 0x40, 0x90, 0x33, 0xc6, 0x69, 0x76, 0xd3, 0x2a, 0x94, 0xa6, 0xc8, 0x7a,
+	0x02, 0x06, 0xba, 0xd0, 0x08, 0x39, 0x04, 0x5f, 0xb6, 0xd2, 0xd2, 0x6b, 0x86, 0x1e, 0xcb, 0x56,
+	0xf6, 0xe4, 0x1d, 0xcd, 0x68, 0x4b, 0x19, 0x76, 0xbf, 0x28, 0x4c, 0xc6, 0x77, 0xf4, 0x8d, 0x89,
+	0xc9, 0xff, 0x00, 0x77, 0x5c, 0xf0, 0x46, 0x15, 0x15, 0xba, 0x1f, 0x34, 0x40, 0xe2, 0x9f, 0x1e,
+	0xec, 0xae, 0xb8, 0xaa, 0x8f, 0xc4, 0xad, 0xd0, 0x67, 0xd2, 0x6e, 0xc8, 0x46, 0xba, 0x15, 0xcb,
+	0xa5, 0xca, 0x64, 0x5b, 0x51, 0xbb, 0xaf, 0xb1, 0x06, 0xd2, 0xb6, 0xa2, 0x64, 0x0e, 0x3b, 0x3d,
+	0x99, 0x95, 0x05, 0x63, 0x85, 0x3b, 0x31, 0x53, 0x97, 0x73, 0x65, 0x50, 0x72, 0x02, 0xdb, 0x0f,
+	0x99, 0x28, 0x04, 0x17, 0x66, 0x32, 0x3f, 0x09, 0x5d, 0xe2, 0xa5, 0x06, 0xe3, 0x29, 0x4c, 0x52,
+	0x95, 0x0b, 0xe5, 0xae, 0xd8, 0x27, 0x08, 0x6d, 0xfc, 0xa8, 0xb7, 0x2b, 0x0e, 0x21, 0x48, 0x15,
+	0xaf, 0x5d, 0x9b, 0x6b, 0x98, 0x74, 0xe1, 0xa3, 0x76, 0x39, 0xfb, 0xe5, 0x41, 0x68, 0x8f, 0x60,
+	0xf7, 0xca, 0x90, 0xf7, 0xe0, 0xf7, 0x2f, 0x06, 0x39, 0x1c, 0x14, 0xfb, 0xf3, 0x71, 0x99, 0x1d,
+	0xfd, 0x9d, 0xb4, 0xf3, 0xff, 0x47, 0xce, 0x61, 0xc3, 0x38, 0x43, 0x96, 0x1e, 0x86, 0x81, 0x77,
+	0xb3, 0x68, 0x95, 0xe8, 0xd5, 0x2f, 0x61, 0x5d, 0x6f, 0x98, 0xec, 0x2f, 0xe5, 0xf4, 0x86, 0xcc,
+	0x0e, 0x56, 0x70, 0x27, 0xbd, 0x08, 0x3f, 0x07, 0x8b, 0xd3, 0x9e, 0xbd, 0xd9, 0x34, 0xcb, 0xe7,
+	0xbf, 0x07, 0x00, 0xd2, 0x16, 0x66, 0x79, 0x50, 0x05, 0x00, 0x00,
 }
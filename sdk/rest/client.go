@@ -0,0 +1,277 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client - a client of the simple-kv REST API, scoped to one tenant
+type Client struct {
+	baseURL    string
+	tenant     string
+	httpClient *http.Client
+}
+
+// Error - a request the server answered with an error status
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %v: %v", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Version - one retained version of a value
+type Version struct {
+	Version   int       `json:"version"`
+	Value     string    `json:"value,omitempty"`
+	Size      int64     `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+	Deleted   bool      `json:"deleted,omitempty"`
+	Chunked   bool      `json:"chunked,omitempty"`
+}
+
+// WatchFilter - selects the change events of a watch, an empty filter
+// selects every event
+type WatchFilter struct {
+	Key           string
+	Prefix        string
+	MetadataQuery string
+	FromRevision  uint64
+}
+
+// Event - a change event delivered by a watch
+type Event struct {
+	Revision  uint64            `json:"revision"`
+	Key       string            `json:"key"`
+	Operation string            `json:"operation"`
+	Version   int               `json:"version"`
+	Value     string            `json:"value,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// NewClient - creates a client of the server at baseURL, e.g.
+// http://localhost:8080, the default tenant when tenant is empty
+func NewClient(baseURL string, tenant string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		tenant:  tenant,
+		// no timeout, watches stay open until their context ends
+		httpClient: &http.Client{},
+	}
+}
+
+// Get - the latest value of a key, byte for byte
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	response, err := c.send(ctx, "GET", c.url("/kv/"+url.PathEscape(key)+"/raw", nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return ioutil.ReadAll(response.Body)
+}
+
+// GetAsOf - the value of a key as it was at the given instant, plain values
+// come JSON encoded as a string while documents come as they are
+func (c *Client) GetAsOf(ctx context.Context, key string, asOf time.Time) ([]byte, error) {
+	query := url.Values{"as_of": {asOf.UTC().Format(time.RFC3339)}}
+	response, err := c.send(ctx, "GET", c.url("/kv/"+url.PathEscape(key), query), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	var value string
+	if err := json.Unmarshal(body, &value); err == nil {
+		return []byte(value), nil
+	}
+	return body, nil
+}
+
+// Set - sets the value of a key, the value is streamed to the server
+func (c *Client) Set(ctx context.Context, key string, value io.Reader) error {
+	return c.do(ctx, "POST", "/kv/"+url.PathEscape(key), nil, value, nil)
+}
+
+// SetDocument - sets a JSON document as the value of a key
+func (c *Client) SetDocument(ctx context.Context, key string, document []byte) error {
+	return c.do(ctx, "POST", "/kv/"+url.PathEscape(key)+"/document", nil, bytes.NewReader(document), nil)
+}
+
+// Delete - deletes a key
+func (c *Client) Delete(ctx context.Context, key string) error {
+	return c.do(ctx, "DELETE", "/kv/"+url.PathEscape(key), nil, nil, nil)
+}
+
+// History - every retained version of a key, oldest first
+func (c *Client) History(ctx context.Context, key string) ([]Version, error) {
+	var versions []Version
+	err := c.do(ctx, "GET", "/kv/"+url.PathEscape(key)+"/history", nil, nil, &versions)
+	return versions, err
+}
+
+// GetMetadata - one metadata value of a key
+func (c *Client) GetMetadata(ctx context.Context, key string, metadataKey string) (string, error) {
+	var value string
+	err := c.do(ctx, "GET", c.metadataPath(key, metadataKey), nil, nil, &value)
+	return value, err
+}
+
+// SetMetadata - sets one metadata value of a key
+func (c *Client) SetMetadata(ctx context.Context, key string, metadataKey string, value string) error {
+	return c.do(ctx, "POST", c.metadataPath(key, metadataKey), nil, strings.NewReader(value), nil)
+}
+
+// DeleteMetadata - deletes one metadata value of a key
+func (c *Client) DeleteMetadata(ctx context.Context, key string, metadataKey string) error {
+	return c.do(ctx, "DELETE", c.metadataPath(key, metadataKey), nil, nil, nil)
+}
+
+// ListMetadata - all metadata of a key
+func (c *Client) ListMetadata(ctx context.Context, key string) (map[string]string, error) {
+	var metadata map[string]string
+	err := c.do(ctx, "GET", "/kv/"+url.PathEscape(key)+"/metadata", nil, nil, &metadata)
+	return metadata, err
+}
+
+// Find - the keys starting with a prefix
+func (c *Client) Find(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := c.do(ctx, "GET", "/kv/search/"+url.PathEscape(prefix), nil, nil, &keys)
+	return keys, err
+}
+
+// FindByMetadata - the keys whose metadata matches a query
+func (c *Client) FindByMetadata(ctx context.Context, query string) ([]string, error) {
+	var keys []string
+	err := c.do(ctx, "GET", "/kv/search/metadata/"+url.PathEscape(query), nil, nil, &keys)
+	return keys, err
+}
+
+// Status - the status of the server
+func (c *Client) Status(ctx context.Context) (map[string]interface{}, error) {
+	var status map[string]interface{}
+	err := c.doURL(ctx, "GET", c.baseURL+"/api/server/status", nil, &status)
+	return status, err
+}
+
+// Watch - calls fn with every change event matching the filter, until the
+// context ends, the server ends the stream or fn returns an error
+func (c *Client) Watch(ctx context.Context, filter WatchFilter, fn func(Event) error) error {
+	query := url.Values{}
+	if filter.Key != "" {
+		query.Set("key", filter.Key)
+	}
+	if filter.Prefix != "" {
+		query.Set("prefix", filter.Prefix)
+	}
+	if filter.MetadataQuery != "" {
+		query.Set("query", filter.MetadataQuery)
+	}
+	if filter.FromRevision > 0 {
+		query.Set("from_revision", fmt.Sprint(filter.FromRevision))
+	}
+
+	response, err := c.send(ctx, "GET", c.url("/watch", query), nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Server-Sent Events, only the data lines are needed as they carry the
+	// whole event
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			return fmt.Errorf("decoding event: %w", err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// Helper Functions
+// metadataPath - path of one metadata value
+func (c *Client) metadataPath(key string, metadataKey string) string {
+	return "/kv/" + url.PathEscape(key) + "/metadata/" + url.PathEscape(metadataKey)
+}
+
+// url - URL of a data route, under the tenant of the client
+func (c *Client) url(path string, query url.Values) string {
+	prefix := c.baseURL + "/api"
+	if c.tenant != "" {
+		prefix += "/tenants/" + url.PathEscape(c.tenant)
+	}
+	if len(query) > 0 {
+		return prefix + path + "?" + query.Encode()
+	}
+	return prefix + path
+}
+
+// do - sends a request to a data route and decodes the JSON response into out
+// when given
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body io.Reader, out interface{}) error {
+	return c.doURL(ctx, method, c.url(path, query), body, out)
+}
+
+func (c *Client) doURL(ctx context.Context, method string, target string, body io.Reader, out interface{}) error {
+	response, err := c.send(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if out == nil {
+		io.Copy(ioutil.Discard, response.Body)
+		return nil
+	}
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// send - sends a request, error statuses are returned as *Error
+func (c *Client) send(ctx context.Context, method string, target string, body io.Reader) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 300 {
+		defer response.Body.Close()
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 64*1024))
+		return nil, &Error{StatusCode: response.StatusCode, Message: strings.TrimSpace(string(message))}
+	}
+	return response, nil
+}
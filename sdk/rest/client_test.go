@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test that requests reach the routes of the client's tenant and error
+// statuses come back as *Error
+func TestClient(t *testing.T) {
+	// Arrange
+	values := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimSuffix(strings.TrimPrefix(r.URL.EscapedPath(), "/api/tenants/acme/kv/"), "/raw")
+		switch {
+		case !strings.HasPrefix(r.URL.Path, "/api/tenants/acme/kv/"):
+			http.Error(w, "wrong tenant", http.StatusBadRequest)
+		case r.Method == "POST":
+			body, _ := ioutil.ReadAll(r.Body)
+			values[key] = string(body)
+		case r.Method == "GET":
+			value, ok := values[key]
+			if !ok {
+				http.Error(w, "key not found", http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, value)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL+"/", "acme")
+	ctx := context.Background()
+
+	// Act
+	setErr := client.Set(ctx, "team/a", strings.NewReader("one"))
+	value, getErr := client.Get(ctx, "team/a")
+	_, missingErr := client.Get(ctx, "team/b")
+
+	// Assert
+	if setErr != nil || getErr != nil || string(value) != "one" {
+		t.Errorf("read back %q (%v, %v) instead of one", value, setErr, getErr)
+	}
+	var statusErr *Error
+	if !errors.As(missingErr, &statusErr) || statusErr.StatusCode != http.StatusNotFound || statusErr.Message != "key not found" {
+		t.Errorf("missing key returned %v", missingErr)
+	}
+}
+
+// Test that watches decode the data lines of the event stream
+func TestClientWatch(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/watch" || r.URL.Query().Get("prefix") != "team/" || r.URL.Query().Get("from_revision") != "7" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: change\ndata: {\"revision\":7,\"key\":\"team/a\",\"operation\":\"set\",\"value\":\"one\"}\n\n")
+		fmt.Fprint(w, ": keep alive\n\n")
+		fmt.Fprint(w, "data: {\"revision\":8,\"key\":\"team/a\",\"operation\":\"delete\"}\n\n")
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, "")
+
+	// Act
+	events := []Event{}
+	err := client.Watch(context.Background(), WatchFilter{Prefix: "team/", FromRevision: 7}, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("watch returned error %v", err)
+	}
+	if len(events) != 2 || events[0].Value != "one" || events[1].Revision != 8 || events[1].Operation != "delete" {
+		t.Errorf("received %+v", events)
+	}
+}
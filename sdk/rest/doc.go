@@ -2,5 +2,6 @@ package rest
 
 /*
 	Package rest - This is the REST package for the application.
-	it is responsible for handling the REST API requests over HTTP/HTTPS
+	it is a client of the REST API over HTTP/HTTPS, reading and writing values,
+	metadata and history, searching keys and watching changes
 */
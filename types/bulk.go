@@ -0,0 +1,28 @@
+package types
+
+import "errors"
+
+// ErrKeyExists - the destination of a copy or rename already exists
+var ErrKeyExists = errors.New("key already exists")
+
+// CopyOptions - settings of copies and renames
+type CopyOptions struct {
+	// Prefix - source and destination are key prefixes rather than keys
+	Prefix bool
+	// History - copies every version rather than the latest value, renames
+	// always keep history
+	History bool
+	// Overwrite - replaces existing destinations
+	Overwrite bool
+	// DryRun - reports the affected keys without changing anything
+	DryRun bool
+}
+
+// BulkResult - keys affected by a bulk operation, destinations are index
+// aligned with keys for copies and renames
+type BulkResult struct {
+	Count        int      `json:"count"`
+	Keys         []string `json:"keys"`
+	Destinations []string `json:"destinations,omitempty"`
+	DryRun       bool     `json:"dryRun"`
+}
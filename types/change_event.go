@@ -0,0 +1,92 @@
+package types
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Change operations
+const (
+	OperationSet            = "set"
+	OperationDelete         = "delete"
+	OperationUndelete       = "undelete"
+	OperationPurge          = "purge"
+	OperationSetMetadata    = "set_metadata"
+	OperationDeleteMetadata = "delete_metadata"
+)
+
+// ErrCompacted - the requested revision is no longer retained
+var ErrCompacted = errors.New("revision has been compacted")
+
+// ChangeEvent - a committed change to a record
+type ChangeEvent struct {
+	// Revision - server wide, monotonically increasing change number
+	Revision  uint64
+	Tenant    string
+	Key       string
+	Operation string
+	// Version - record version after the change, -1 once the record is gone
+	Version int
+	// PreviousVersion - record version before the change, -1 for new records
+	PreviousVersion int
+	Value           []byte
+	Metadata        map[string]string
+	MetadataDiff    MetadataDiff
+	Timestamp       time.Time
+}
+
+// MetadataDiff - metadata entries set or removed by a change
+type MetadataDiff struct {
+	Set     map[string]string `json:"set,omitempty"`
+	Removed []string          `json:"removed,omitempty"`
+}
+
+// DiffMetadata - computes the metadata difference between two snapshots
+func DiffMetadata(before map[string]string, after map[string]string) MetadataDiff {
+	diff := MetadataDiff{}
+	for key, value := range after {
+		if previous, found := before[key]; !found || previous != value {
+			if diff.Set == nil {
+				diff.Set = make(map[string]string)
+			}
+			diff.Set[key] = value
+		}
+	}
+	for key := range before {
+		if _, found := after[key]; !found {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// WatchFilter - selects the change events a watcher receives,
+// empty fields match everything
+type WatchFilter struct {
+	Tenant        string
+	Key           string
+	Prefix        string
+	MetadataQuery string
+	// FromRevision - replay retained events starting at this revision, 0 for live only
+	FromRevision uint64
+}
+
+// Matches - checks if a change event passes the filter
+func (f WatchFilter) Matches(event ChangeEvent) bool {
+	if f.Tenant != "" && event.Tenant != f.Tenant {
+		return false
+	}
+	if f.Key != "" && event.Key != f.Key {
+		return false
+	}
+	if f.Prefix != "" && !strings.HasPrefix(event.Key, f.Prefix) {
+		return false
+	}
+	if f.MetadataQuery != "" && !MetadataMatches(event.Metadata, f.MetadataQuery) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrValueTooLarge - the value is larger than the server accepts
+var ErrValueTooLarge = errors.New("value is too large")
+
+// ChunkManifest - stored in place of a value too large to keep in memory, the
+// value itself is split into chunks kept by the persistence layer
+type ChunkManifest struct {
+	// Blob - names the chunks of this value, unique per upload
+	Blob string `json:"blob"`
+	// Size - length of the whole value
+	Size int64 `json:"size"`
+	// ChunkSize - length of every chunk but the last
+	ChunkSize int `json:"chunkSize"`
+	// Chunks - number of chunks
+	Chunks int `json:"chunks"`
+}
+
+// ParseChunkManifest - decodes the manifest of a chunked value
+func ParseChunkManifest(raw []byte) (ChunkManifest, error) {
+	var manifest ChunkManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid chunk manifest: %v", err)
+	}
+	if manifest.Blob == "" || manifest.ChunkSize <= 0 || manifest.Size < 0 ||
+		int64(manifest.Chunks) != (manifest.Size+int64(manifest.ChunkSize)-1)/int64(manifest.ChunkSize) {
+		return manifest, fmt.Errorf("invalid chunk manifest")
+	}
+	return manifest, nil
+}
+
+// Encode - encodes the manifest for storage
+func (m ChunkManifest) Encode() []byte {
+	raw, _ := json.Marshal(m)
+	return raw
+}
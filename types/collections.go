@@ -0,0 +1,97 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Structured values are stored as JSON in the record value, lists as arrays,
+// sets as sorted arrays without duplicates and hashes as objects, so plain
+// reads still return something readable
+
+// DecodeList - decodes a stored list, nil decodes to an empty list
+func DecodeList(value []byte) ([]string, error) {
+	list := make([]string, 0)
+	if len(value) == 0 {
+		return list, nil
+	}
+	err := json.Unmarshal(value, &list)
+	return list, err
+}
+
+// EncodeList - encodes a list for storage
+func EncodeList(list []string) []byte {
+	if list == nil {
+		list = make([]string, 0)
+	}
+	encoded, _ := json.Marshal(list)
+	return encoded
+}
+
+// DecodeSet - decodes a stored set into its members
+func DecodeSet(value []byte) (map[string]bool, error) {
+	list, err := DecodeList(value)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(list))
+	for _, member := range list {
+		set[member] = true
+	}
+	return set, nil
+}
+
+// EncodeSet - encodes set members for storage, sorted so equal sets store equal bytes
+func EncodeSet(set map[string]bool) []byte {
+	return EncodeList(SetMembers(set))
+}
+
+// SetMembers - sorted members of a set
+func SetMembers(set map[string]bool) []string {
+	members := make([]string, 0, len(set))
+	for member := range set {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+	return members
+}
+
+// DecodeHash - decodes a stored hash, nil decodes to an empty hash
+func DecodeHash(value []byte) (map[string]string, error) {
+	hash := make(map[string]string)
+	if len(value) == 0 {
+		return hash, nil
+	}
+	err := json.Unmarshal(value, &hash)
+	return hash, err
+}
+
+// EncodeHash - encodes a hash for storage
+func EncodeHash(hash map[string]string) []byte {
+	if hash == nil {
+		hash = make(map[string]string)
+	}
+	encoded, _ := json.Marshal(hash)
+	return encoded
+}
+
+// ListRange - elements between start and stop inclusive, negative indexes
+// count from the end of the list
+func ListRange(list []string, start int, stop int) []string {
+	if start < 0 {
+		start += len(list)
+	}
+	if stop < 0 {
+		stop += len(list)
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= len(list) {
+		stop = len(list) - 1
+	}
+	if start > stop {
+		return make([]string, 0)
+	}
+	return list[start : stop+1]
+}
@@ -0,0 +1,18 @@
+package types
+
+// ConfigSetting - the effective value of a setting and where it came from
+type ConfigSetting struct {
+	// Name - dotted name of the setting
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	// Secret - the value is redacted, when set
+	Secret bool `json:"secret,omitempty"`
+}
+
+// ConfigReport - the configuration a server runs with
+type ConfigReport struct {
+	Settings []ConfigSetting `json:"settings"`
+	// Pending - settings changed since the server started that wait for a restart
+	Pending []string `json:"pending"`
+}
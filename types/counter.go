@@ -0,0 +1,37 @@
+package types
+
+import "errors"
+
+// Errors shared by typed value operations
+var (
+	// ErrTypeMismatch - the operation does not apply to the stored value type
+	ErrTypeMismatch = errors.New("operation does not match the value type")
+	// ErrOutOfBounds - the result would leave the allowed range
+	ErrOutOfBounds = errors.New("result is out of bounds")
+	// ErrEmptyCollection - nothing left to pop
+	ErrEmptyCollection = errors.New("collection is empty")
+)
+
+// MetadataValueType - metadata key recording how a value is encoded
+const MetadataValueType = "ValueType"
+
+// Value types
+const (
+	ValueTypeCounter  = "counter"
+	ValueTypeSequence = "sequence"
+	ValueTypeList     = "list"
+	ValueTypeSet      = "set"
+	ValueTypeHash     = "hash"
+	ValueTypeDocument = "document"
+	ValueTypeChunked  = "chunked"
+	ValueTypeLock     = "lock"
+)
+
+// CounterOptions - optional settings for counter increments
+type CounterOptions struct {
+	// Initial - value assumed when the key does not exist yet
+	Initial int64
+	// Min, Max - inclusive bounds, nil for unbounded
+	Min *int64
+	Max *int64
+}
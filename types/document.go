@@ -0,0 +1,375 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by document operations
+var (
+	// ErrInvalidDocument - a document, path or patch is not well formed
+	ErrInvalidDocument = errors.New("invalid document")
+	// ErrPathNotFound - a document path does not exist
+	ErrPathNotFound = errors.New("document path not found")
+	// ErrPatchTestFailed - a JSON Patch test operation did not match
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// Documents are JSON values, paths are either JSON Pointers (RFC 6901) such as
+// "/address/city" or dotted paths such as "address.city", array elements are
+// addressed by index
+
+// DocumentGet - selects the value at a path, an empty path selects the whole document
+func DocumentGet(document []byte, path string) ([]byte, error) {
+	root, err := decodeDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := documentLookup(root, tokens)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node)
+}
+
+// ApplyJSONPatch - applies an RFC 6902 JSON Patch, either every operation
+// applies or the document is left unchanged
+func ApplyJSONPatch(document []byte, patch []byte) ([]byte, error) {
+	root, err := decodeDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []struct {
+		Op    string          `json:"op"`
+		Path  *string         `json:"path"`
+		From  *string         `json:"from"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: patch must be an array of operations", ErrInvalidDocument)
+	}
+
+	for i, operation := range operations {
+		if operation.Path == nil {
+			return nil, fmt.Errorf("%w: operation %d has no path", ErrInvalidDocument, i)
+		}
+		path, err := parsePointer(*operation.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		var from []string
+		if operation.Op == "move" || operation.Op == "copy" {
+			if operation.From == nil {
+				return nil, fmt.Errorf("%w: operation %d has no from", ErrInvalidDocument, i)
+			}
+			if from, err = parsePointer(*operation.From); err != nil {
+				return nil, err
+			}
+		}
+
+		var value interface{}
+		if operation.Op == "add" || operation.Op == "replace" || operation.Op == "test" {
+			if operation.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d has no value", ErrInvalidDocument, i)
+			}
+			if value, err = decodeDocument(operation.Value); err != nil {
+				return nil, err
+			}
+		}
+
+		switch operation.Op {
+		case "add":
+			root, err = documentAdd(root, path, value, false)
+		case "replace":
+			root, err = documentAdd(root, path, value, true)
+		case "remove":
+			root, _, err = documentRemove(root, path)
+		case "move":
+			var moved interface{}
+			if root, moved, err = documentRemove(root, from); err == nil {
+				root, err = documentAdd(root, path, moved, false)
+			}
+		case "copy":
+			var copied interface{}
+			if copied, err = documentLookup(root, from); err == nil {
+				copied, err = documentClone(copied)
+			}
+			if err == nil {
+				root, err = documentAdd(root, path, copied, false)
+			}
+		case "test":
+			var current interface{}
+			if current, err = documentLookup(root, path); err == nil && !documentEqual(current, value) {
+				err = fmt.Errorf("%w: %v", ErrPatchTestFailed, *operation.Path)
+			}
+		default:
+			err = fmt.Errorf("%w: unknown operation %q", ErrInvalidDocument, operation.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(root)
+}
+
+// ApplyMergePatch - applies an RFC 7386 JSON Merge Patch
+func ApplyMergePatch(document []byte, patch []byte) ([]byte, error) {
+	root, err := decodeDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decodeDocument(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(root, changes))
+}
+
+// FlattenDocument - maps every scalar in a document to its dotted path, so
+// documents can be queried like metadata
+func FlattenDocument(document []byte) (map[string]string, error) {
+	root, err := decodeDocument(document)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]string)
+	flatten(root, "", fields)
+	return fields, nil
+}
+
+// Helper Functions
+func decodeDocument(document []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("%w: trailing data", ErrInvalidDocument)
+	}
+	return root, nil
+}
+
+// parsePath - accepts a JSON Pointer or a dotted path
+func parsePath(path string) ([]string, error) {
+	if path == "" || strings.HasPrefix(path, "/") {
+		return parsePointer(path)
+	}
+	return strings.Split(path, "."), nil
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidDocument, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidDocument, token)
+	}
+	if index > length || (!allowEnd && index == length) {
+		return 0, fmt.Errorf("%w: index %d", ErrPathNotFound, index)
+	}
+	return index, nil
+}
+
+func documentLookup(node interface{}, tokens []string) (interface{}, error) {
+	for _, token := range tokens {
+		switch current := node.(type) {
+		case map[string]interface{}:
+			child, ok := current[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+			}
+			node = child
+		case []interface{}:
+			index, err := arrayIndex(token, len(current), false)
+			if err != nil {
+				return nil, err
+			}
+			node = current[index]
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+		}
+	}
+	return node, nil
+}
+
+// documentAdd - adds or, when replace is set, replaces the value at a path
+func documentAdd(node interface{}, tokens []string, value interface{}, replace bool) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	token, last := tokens[0], len(tokens) == 1
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		child, ok := current[token]
+		if last {
+			if replace && !ok {
+				return nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+			}
+			current[token] = value
+			return current, nil
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+		}
+		updated, err := documentAdd(child, tokens[1:], value, replace)
+		if err != nil {
+			return nil, err
+		}
+		current[token] = updated
+		return current, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(current), last && !replace)
+		if err != nil {
+			return nil, err
+		}
+		if last && !replace {
+			current = append(current, nil)
+			copy(current[index+1:], current[index:])
+			current[index] = value
+			return current, nil
+		}
+		if last {
+			current[index] = value
+			return current, nil
+		}
+		updated, err := documentAdd(current[index], tokens[1:], value, replace)
+		if err != nil {
+			return nil, err
+		}
+		current[index] = updated
+		return current, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+	}
+}
+
+// documentRemove - removes the value at a path, returning it
+func documentRemove(node interface{}, tokens []string) (interface{}, interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidDocument)
+	}
+	token, last := tokens[0], len(tokens) == 1
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		child, ok := current[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+		}
+		if last {
+			delete(current, token)
+			return current, child, nil
+		}
+		updated, removed, err := documentRemove(child, tokens[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		current[token] = updated
+		return current, removed, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(current), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if last {
+			removed := current[index]
+			return append(current[:index], current[index+1:]...), removed, nil
+		}
+		updated, removed, err := documentRemove(current[index], tokens[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		current[index] = updated
+		return current, removed, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %v", ErrPathNotFound, token)
+	}
+}
+
+func documentClone(node interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDocument(encoded)
+}
+
+// documentEqual - compares canonical encodings, object keys marshal sorted
+func documentEqual(a interface{}, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{})
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = mergePatch(object[key], value)
+	}
+	return object
+}
+
+func flatten(node interface{}, prefix string, fields map[string]string) {
+	join := func(token string) string {
+		if prefix == "" {
+			return token
+		}
+		return prefix + "." + token
+	}
+
+	switch current := node.(type) {
+	case map[string]interface{}:
+		for key, child := range current {
+			flatten(child, join(key), fields)
+		}
+	case []interface{}:
+		for index, child := range current {
+			flatten(child, join(strconv.Itoa(index)), fields)
+		}
+	case string:
+		fields[prefix] = current
+	case nil:
+		fields[prefix] = "null"
+	default:
+		fields[prefix] = fmt.Sprintf("%v", current)
+	}
+}
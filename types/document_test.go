@@ -0,0 +1,82 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test JSON Patch operations, including the RFC 6902 examples
+func TestApplyJSONPatch(t *testing.T) {
+	cases := []struct {
+		document string
+		patch    string
+		result   string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"baz"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"baz":"qux"}`, `[{"op":"replace","path":"/baz","value":1.50}]`, `{"baz":1.50}`},
+		{`{"foo":{"bar":"baz"},"q":{}}`, `[{"op":"move","from":"/foo/bar","path":"/q/bar"}]`, `{"foo":{},"q":{"bar":"baz"}}`},
+		{`{"a/b":1}`, `[{"op":"copy","from":"/a~1b","path":"/c"}]`, `{"a/b":1,"c":1}`},
+	}
+
+	for _, c := range cases {
+		// Act
+		result, err := ApplyJSONPatch([]byte(c.document), []byte(c.patch))
+
+		// Assert
+		if err != nil || string(result) != c.result {
+			t.Errorf("patch %s on %s = %s (%v) instead of %s", c.patch, c.document, result, err, c.result)
+		}
+	}
+}
+
+// Test that failing patches report why
+func TestApplyJSONPatchErrors(t *testing.T) {
+	document := []byte(`{"foo":"bar"}`)
+	cases := []struct {
+		patch string
+		err   error
+	}{
+		{`[{"op":"test","path":"/foo","value":"baz"}]`, ErrPatchTestFailed},
+		{`[{"op":"remove","path":"/missing"}]`, ErrPathNotFound},
+		{`[{"op":"replace","path":"/missing","value":1}]`, ErrPathNotFound},
+		{`[{"op":"jump","path":"/foo"}]`, ErrInvalidDocument},
+		{`{"op":"add"}`, ErrInvalidDocument},
+	}
+
+	for _, c := range cases {
+		// Act
+		_, err := ApplyJSONPatch(document, []byte(c.patch))
+
+		// Assert
+		if !errors.Is(err, c.err) {
+			t.Errorf("patch %s returned %v instead of %v", c.patch, err, c.err)
+		}
+	}
+}
+
+// Test merge patches, path reads and flattening
+func TestDocumentMergeGetAndFlatten(t *testing.T) {
+	// Arrange
+	document := []byte(`{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"]}`)
+
+	// Act
+	merged, err := ApplyMergePatch(document, []byte(`{"title":"Hello!","author":{"familyName":null},"tags":["example"]}`))
+
+	// Assert
+	if err != nil || string(merged) != `{"author":{"givenName":"John"},"tags":["example"],"title":"Hello!"}` {
+		t.Errorf("merge patch = %s (%v)", merged, err)
+	}
+	if value, err := DocumentGet(document, "/author/givenName"); err != nil || string(value) != `"John"` {
+		t.Errorf("pointer read = %s (%v)", value, err)
+	}
+	if value, err := DocumentGet(document, "tags.1"); err != nil || string(value) != `"sample"` {
+		t.Errorf("dotted read = %s (%v)", value, err)
+	}
+	fields, _ := FlattenDocument(document)
+	if fields["author.familyName"] != "Doe" || fields["tags.0"] != "example" {
+		t.Errorf("flattened fields are %v", fields)
+	}
+}
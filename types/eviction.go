@@ -0,0 +1,153 @@
+package types
+
+import (
+	"container/heap"
+	"container/list"
+	"fmt"
+)
+
+// Eviction policies
+// pick which resident record leaves memory first once the memory limit is reached
+const (
+	// EvictionPolicyLRU - evicts the least recently used record
+	EvictionPolicyLRU = "lru"
+	// EvictionPolicyLFU - evicts the least frequently used record, oldest first on ties
+	EvictionPolicyLFU = "lfu"
+)
+
+// evictionPolicy - orders resident keys by how cold they are
+type evictionPolicy interface {
+	// touch - records an access to a key, adding it when new
+	touch(key string)
+	// remove - forgets a key
+	remove(key string)
+	// victim - the coldest key other than skip
+	victim(skip string) (string, bool)
+}
+
+func newEvictionPolicy(name string) (evictionPolicy, error) {
+	switch name {
+	case EvictionPolicyLRU, "":
+		return &lruPolicy{order: list.New(), elements: make(map[string]*list.Element)}, nil
+	case EvictionPolicyLFU:
+		return &lfuPolicy{entries: make(map[string]*lfuEntry)}, nil
+	default:
+		return nil, fmt.Errorf("unknown eviction policy '%s'", name)
+	}
+}
+
+// lruPolicy - keys in access order, most recent at the front
+type lruPolicy struct {
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+func (p *lruPolicy) touch(key string) {
+	if element, ok := p.elements[key]; ok {
+		p.order.MoveToFront(element)
+		return
+	}
+	p.elements[key] = p.order.PushFront(key)
+}
+
+func (p *lruPolicy) remove(key string) {
+	if element, ok := p.elements[key]; ok {
+		p.order.Remove(element)
+		delete(p.elements, key)
+	}
+}
+
+func (p *lruPolicy) victim(skip string) (string, bool) {
+	for element := p.order.Back(); element != nil; element = element.Prev() {
+		if key := element.Value.(string); key != skip {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// lfuPolicy - a min heap of keys by access count, then by last access
+type lfuPolicy struct {
+	heap    lfuHeap
+	entries map[string]*lfuEntry
+	clock   uint64
+}
+
+type lfuEntry struct {
+	key   string
+	count uint64
+	tick  uint64
+	index int
+}
+
+func (p *lfuPolicy) touch(key string) {
+	p.clock++
+	if entry, ok := p.entries[key]; ok {
+		entry.count++
+		entry.tick = p.clock
+		heap.Fix(&p.heap, entry.index)
+		return
+	}
+	entry := &lfuEntry{key: key, count: 1, tick: p.clock}
+	p.entries[key] = entry
+	heap.Push(&p.heap, entry)
+}
+
+func (p *lfuPolicy) remove(key string) {
+	if entry, ok := p.entries[key]; ok {
+		heap.Remove(&p.heap, entry.index)
+		delete(p.entries, key)
+	}
+}
+
+func (p *lfuPolicy) victim(skip string) (string, bool) {
+	if len(p.heap) == 0 {
+		return "", false
+	}
+	if p.heap[0].key != skip {
+		return p.heap[0].key, true
+	}
+
+	// the next coldest key is one of the root's children
+	next := -1
+	for _, child := range []int{1, 2} {
+		if child < len(p.heap) && (next < 0 || p.heap.Less(child, next)) {
+			next = child
+		}
+	}
+	if next < 0 {
+		return "", false
+	}
+	return p.heap[next].key, true
+}
+
+// lfuHeap - heap.Interface over lfu entries
+type lfuHeap []*lfuEntry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count < h[j].count
+	}
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x interface{}) {
+	entry := x.(*lfuEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap) Pop() interface{} {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// Errors of feature flags
+var (
+	// ErrFlagNotFound - no flag of that name is stored or defined
+	ErrFlagNotFound = errors.New("feature flag not found")
+	// ErrInvalidFlag - a flag is not well formed
+	ErrInvalidFlag = errors.New("invalid feature flag")
+)
+
+// FlagNamespace - reserved key prefix holding feature flags, a flag is stored
+// as a document at FlagNamespace + name
+const FlagNamespace = "_flag/"
+
+// FeatureFlag - a switch evaluated at runtime, for everyone, for a percentage
+// of tenants and subjects, or for listed tenants
+type FeatureFlag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Enabled - turns the flag off for everyone when false, whatever the
+	// rollout and tenants
+	Enabled bool `json:"enabled"`
+	// Rollout - percentage of tenants and subjects the flag is on for, 1 to
+	// 99, zero or 100 for everyone
+	Rollout int `json:"rollout,omitempty"`
+	// Tenants - tenants the flag is turned on or off for, ahead of the rollout
+	Tenants map[string]bool `json:"tenants,omitempty"`
+}
+
+// ParseFeatureFlag - reads and validates a stored flag
+func ParseFeatureFlag(data []byte) (FeatureFlag, error) {
+	var flag FeatureFlag
+	if err := json.Unmarshal(data, &flag); err != nil {
+		return flag, fmt.Errorf("%w: %v", ErrInvalidFlag, err)
+	}
+	return flag, flag.Validate()
+}
+
+// Validate - checks the name, rollout and tenants of a flag
+func (f FeatureFlag) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("%w: name cannot be empty", ErrInvalidFlag)
+	}
+	if f.Rollout < 0 || f.Rollout > 100 {
+		return fmt.Errorf("%w: rollout %d is not a percentage", ErrInvalidFlag, f.Rollout)
+	}
+	for tenant := range f.Tenants {
+		if tenant == DefaultTenant {
+			continue
+		}
+		if err := ValidateTenant(tenant); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFlag, err)
+		}
+	}
+	return nil
+}
+
+// EnabledFor - evaluates the flag for a tenant and a subject, such as a key or
+// a user, the same tenant and subject always land on the same side of a rollout
+func (f FeatureFlag) EnabledFor(tenant string, subject string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if enabled, ok := f.Tenants[tenant]; ok {
+		return enabled
+	}
+	if f.Rollout <= 0 || f.Rollout >= 100 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(f.Name + "/" + tenant + "/" + subject))
+	return int(hash.Sum32()%100) < f.Rollout
+}
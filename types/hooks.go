@@ -0,0 +1,60 @@
+package types
+
+import "errors"
+
+// ErrHookRejected - a pre-write hook refused the operation
+var ErrHookRejected = errors.New("rejected by hook")
+
+// WriteOperation - a write about to be applied, as seen by pre-write hooks
+type WriteOperation struct {
+	Tenant string `json:"tenant"`
+	Key    string `json:"key"`
+	// Operation - OperationSet, OperationDelete, OperationUndelete,
+	// OperationSetMetadata or OperationDeleteMetadata
+	Operation string `json:"operation"`
+	// Value - the value being set, nil for streamed values past one chunk
+	Value []byte `json:"value,omitempty"`
+	// Size - the length of the value being set
+	Size          int64  `json:"size,omitempty"`
+	MetadataKey   string `json:"metadataKey,omitempty"`
+	MetadataValue string `json:"metadataValue,omitempty"`
+}
+
+// Hook - a plugin invoked around the writes of clients
+type Hook interface {
+	// Name - identifies the hook in errors and logs
+	Name() string
+	// BeforeWrite - called before the write is applied, may change the value or
+	// the metadata value of the operation, returning an error rejects it
+	BeforeWrite(op *WriteOperation) error
+	// AfterWrite - called with every committed change, purges included, in
+	// revision order and off the write path
+	AfterWrite(event ChangeEvent)
+}
+
+// HookFuncs - a Hook made of functions, either function may be nil
+type HookFuncs struct {
+	HookName string
+	Before   func(op *WriteOperation) error
+	After    func(event ChangeEvent)
+}
+
+// Name - the name of the hook
+func (h HookFuncs) Name() string {
+	return h.HookName
+}
+
+// BeforeWrite - calls Before when set
+func (h HookFuncs) BeforeWrite(op *WriteOperation) error {
+	if h.Before == nil {
+		return nil
+	}
+	return h.Before(op)
+}
+
+// AfterWrite - calls After when set
+func (h HookFuncs) AfterWrite(event ChangeEvent) {
+	if h.After != nil {
+		h.After(event)
+	}
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +12,7 @@ import (
 type KVRecord struct {
 	// define uuid id
 	Id       uuid.UUID
+	Tenant   string
 	Key      string
 	Value    *ValuesContainer
 	Metadata *MetadataContainer
@@ -29,6 +31,36 @@ func NewKVRecord(key string, value []byte) *KVRecord {
 	return record
 }
 
+// TenantName - A function that gets the tenant of a KV Record, records without
+// one belong to the default tenant
+func (r *KVRecord) TenantName() string {
+	if r.Tenant == "" {
+		return DefaultTenant
+	}
+	return r.Tenant
+}
+
+// StorageKey - A function that gets the key a KV Record is stored under
+func (r *KVRecord) StorageKey() string {
+	return StorageKey(r.Tenant, r.Key)
+}
+
+// Clone - A function that copies a KV Record with its history under another key,
+// versions share their value bytes, which are never modified in place
+func (r *KVRecord) Clone(tenant string, key string) KVRecord {
+	values, timestamps, tombstones := r.Value.History()
+	clone := KVRecord{
+		Id:       uuid.New(),
+		Tenant:   tenant,
+		Key:      key,
+		Value:    &ValuesContainer{Value: values, Timestamps: timestamps, Tombstones: tombstones},
+		Metadata: NewMetadataContainer(),
+	}
+	clone.Metadata.Metadata = r.Metadata.Copy()
+	clone.Metadata.History = r.Metadata.GetHistory()
+	return clone
+}
+
 // Set Metadata - A function that sets the metadata for a KV Record
 func (r *KVRecord) SetMetadata(key string, value string) (int, error) {
 	// if the key is empty return error
@@ -140,3 +172,65 @@ func (r *KVRecord) GetValue(version int) (value []byte, err error) {
 	// if version is a positive number return the value at that index
 	return r.Value.Get(version)
 }
+
+// Get Value at a point in time
+func (r *KVRecord) GetValueAt(at time.Time) (value []byte, err error) {
+	value, version, err := r.Value.GetAt(at)
+	if err != nil {
+		return nil, err
+	}
+
+	// a tombstone means the key was deleted at that instant
+	if r.Value.IsTombstone(version) {
+		return nil, fmt.Errorf("%w: %v", ErrKeyNotFound, r.Key)
+	}
+	return value, nil
+}
+
+// List Metadata at a point in time
+func (r *KVRecord) ListMetadataAt(at time.Time) (metadata map[string]string, err error) {
+	// metadata history starts with the record, so anything older is out of range
+	if _, err := r.GetValueAt(at); err != nil {
+		return nil, err
+	}
+
+	return r.Metadata.GetAllAt(at), nil
+}
+
+// Existed At - checks if the record was live at the given instant
+func (r *KVRecord) ExistedAt(at time.Time) bool {
+	_, err := r.GetValueAt(at)
+	return err == nil
+}
+
+// Is Deleted - checks if the latest version is a soft delete tombstone
+func (r *KVRecord) IsDeleted() bool {
+	return r.Value.IsTombstone(-1)
+}
+
+// Mark Deleted - writes a tombstone version, keeping the history
+func (r *KVRecord) MarkDeleted() (version int, err error) {
+	if r.IsDeleted() {
+		return -1, fmt.Errorf("key already deleted")
+	}
+
+	version = r.Value.SetTombstone()
+	r.Metadata.Set("Version", strconv.Itoa(version))
+	return version, nil
+}
+
+// Restore - writes the last live value back as a new version
+func (r *KVRecord) Restore() (version int, err error) {
+	if !r.IsDeleted() {
+		return -1, fmt.Errorf("key is not deleted")
+	}
+
+	value, _, err := r.Value.GetLastLive()
+	if err != nil {
+		return -1, err
+	}
+
+	version = r.Value.Set(value)
+	r.Metadata.Set("Version", strconv.Itoa(version))
+	return version, nil
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+// Errors of leases and locks
+var (
+	// ErrLeaseNotFound - the lease expired, was revoked or never existed
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrLockHeld - the lock is held by another lease
+	ErrLockHeld = errors.New("lock is held")
+	// ErrLockNotHeld - the fencing token does not name the current holder
+	ErrLockNotHeld = errors.New("lock is not held by this token")
+)
+
+// LockNamespace - reserved key prefix of lock records, a lock record holds the
+// id of its holder's lease and its fencing token, or nothing once released
+const LockNamespace = "_lock/"
+
+// MetadataLease - metadata key naming the lease a key is attached to
+const MetadataLease = "Lease"
+
+// Lease - a time to live shared by the keys and locks attached to it
+type Lease struct {
+	ID        int64     `json:"id"`
+	TTL       int64     `json:"ttl"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Keys      []string  `json:"keys"`
+	Locks     []string  `json:"locks"`
+}
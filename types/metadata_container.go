@@ -1,15 +1,29 @@
 package types
 
-import "sync"
+import (
+	"sync"
+	"time"
+)
+
+// MetadataChange - a single committed change to a metadata entry
+type MetadataChange struct {
+	Key         string
+	Value       string
+	Deleted     bool
+	CommittedAt time.Time
+}
 
 type MetadataContainer struct {
 	mu       sync.Mutex
 	Metadata map[string]string
+	// History - ordered log of metadata changes, used for point in time reads
+	History []MetadataChange
 }
 
 func NewMetadataContainer() *MetadataContainer {
 	return &MetadataContainer{
 		Metadata: make(map[string]string),
+		History:  make([]MetadataChange, 0),
 	}
 }
 
@@ -17,6 +31,11 @@ func (c *MetadataContainer) Set(key string, value string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.Metadata[key] = value
+	c.History = append(c.History, MetadataChange{
+		Key:         key,
+		Value:       value,
+		CommittedAt: time.Now().UTC(),
+	})
 }
 
 func (c *MetadataContainer) Get(key string) (string, bool) {
@@ -30,6 +49,11 @@ func (c *MetadataContainer) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	delete(c.Metadata, key)
+	c.History = append(c.History, MetadataChange{
+		Key:         key,
+		Deleted:     true,
+		CommittedAt: time.Now().UTC(),
+	})
 }
 
 func (c *MetadataContainer) GetAll() map[string]string {
@@ -37,3 +61,41 @@ func (c *MetadataContainer) GetAll() map[string]string {
 	defer c.mu.Unlock()
 	return c.Metadata
 }
+
+// Copy - returns a snapshot of the metadata that is safe to read without the lock
+func (c *MetadataContainer) Copy() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metadata := make(map[string]string, len(c.Metadata))
+	for key, value := range c.Metadata {
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// GetAllAt - replays the change history up to the given instant
+func (c *MetadataContainer) GetAllAt(at time.Time) map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metadata := make(map[string]string)
+	for _, change := range c.History {
+		if change.CommittedAt.After(at) {
+			break
+		}
+		if change.Deleted {
+			delete(metadata, change.Key)
+			continue
+		}
+		metadata[change.Key] = change.Value
+	}
+	return metadata
+}
+
+// GetHistory - returns a copy of the metadata change log
+func (c *MetadataContainer) GetHistory() []MetadataChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	history := make([]MetadataChange, len(c.History))
+	copy(history, c.History)
+	return history
+}
@@ -0,0 +1,227 @@
+package types
+
+// Memory limit
+// records past the limit are evicted from memory, leaving only their key in the
+// container's index, and are faulted back in from persistence when read
+
+// MemoryLimit - the memory budget of a container and how it reaches persistence
+type MemoryLimit struct {
+	// Bytes - budget for resident records, see RecordSize
+	Bytes int64
+	// Policy - EvictionPolicyLRU or EvictionPolicyLFU
+	Policy string
+	// Load - reads an evicted record back by storage key
+	Load func(key string) (KVRecord, error)
+	// Store - writes a changed record back before it is evicted
+	Store func(record KVRecord) error
+}
+
+// CacheStats - counters of a memory limited container
+type CacheStats struct {
+	Policy        string `json:"policy"`
+	LimitBytes    int64  `json:"limitBytes"`
+	ResidentBytes int64  `json:"residentBytes"`
+	ResidentKeys  int    `json:"residentKeys"`
+	EvictedKeys   int    `json:"evictedKeys"`
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`
+}
+
+// recordCache - residency bookkeeping of a memory limited container
+type recordCache struct {
+	limit  MemoryLimit
+	policy evictionPolicy
+	// sizes of resident records
+	sizes    map[string]int64
+	resident int64
+	// resident records changed since they were loaded
+	dirty map[string]bool
+	// evicted keys, with whether the record was soft deleted
+	evicted map[string]bool
+	stats   CacheStats
+}
+
+// RecordSize - the approximate memory held by a record
+func RecordSize(record KVRecord) int64 {
+	size := int64(len(record.Tenant) + len(record.Key))
+	if record.Value != nil {
+		total, _ := record.Value.Size()
+		size += total
+	}
+	if record.Metadata != nil {
+		for _, change := range record.Metadata.GetHistory() {
+			size += int64(len(change.Key) + len(change.Value))
+		}
+	}
+	return size
+}
+
+// SetMemoryLimit - caps the memory held by records, evicting the coldest ones
+func (c *Container) SetMemoryLimit(limit MemoryLimit) error {
+	policy, err := newEvictionPolicy(limit.Policy)
+	if err != nil {
+		return err
+	}
+	if limit.Policy == "" {
+		limit.Policy = EvictionPolicyLRU
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = &recordCache{
+		limit:   limit,
+		policy:  policy,
+		sizes:   make(map[string]int64),
+		dirty:   make(map[string]bool),
+		evicted: make(map[string]bool),
+	}
+	c.cache.stats.Policy = limit.Policy
+	c.cache.stats.LimitBytes = limit.Bytes
+	for key, record := range c.Records {
+		c.admit(key, record, true)
+	}
+	return nil
+}
+
+// CacheStats - counters of the memory limit, false when the container is unlimited
+func (c *Container) CacheStats() (CacheStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache == nil {
+		return CacheStats{}, false
+	}
+	stats := c.cache.stats
+	stats.ResidentBytes = c.cache.resident
+	stats.ResidentKeys = len(c.Records)
+	stats.EvictedKeys = len(c.cache.evicted)
+	return stats, true
+}
+
+// get - a record by key, faulting an evicted record back in, callers hold the lock
+func (c *Container) get(key string) (KVRecord, bool) {
+	record, ok := c.Records[key]
+	if c.cache == nil {
+		return record, ok
+	}
+	if ok {
+		c.cache.stats.Hits++
+		c.cache.policy.touch(key)
+		return record, true
+	}
+	if _, evicted := c.cache.evicted[key]; !evicted {
+		return record, false
+	}
+
+	c.cache.stats.Misses++
+	record, err := c.cache.limit.Load(key)
+	if err != nil {
+		return KVRecord{}, false
+	}
+	delete(c.cache.evicted, key)
+	c.admit(key, record, false)
+	return record, true
+}
+
+// scanned - a record seen by a scan
+type scanned struct {
+	key    string
+	record KVRecord
+}
+
+// scan - the records whose key and soft deleted state are wanted, without
+// changing residency so scans do not flush the working set, resident records
+// are taken under the lock and evicted ones are read from persistence once it
+// is released, callers do not hold the lock
+func (c *Container) scan(want func(key string, deleted bool) bool) []scanned {
+	c.mu.Lock()
+	var records []scanned
+	for key, record := range c.Records {
+		if want(key, record.IsDeleted()) {
+			records = append(records, scanned{key: key, record: record})
+		}
+	}
+	var evicted []string
+	for key, deleted := range c.evictedKeys() {
+		if want(key, deleted) {
+			evicted = append(evicted, key)
+		}
+	}
+	var load func(key string) (KVRecord, error)
+	if c.cache != nil {
+		load = c.cache.limit.Load
+	}
+	c.mu.Unlock()
+
+	for _, key := range evicted {
+		if record, err := load(key); err == nil {
+			records = append(records, scanned{key: key, record: record})
+		}
+	}
+	return records
+}
+
+// evictedKeys - keys of evicted records, callers hold the lock
+func (c *Container) evictedKeys() map[string]bool {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.evicted
+}
+
+// put - stores a record, callers hold the lock
+func (c *Container) put(key string, record KVRecord, dirty bool) {
+	if c.cache == nil {
+		c.Records[key] = record
+		return
+	}
+	delete(c.cache.evicted, key)
+	c.admit(key, record, dirty)
+}
+
+// drop - forgets a record, callers hold the lock
+func (c *Container) drop(key string) {
+	delete(c.Records, key)
+	if c.cache == nil {
+		return
+	}
+	c.cache.resident -= c.cache.sizes[key]
+	delete(c.cache.sizes, key)
+	delete(c.cache.dirty, key)
+	delete(c.cache.evicted, key)
+	c.cache.policy.remove(key)
+}
+
+// admit - makes a record resident and evicts others past the limit
+func (c *Container) admit(key string, record KVRecord, dirty bool) {
+	c.Records[key] = record
+	size := RecordSize(record)
+	c.cache.resident += size - c.cache.sizes[key]
+	c.cache.sizes[key] = size
+	c.cache.dirty[key] = c.cache.dirty[key] || dirty
+	c.cache.policy.touch(key)
+	c.evict(key)
+}
+
+// evict - evicts the coldest records until within the limit, keeping the given key,
+// changed records are written back first and stay resident when that fails
+func (c *Container) evict(keep string) {
+	for c.cache.resident > c.cache.limit.Bytes {
+		key, ok := c.cache.policy.victim(keep)
+		if !ok {
+			return
+		}
+
+		record := c.Records[key]
+		if c.cache.dirty[key] {
+			if err := c.cache.limit.Store(record); err != nil {
+				return
+			}
+		}
+
+		deleted := record.IsDeleted()
+		c.drop(key)
+		c.cache.evicted[key] = deleted
+		c.cache.stats.Evictions++
+	}
+}
@@ -2,14 +2,17 @@ package types
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Container struct {
 	mu      sync.Mutex
 	Records map[string]KVRecord
+
+	// memory limit, nil when every record stays resident
+	cache *recordCache
 }
 
 func NewContainer() *Container {
@@ -21,51 +24,127 @@ func NewContainer() *Container {
 func (c *Container) Get(key string) (KVRecord, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	record, ok := c.Records[key]
-	return record, ok
+	return c.get(key)
 }
 
 func (c *Container) Set(key string, record KVRecord) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.Records[key] = record
+	c.put(key, record, true)
 }
 
 func (c *Container) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.Records, key)
+	c.drop(key)
+}
+
+// Has - checks for a key, resident or evicted, without faulting it in
+func (c *Container) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.Records[key]; ok {
+		return true
+	}
+	_, ok := c.evictedKeys()[key]
+	return ok
 }
 
 func (c *Container) Find(partialKey string) []string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	var keys []string
-	for key := range c.Records {
-		if partialKey == key[:len(partialKey)] {
+	for key, record := range c.Records {
+		if strings.HasPrefix(key, partialKey) && !record.IsDeleted() {
+			keys = append(keys, key)
+		}
+	}
+	for key, deleted := range c.evictedKeys() {
+		if strings.HasPrefix(key, partialKey) && !deleted {
 			keys = append(keys, key)
 		}
 	}
 	return keys
 }
 
+// FindAt - prefix scan over the records that existed at the given instant
+func (c *Container) FindAt(partialKey string, at time.Time) []string {
+	var keys []string
+	for _, found := range c.scan(func(key string, _ bool) bool { return strings.HasPrefix(key, partialKey) }) {
+		if found.record.ExistedAt(at) {
+			keys = append(keys, found.key)
+		}
+	}
+	return keys
+}
+
 func (c *Container) FindByMetadata(query string) []string {
 	// TODO: Review
-	c.mu.Lock()
-	defer c.mu.Unlock()
 	var keys []string
-	for key, record := range c.Records {
-		if _, found := record.Metadata.Get(query); found {
+	for _, found := range c.scan(live) {
+		if !found.record.IsDeleted() && MetadataMatches(found.record.Metadata.Copy(), query) {
+			keys = append(keys, found.key)
+		}
+	}
+	return keys
+}
+
+// FindByDocument - keys of documents whose fields match a metadata style query,
+// fields are addressed by dotted path
+func (c *Container) FindByDocument(query string) []string {
+	var keys []string
+	for _, found := range c.scan(live) {
+		record, key := found.record, found.key
+		if record.IsDeleted() {
+			continue
+		}
+		if valueType, _ := record.Metadata.Get(MetadataValueType); valueType != ValueTypeDocument {
+			continue
+		}
+		document, err := record.GetValue(-1)
+		if err != nil {
+			continue
+		}
+		fields, err := FlattenDocument(document)
+		if err == nil && MetadataMatches(fields, query) {
 			keys = append(keys, key)
 		}
 	}
 	return keys
 }
 
+// PurgeDeleted - removes soft deleted records whose tombstone was committed before the cutoff
+func (c *Container) PurgeDeleted(cutoff time.Time) []KVRecord {
+	candidates := c.scan(func(_ string, deleted bool) bool { return deleted })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var purged []KVRecord
+	for _, candidate := range candidates {
+		// the record may have changed since it was scanned
+		key, record := candidate.key, candidate.record
+		if resident, ok := c.Records[key]; ok {
+			record = resident
+		} else if deleted, evicted := c.evictedKeys()[key]; !evicted || !deleted {
+			continue
+		}
+		if !record.IsDeleted() {
+			continue
+		}
+		deletedAt, err := record.Value.GetTimestamp(-1)
+		if err != nil || !deletedAt.Before(cutoff) {
+			continue
+		}
+		c.drop(key)
+		purged = append(purged, record)
+	}
+	return purged
+}
+
 func (c *Container) GetMetadata(key string, metadataKey string) (string, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	record, ok := c.Records[key]
+	record, ok := c.get(key)
 	if !ok {
 		return "", false
 	}
@@ -76,55 +155,69 @@ func (c *Container) GetMetadata(key string, metadataKey string) (string, bool) {
 func (c *Container) SetMetadata(key string, metadataKey string, metadataValue string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	record := c.Records[key]
+	record, _ := c.get(key)
 	record.Metadata.Set(metadataKey, metadataValue)
-	c.Records[key] = record
+	c.put(key, record, true)
 }
 
 func (c *Container) DeleteMetadata(key string, metadataKey string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	record := c.Records[key]
+	record, _ := c.get(key)
 	record.Metadata.Delete(metadataKey)
-	c.Records[key] = record
+	c.put(key, record, true)
 }
 
 func (c *Container) GetAllMetadata(key string) map[string]string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	record := c.Records[key]
+	record, _ := c.get(key)
 	return record.Metadata.GetAll()
 }
 
+// Len - the number of records, resident or evicted
+func (c *Container) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.Records) + len(c.evictedKeys())
+}
+
 func (c *Container) List() []string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	return c.scanKeys()
+}
+
+// live - wants the records that are not soft deleted
+func live(_ string, deleted bool) bool {
+	return !deleted
+}
+
+// scanKeys - every key, resident or evicted, callers hold the lock
+func (c *Container) scanKeys() []string {
 	var keys []string
 	for key := range c.Records {
 		keys = append(keys, key)
 	}
+	for key := range c.evictedKeys() {
+		keys = append(keys, key)
+	}
 	return keys
 }
 
-func (c *Container) BulkLoad(records []KVRecord, logger *log.Logger) error {
+// BulkLoad - adds records loaded from persistence
+func (c *Container) BulkLoad(records []KVRecord) error {
 	c.mu.Lock()
-	logger.Println("BulkLoad() called")
 	defer c.mu.Unlock()
 	for _, record := range records {
-		fmt.Print(".")
-		c.Records[record.Key] = record
-
-		// DEBUG
-		logger.Printf("Loaded record: %s", record.Key)
-		// print number of value entries
-		logger.Printf("Number of value entries: %d", record.Value.Len())
+		c.put(record.StorageKey(), record, false)
 	}
 
 	return nil
 }
 
-func (c *Container) GetAll(logger *log.Logger) []KVRecord {
-	logger.Printf("GetAll() called")
+// GetAll - resident records, evicted records are already persisted
+func (c *Container) GetAll() []KVRecord {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	var records []KVRecord
@@ -134,6 +227,28 @@ func (c *Container) GetAll(logger *log.Logger) []KVRecord {
 	return records
 }
 
+// MetadataMatches - checks metadata against a query, either a bare metadata key
+// that must be present, or comma separated "key:operator:value" entries ANDed together
+func MetadataMatches(metadata map[string]string, query string) bool {
+	if !strings.Contains(query, ":") {
+		_, found := metadata[query]
+		return found
+	}
+
+	for _, entry := range strings.Split(query, ",") {
+		entryParts := strings.SplitN(entry, ":", 3)
+		if len(entryParts) != 3 || !isValidOperator(entryParts[1]) {
+			return false
+		}
+
+		value, found := metadata[entryParts[0]]
+		if !found || !matches(value, entryParts[1], entryParts[2]) {
+			return false
+		}
+	}
+	return true
+}
+
 // Helper Functions
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// Test that scans read evicted records without holding the container lock
+func TestScanLoadsEvictedRecordsUnlocked(t *testing.T) {
+	// Arrange
+	container := NewContainer()
+	stored := make(map[string]KVRecord)
+	blocked := false
+	container.SetMemoryLimit(MemoryLimit{
+		Bytes: 1,
+		Load: func(key string) (KVRecord, error) {
+			done := make(chan struct{})
+			go func() {
+				container.Len()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				blocked = true
+			}
+			return stored[key], nil
+		},
+		Store: func(record KVRecord) error {
+			stored[record.Key] = record
+			return nil
+		},
+	})
+	for _, key := range []string{"a", "b"} {
+		record := NewKVRecord(key, []byte("value"))
+		record.Metadata.Set("owner", "team")
+		container.Set(key, *record)
+	}
+
+	// Act
+	keys := container.FindByMetadata("owner")
+
+	// Assert
+	sort.Strings(keys)
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("found %v instead of a and b", keys)
+	}
+	if blocked {
+		t.Errorf("evicted records were loaded while the container was locked")
+	}
+}
@@ -0,0 +1,269 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaNamespace - reserved key prefix holding value schemas, the schema for a
+// key prefix is stored at SchemaNamespace + prefix
+const SchemaNamespace = "_schema/"
+
+// Errors returned by schema validation
+var (
+	// ErrInvalidSchema - a schema is not well formed
+	ErrInvalidSchema = errors.New("invalid schema")
+	// ErrSchemaViolation - a value does not satisfy the schema of its prefix
+	ErrSchemaViolation = errors.New("value violates schema")
+)
+
+// SchemaViolation - one failing location in a value
+type SchemaViolation struct {
+	// Path - JSON Pointer to the failing part of the value, empty for the whole value
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// ValidationError - a rejected write with every violation found
+type ValidationError struct {
+	Key           string            `json:"key"`
+	Prefix        string            `json:"prefix"`
+	SchemaVersion int               `json:"schema_version"`
+	Violations    []SchemaViolation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	failures := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		failures = append(failures, fmt.Sprintf("%q %s", violation.Path, violation.Message))
+	}
+	return fmt.Sprintf("%v: %v (schema %v version %d): %s", ErrSchemaViolation, e.Key, e.Prefix, e.SchemaVersion, strings.Join(failures, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+// Schema - the supported subset of JSON Schema, a bare JSON string such as
+// "integer" declares just the type
+type Schema struct {
+	Type                 schemaTypes        `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes - accepts "type" as a single name or a list of names
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaTypes{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*t = names
+	return nil
+}
+
+// ParseSchema - parses and checks a schema
+func ParseSchema(raw []byte) (*Schema, error) {
+	schema := &Schema{}
+	var declared string
+	if err := json.Unmarshal(raw, &declared); err == nil {
+		schema.Type = schemaTypes{declared}
+	} else if err := json.Unmarshal(raw, schema); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	if err := schema.compile(""); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Validate - checks a value against the schema, values that are not JSON, or
+// that only fit the schema as text, are checked as strings
+func (s *Schema) Validate(value []byte) []SchemaViolation {
+	var node interface{} = string(value)
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err == nil && !decoder.More() {
+		node = decoded
+	}
+	// plain text such as 42 is still a valid string value
+	if len(s.Type) > 0 && !s.Type.matches(node) && s.Type.matches(string(value)) {
+		node = string(value)
+	}
+
+	var violations []SchemaViolation
+	s.validate(node, "", &violations)
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+	return violations
+}
+
+// Helper Functions
+func (s *Schema) compile(path string) error {
+	for _, name := range s.Type {
+		switch name {
+		case "string", "number", "integer", "boolean", "object", "array", "null":
+		default:
+			return fmt.Errorf("%w: unknown type %q at %q", ErrInvalidSchema, name, path)
+		}
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: pattern at %q: %v", ErrInvalidSchema, path, err)
+		}
+		s.pattern = pattern
+	}
+	for name, property := range s.Properties {
+		if property == nil {
+			return fmt.Errorf("%w: empty property %q at %q", ErrInvalidSchema, name, path)
+		}
+		if err := property.compile(path + "/properties/" + name); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile(path + "/items")
+	}
+	return nil
+}
+
+func (s *Schema) validate(node interface{}, path string, violations *[]SchemaViolation) {
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, SchemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(node) {
+		fail("must be %s", strings.Join(s.Type, " or "))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if documentEqual(node, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of the enumerated values")
+		}
+	}
+
+	switch value := node.(type) {
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			fail("must match %s", s.Pattern)
+		}
+	case json.Number:
+		number, _ := value.Float64()
+		if s.Minimum != nil && number < *s.Minimum {
+			fail("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			fail("must be at most %v", *s.Maximum)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				s.Items.validate(item, path+"/"+strconv.Itoa(i), violations)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				*violations = append(*violations, SchemaViolation{Path: path + "/" + escapePointer(name), Message: "is required"})
+			}
+		}
+		for name, property := range value {
+			if schema, ok := s.Properties[name]; ok {
+				schema.validate(property, path+"/"+escapePointer(name), violations)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*violations = append(*violations, SchemaViolation{Path: path + "/" + escapePointer(name), Message: "is not allowed"})
+			}
+		}
+	}
+}
+
+func (t schemaTypes) matches(node interface{}) bool {
+	for _, name := range t {
+		switch value := node.(type) {
+		case string:
+			if name == "string" {
+				return true
+			}
+		case json.Number:
+			if name == "number" {
+				return true
+			}
+			if number, err := value.Float64(); name == "integer" && err == nil && number == math.Trunc(number) {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -1,17 +1,69 @@
 package types
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 // Server API interface
 type Server interface {
-	GetStatus() (interface{}, error)
-	Start()
-	Stop()
+	GetStatus() (ServerStatus, error)
+	GetConfig() (ConfigReport, error)
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
 	Get(key string) (interface{}, error)
 	Set(key string, value interface{}) error
 	Delete(key string) error
+	Undelete(key string) error
 	SetMetadata(key string, metadataKey string, metadataValue string) error
 	GetMetadata(key string, metadataKey string) (string, error)
 	DeleteMetadata(key string, metadataKey string) error
 	GetAllMetadata(key string) (map[string]string, error)
 	Find(partialKey string) ([]string, error)
 	FindByMetadata(query string) ([]string, error)
+	GetAsOf(key string, asOf time.Time) (interface{}, error)
+	GetAllMetadataAsOf(key string, asOf time.Time) (map[string]string, error)
+	FindAsOf(partialKey string, asOf time.Time) ([]string, error)
+	History(key string) ([]ValueVersion, error)
+	Watch(filter WatchFilter) (<-chan ChangeEvent, func(), error)
+	Increment(key string, delta int64, options CounterOptions) (int64, error)
+	NextSequence(key string) (int64, error)
+	ListPush(key string, values []string, front bool) (int, error)
+	ListPop(key string, front bool) (string, error)
+	ListRange(key string, start int, stop int) ([]string, error)
+	SetAdd(key string, members []string) (int, error)
+	SetRemove(key string, members []string) (int, error)
+	SetMembers(key string) ([]string, error)
+	HashSet(key string, field string, value string) error
+	HashGet(key string, field string) (string, error)
+	HashDelete(key string, field string) error
+	HashGetAll(key string) (map[string]string, error)
+	SetDocument(key string, document []byte) error
+	GetDocument(key string, path string) ([]byte, error)
+	PatchDocument(key string, patch []byte, merge bool) ([]byte, error)
+	FindByDocument(query string) ([]string, error)
+	SetSchema(prefix string, schema []byte) error
+	GetSchema(prefix string, version int) ([]byte, error)
+	DeleteSchema(prefix string) error
+	SetStream(key string, value io.Reader) (int64, error)
+	OpenValue(key string) (io.ReadSeeker, error)
+	GrantLease(ttl time.Duration) (Lease, error)
+	KeepAliveLease(id int64) (Lease, error)
+	GetLease(id int64) (Lease, error)
+	RevokeLease(id int64) error
+	AttachLease(key string, id int64) error
+	Lock(name string, leaseID int64) (int64, error)
+	Unlock(name string, token int64) error
+	DeletePrefix(prefix string, dryRun bool) (BulkResult, error)
+	DeleteByMetadata(query string, dryRun bool) (BulkResult, error)
+	Copy(source string, destination string, options CopyOptions) (BulkResult, error)
+	Rename(source string, destination string, options CopyOptions) (BulkResult, error)
+	ForTenant(tenant string) (Server, error)
+	ListFlags() ([]FeatureFlag, error)
+	GetFlag(name string) (FeatureFlag, error)
+	SetFlag(flag FeatureFlag) error
+	DeleteFlag(name string) error
+	EvaluateFlag(name string, subject string) (bool, error)
+	Usage() (map[string]UsageReport, error)
 }
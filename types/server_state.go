@@ -1,5 +1,7 @@
 package types
 
+import "errors"
+
 // Server State is an enum that represents the state of the server
 type ServerState int
 
@@ -17,3 +19,24 @@ const (
 	// ServerUnknownState - The server is in an unknown state
 	ServerUnknownState
 )
+
+// ErrInvalidTransition - the server cannot move from its current state to the requested one
+var ErrInvalidTransition = errors.New("invalid server state transition")
+
+// String - the name of the state
+func (state ServerState) String() string {
+	switch state {
+	case ServerStarting:
+		return "Starting"
+	case ServerRunning:
+		return "Running"
+	case ServerStopping:
+		return "Stopping"
+	case ServerStopped:
+		return "Stopped"
+	case ServerError:
+		return "Error"
+	default:
+		return "Unknown"
+	}
+}
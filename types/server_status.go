@@ -0,0 +1,37 @@
+package types
+
+// ServerStatus - a snapshot of a server
+type ServerStatus struct {
+	State   string `json:"state"`
+	Version string `json:"version"`
+	// UptimeSeconds - time since the server started running, 0 before
+	UptimeSeconds int64 `json:"uptime_seconds"`
+	// Records - records held, evicted ones included
+	Records     int               `json:"records"`
+	Memory      MemoryStatus      `json:"memory"`
+	Persistence PersistenceStatus `json:"persistence"`
+	// Listeners - addresses of the APIs by protocol
+	Listeners   map[string]string `json:"listeners"`
+	ClusterRole string            `json:"cluster_role"`
+	// Cache - counters of the memory limit, none when unlimited
+	Cache *CacheStats `json:"cache,omitempty"`
+}
+
+// MemoryStatus - memory of the process
+type MemoryStatus struct {
+	// AllocBytes - bytes of live heap objects
+	AllocBytes uint64 `json:"alloc_bytes"`
+	// SysBytes - bytes obtained from the operating system
+	SysBytes   uint64 `json:"sys_bytes"`
+	GCCycles   uint32 `json:"gc_cycles"`
+	Goroutines int    `json:"goroutines"`
+}
+
+// PersistenceStatus - the persistence driver and its latest sync
+type PersistenceStatus struct {
+	Driver string `json:"driver"`
+	// LastSync - when the latest sync ended, RFC 3339, empty before the first
+	LastSync       string `json:"last_sync,omitempty"`
+	LastSyncMillis int64  `json:"last_sync_millis"`
+	LastSyncError  string `json:"last_sync_error,omitempty"`
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidKey - the key could name a record of another tenant, or the
+// metadata key is reserved for the server
+var ErrInvalidKey = errors.New("invalid key")
+
+// ErrKeyNotFound - the key, or the metadata key of a record, has no live value
+var ErrKeyNotFound = errors.New("key not found")
+
+// DefaultTenant - tenant of requests that name none, and of records written
+// before tenants existed
+const DefaultTenant = "default"
+
+// tenantSeparator - joins tenant and key in storage keys, default tenant keys
+// are stored as they are so existing data keeps its keys
+const tenantSeparator = "\x00"
+
+// maximum length of a tenant name
+const maxTenantLength = 64
+
+// ValidateTenant - tenant names are short and limited to lower case letters,
+// digits, dashes and underscores, so every tenant can be given its own quota
+// where setting names are not case sensitive and dots separate sections
+func ValidateTenant(tenant string) error {
+	if tenant == "" || len(tenant) > maxTenantLength {
+		return fmt.Errorf("tenant must be 1 to %d characters", maxTenantLength)
+	}
+	for _, r := range tenant {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return fmt.Errorf("tenant %q contains invalid character %q", tenant, r)
+		}
+	}
+	return nil
+}
+
+// ValidateKey - keys must not contain the tenant separator, a NUL character,
+// which would let a key of one tenant name the record of another
+func ValidateKey(key string) error {
+	if strings.Contains(key, tenantSeparator) {
+		return fmt.Errorf("%w: %q contains a NUL character", ErrInvalidKey, key)
+	}
+	return nil
+}
+
+// StorageKey - the key a tenant's record is stored under, unique across tenants
+func StorageKey(tenant string, key string) string {
+	if tenant == "" || tenant == DefaultTenant {
+		return key
+	}
+	return tenant + tenantSeparator + key
+}
+
+// SplitStorageKey - the tenant and key a storage key was built from
+func SplitStorageKey(storageKey string) (tenant string, key string) {
+	if i := strings.Index(storageKey, tenantSeparator); i >= 0 {
+		return storageKey[:i], storageKey[i+len(tenantSeparator):]
+	}
+	return DefaultTenant, storageKey
+}
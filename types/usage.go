@@ -0,0 +1,49 @@
+package types
+
+import "errors"
+
+// ErrQuotaExceeded - a write would take a tenant past one of its limits
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// TenantUsage - resources a tenant consumes
+type TenantUsage struct {
+	// Keys - live keys, soft deleted keys only account for their history
+	Keys int64 `json:"keys"`
+	// ValueBytes - latest values plus metadata of live keys
+	ValueBytes int64 `json:"value_bytes"`
+	// HistoryBytes - superseded versions kept for history
+	HistoryBytes int64 `json:"history_bytes"`
+}
+
+// Add - the sum of two usages
+func (u TenantUsage) Add(other TenantUsage) TenantUsage {
+	return TenantUsage{
+		Keys:         u.Keys + other.Keys,
+		ValueBytes:   u.ValueBytes + other.ValueBytes,
+		HistoryBytes: u.HistoryBytes + other.HistoryBytes,
+	}
+}
+
+// Sub - the difference of two usages
+func (u TenantUsage) Sub(other TenantUsage) TenantUsage {
+	return TenantUsage{
+		Keys:         u.Keys - other.Keys,
+		ValueBytes:   u.ValueBytes - other.ValueBytes,
+		HistoryBytes: u.HistoryBytes - other.HistoryBytes,
+	}
+}
+
+// TenantQuota - limits of a tenant, zero for unlimited
+type TenantQuota struct {
+	MaxKeys         int64 `json:"max_keys"`
+	MaxValueBytes   int64 `json:"max_value_bytes"`
+	MaxHistoryBytes int64 `json:"max_history_bytes"`
+	// MaxValueSize - largest single value
+	MaxValueSize int64 `json:"max_value_size"`
+}
+
+// UsageReport - usage of a tenant next to its limits
+type UsageReport struct {
+	Usage TenantUsage `json:"usage"`
+	Quota TenantQuota `json:"quota"`
+}
@@ -1,18 +1,35 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 )
 
+// ErrBeforeHistory - returned when a point in time read asks for a moment
+// older than the oldest retained version
+var ErrBeforeHistory = errors.New("requested time precedes retained history")
+
 type ValuesContainer struct {
 	mu    sync.Mutex
 	Value [][]byte
+	// Timestamps - commit time of each entry in Value, index aligned
+	Timestamps []time.Time
+	// Tombstones - marks entries in Value written by a soft delete, index aligned
+	Tombstones []bool
+
+	// sized - versions already added to bytes, versions are only appended so
+	// Size only adds the ones appended since it last ran
+	sized int
+	bytes int64
 }
 
 func NewValuesContainer(value []byte) *ValuesContainer {
 	container := &ValuesContainer{
-		Value: make([][]byte, 0),
+		Value:      make([][]byte, 0),
+		Timestamps: make([]time.Time, 0),
+		Tombstones: make([]bool, 0),
 	}
 	container.Set(value)
 	return container
@@ -35,13 +52,112 @@ func (c *ValuesContainer) Get(version int) ([]byte, error) {
 }
 
 func (c *ValuesContainer) Set(value []byte) int {
+	return c.SetAt(value, time.Now().UTC())
+}
+
+// SetAt - appends a value committed at the given time, used when loading
+// history back from persistence
+func (c *ValuesContainer) SetAt(value []byte, committedAt time.Time) int {
+	return c.appendVersion(value, committedAt, false)
+}
+
+// SetTombstone - appends a tombstone version marking the value as deleted
+func (c *ValuesContainer) SetTombstone() int {
+	return c.SetTombstoneAt(time.Now().UTC())
+}
+
+// SetTombstoneAt - appends a tombstone committed at the given time
+func (c *ValuesContainer) SetTombstoneAt(committedAt time.Time) int {
+	return c.appendVersion(nil, committedAt, true)
+}
+
+// IsTombstone - checks if a version is a tombstone, -1 for the last one
+func (c *ValuesContainer) IsTombstone(version int) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.Value = append(c.Value, value)
+	if version < 0 {
+		version = len(c.Value) - 1
+	}
+	if version < 0 || version > len(c.Tombstones)-1 {
+		return false
+	}
+	return c.Tombstones[version]
+}
 
-	// get the version of the value
-	version := len(c.Value) - 1
-	return version
+// GetLastLive - returns the latest value that is not a tombstone
+func (c *ValuesContainer) GetLastLive() ([]byte, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for version := len(c.Value) - 1; version >= 0; version-- {
+		if version < len(c.Tombstones) && c.Tombstones[version] {
+			continue
+		}
+		return c.Value[version], version, nil
+	}
+	return nil, -1, fmt.Errorf("no live version retained")
+}
+
+// GetAt - returns the value, and its version, that was current at the given instant
+func (c *ValuesContainer) GetAt(at time.Time) ([]byte, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	version := c.versionAt(at)
+	if version < 0 {
+		return nil, -1, c.beforeHistory(at)
+	}
+
+	return c.Value[version], version, nil
+}
+
+// GetTimestamp - returns the commit time of a version, -1 for the last one
+func (c *ValuesContainer) GetTimestamp(version int) (time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version < 0 {
+		version = len(c.Timestamps) - 1
+	}
+
+	if version < 0 || version > len(c.Timestamps)-1 {
+		return time.Time{}, fmt.Errorf("version %d is out of range", version)
+	}
+
+	return c.Timestamps[version], nil
+}
+
+// FirstTimestamp - returns the commit time of the oldest retained version
+func (c *ValuesContainer) FirstTimestamp() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.Timestamps) == 0 {
+		return time.Time{}
+	}
+	return c.Timestamps[0]
+}
+
+// History - returns a copy of all retained versions, their commit times and tombstone marks
+func (c *ValuesContainer) History() ([][]byte, []time.Time, []bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make([][]byte, len(c.Value))
+	copy(values, c.Value)
+	timestamps := make([]time.Time, len(c.Value))
+	copy(timestamps, c.Timestamps)
+	tombstones := make([]bool, len(c.Value))
+	copy(tombstones, c.Tombstones)
+	return values, timestamps, tombstones
+}
+
+// ValueVersion - one retained version of a value, as listed by a history read
+type ValueVersion struct {
+	Version   int       `json:"version"`
+	Value     []byte    `json:"value,omitempty"`
+	Size      int64     `json:"size"`
+	Timestamp time.Time `json:"timestamp"`
+	// Deleted - the version is a soft delete tombstone
+	Deleted bool `json:"deleted"`
+	// Chunked - the value is stored as chunks and left out of the listing
+	Chunked bool `json:"chunked"`
 }
 
 func (c *ValuesContainer) GetVersion() int {
@@ -50,8 +166,64 @@ func (c *ValuesContainer) GetVersion() int {
 	return len(c.Value) - 1
 }
 
+// Size - total bytes of every version, and of the latest version
+func (c *ValuesContainer) Size() (total int64, latest int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// versions replaced rather than appended are counted again
+	if c.sized > len(c.Value) {
+		c.sized, c.bytes = 0, 0
+	}
+	for ; c.sized < len(c.Value); c.sized++ {
+		c.bytes += int64(len(c.Value[c.sized]))
+	}
+	if len(c.Value) > 0 {
+		latest = int64(len(c.Value[len(c.Value)-1]))
+	}
+	return c.bytes, latest
+}
+
 func (c *ValuesContainer) Len() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return len(c.Value)
 }
+
+// Helper Functions
+func (c *ValuesContainer) appendVersion(value []byte, committedAt time.Time, tombstone bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// containers built before tombstones existed need their marks backfilled
+	for len(c.Tombstones) < len(c.Value) {
+		c.Tombstones = append(c.Tombstones, false)
+	}
+	c.Value = append(c.Value, value)
+	c.Timestamps = append(c.Timestamps, committedAt)
+	c.Tombstones = append(c.Tombstones, tombstone)
+
+	// get the version of the value
+	version := len(c.Value) - 1
+	return version
+}
+
+// versionAt - index of the last version committed at or before `at`, -1 if none
+func (c *ValuesContainer) versionAt(at time.Time) int {
+	version := -1
+	for i, committedAt := range c.Timestamps {
+		if committedAt.After(at) {
+			break
+		}
+		version = i
+	}
+	return version
+}
+
+func (c *ValuesContainer) beforeHistory(at time.Time) error {
+	if len(c.Timestamps) == 0 {
+		return fmt.Errorf("%w: no versions retained", ErrBeforeHistory)
+	}
+	return fmt.Errorf("%w: %s is before %s",
+		ErrBeforeHistory,
+		at.UTC().Format(time.RFC3339),
+		c.Timestamps[0].UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Test point in time reads over the version history
+func TestValuesContainerGetAt(t *testing.T) {
+	// Arrange
+	start := time.Date(2023, 4, 1, 14, 0, 0, 0, time.UTC)
+	container := &ValuesContainer{}
+	container.SetAt([]byte("v0"), start)
+	container.SetAt([]byte("v1"), start.Add(2*time.Minute))
+	container.SetAt([]byte("v2"), start.Add(5*time.Minute))
+
+	cases := []struct {
+		at      time.Time
+		value   string
+		version int
+	}{
+		{start, "v0", 0},
+		{start.Add(time.Minute), "v0", 0},
+		{start.Add(2 * time.Minute), "v1", 1},
+		{start.Add(time.Hour), "v2", 2},
+	}
+
+	for _, c := range cases {
+		// Act
+		value, version, err := container.GetAt(c.at)
+
+		// Assert
+		if err != nil {
+			t.Errorf("GetAt(%v) returned error %v", c.at, err)
+			continue
+		}
+		if string(value) != c.value || version != c.version {
+			t.Errorf("GetAt(%v) = %s@%d instead of %s@%d", c.at, value, version, c.value, c.version)
+		}
+	}
+}
+
+// Test that reads before the oldest version are rejected
+func TestValuesContainerGetAtBeforeHistory(t *testing.T) {
+	// Arrange
+	start := time.Date(2023, 4, 1, 14, 0, 0, 0, time.UTC)
+	container := &ValuesContainer{}
+	container.SetAt([]byte("v0"), start)
+
+	// Act
+	_, _, err := container.GetAt(start.Add(-time.Second))
+
+	// Assert
+	if !errors.Is(err, ErrBeforeHistory) {
+		t.Errorf("expected ErrBeforeHistory, got %v", err)
+	}
+}
+
+// Test metadata replay at a point in time
+func TestMetadataContainerGetAllAt(t *testing.T) {
+	// Arrange
+	container := NewMetadataContainer()
+	container.Set("owner", "alice")
+	between := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	container.Set("owner", "bob")
+	container.Delete("owner")
+
+	// Act
+	before := container.GetAllAt(between)
+	after := container.GetAllAt(time.Now().UTC())
+
+	// Assert
+	if before["owner"] != "alice" {
+		t.Errorf("owner was %q instead of alice", before["owner"])
+	}
+	if _, found := after["owner"]; found {
+		t.Errorf("owner should have been deleted")
+	}
+}
+
+// Test that sizes follow appended versions and tombstones
+func TestValuesContainerSize(t *testing.T) {
+	// Arrange
+	container := NewValuesContainer([]byte("abc"))
+	container.Size()
+
+	// Act
+	container.Set([]byte("de"))
+	container.SetTombstone()
+	container.Set([]byte("f"))
+	total, latest := container.Size()
+
+	// Assert
+	if total != 6 || latest != 1 {
+		t.Errorf("size is %d with latest %d instead of 6 and 1", total, latest)
+	}
+}
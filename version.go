@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+)
+
+// Build information, set at link time with
+// -ldflags "-X main.version=<version> -X main.commit=<commit> -X main.buildDate=<date>"
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
+// printVersion - the version command
+func printVersion(args []string) error {
+	flags := flag.NewFlagSet("version", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Printf("simple-kv %v (commit %v, built %v, %v %v/%v)\n",
+		version, commit, buildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	return nil
+}